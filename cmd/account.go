@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"netsuite-cli/internal/auth"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	accountIDFlag             string
+	accountAuthIDFlag         string
+	accountDefaultFlag        bool
+	accountClientIDFlag       string
+	accountCertificateIDFlag  string
+	accountPrivateKeyPathFlag string
+)
+
+// accountCmd represents the account command
+var accountCmd = &cobra.Command{
+	Use:   "account",
+	Short: "Manage NetSuite account profiles",
+	Long: `Manage the NetSuite account profiles stored in the user configuration. Each
+profile maps a label to an account ID and the SuiteCloud authentication ID created
+with 'suitecloud account:setup'. The default profile is used by the deploy,
+validate and push commands when the project does not select an environment.`,
+}
+
+// accountListCmd represents the account list command
+var accountListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the account profiles",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAccountList()
+	},
+}
+
+// accountUseCmd represents the account use command
+var accountUseCmd = &cobra.Command{
+	Use:   "use <label>",
+	Short: "Set the default account profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAccountUse(args[0])
+	},
+}
+
+// accountAddCmd represents the account add command
+var accountAddCmd = &cobra.Command{
+	Use:   "add [label]",
+	Short: "Add or update an account profile",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAccountAdd(args)
+	},
+}
+
+// accountLoginCmd represents the account login command, replaced by auth login
+var accountLoginCmd = &cobra.Command{
+	Use:        "login [label]",
+	Short:      "Request an OAuth 2.0 access token for an account profile",
+	Deprecated: "use 'netsuite-cli auth login' instead",
+	Args:       cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		label := ""
+		if len(args) > 0 {
+			label = args[0]
+		}
+		return runAuthLogin(label)
+	},
+}
+
+func init() {
+	accountAddCmd.Flags().StringVar(&accountIDFlag, "account-id", "", "NetSuite account ID, e.g. 1234567_SB1")
+	accountAddCmd.Flags().StringVar(&accountAuthIDFlag, "authid", "", "SuiteCloud authentication ID")
+	accountAddCmd.Flags().BoolVar(&accountDefaultFlag, "default", false, "Make this the default account profile")
+	accountAddCmd.Flags().StringVar(&accountClientIDFlag, "client-id", "", "Integration record client ID for OAuth 2.0 machine-to-machine authentication")
+	accountAddCmd.Flags().StringVar(&accountCertificateIDFlag, "certificate-id", "", "Certificate ID from the OAuth 2.0 client credentials setup")
+	accountAddCmd.Flags().StringVar(&accountPrivateKeyPathFlag, "private-key", "", "Path to the PEM private key of the certificate")
+
+	accountCmd.AddCommand(accountListCmd)
+	accountCmd.AddCommand(accountUseCmd)
+	accountCmd.AddCommand(accountAddCmd)
+	accountCmd.AddCommand(accountLoginCmd)
+	rootCmd.AddCommand(accountCmd)
+}
+
+// loadUserConfig loads the user configuration, returning an empty one when none exists yet.
+func loadUserConfig() (*UserConfig, error) {
+	userConfig, err := LoadUserConfig()
+	if err != nil {
+		return nil, configError(err)
+	}
+	if userConfig == nil {
+		userConfig = &UserConfig{}
+	}
+	return userConfig, nil
+}
+
+// runAccountList prints the account profiles.
+func runAccountList() error {
+	userConfig, err := loadUserConfig()
+	if err != nil {
+		return err
+	}
+	if jsonFlag {
+		type account struct {
+			Label     string `json:"label"`
+			AccountID string `json:"accountId"`
+			AuthID    string `json:"authId"`
+			Default   bool   `json:"default"`
+		}
+		accounts := make([]account, 0, len(userConfig.Accounts))
+		for _, profile := range userConfig.Accounts {
+			accounts = append(accounts, account{Label: profile.Label, AccountID: profile.AccountID, AuthID: profile.AuthID, Default: profile.Default})
+		}
+		setJSONResult(accounts)
+		return nil
+	}
+	if len(userConfig.Accounts) == 0 {
+		fmt.Println("No account profiles defined. Use 'netsuite-cli account add' to add one.")
+		return nil
+	}
+
+	for _, account := range userConfig.Accounts {
+		marker := " "
+		if account.Default {
+			marker = "*"
+		}
+		fmt.Printf("%s %s\t%s\t%s\n", marker, account.Label, account.AccountID, account.AuthID)
+	}
+	return nil
+}
+
+// runAccountUse marks the given account profile as the default.
+func runAccountUse(label string) error {
+	userConfig, err := loadUserConfig()
+	if err != nil {
+		return err
+	}
+	account := userConfig.FindAccount(label)
+	if account == nil {
+		return fmt.Errorf("account profile '%s' not found", label)
+	}
+
+	for i := range userConfig.Accounts {
+		userConfig.Accounts[i].Default = false
+	}
+	account.Default = true
+
+	if err := SaveUserConfig(userConfig); err != nil {
+		return err
+	}
+	logInfo("Default account profile set to '%s'", account.Label)
+	return nil
+}
+
+// runAccountAdd adds a new account profile or updates an existing one.
+func runAccountAdd(args []string) error {
+	userConfig, err := loadUserConfig()
+	if err != nil {
+		return err
+	}
+	reader := bufio.NewReader(os.Stdin)
+
+	label := ""
+	if len(args) > 0 {
+		label = strings.TrimSpace(args[0])
+	}
+	if label == "" {
+		label, err = promptLine(reader, "Enter profile label: ")
+		if err != nil {
+			return err
+		}
+	}
+	if label == "" {
+		return errors.New("profile label is required")
+	}
+
+	accountID := strings.TrimSpace(accountIDFlag)
+	if accountID == "" {
+		accountID, err = promptLine(reader, "Enter account ID (e.g., 1234567_SB1): ")
+		if err != nil {
+			return err
+		}
+	}
+	if accountID == "" {
+		return errors.New("account ID is required")
+	}
+
+	authID := strings.TrimSpace(accountAuthIDFlag)
+	if authID == "" {
+		authID, err = promptLine(reader, fmt.Sprintf("Enter SuiteCloud authentication ID (default: %s): ", label))
+		if err != nil {
+			return err
+		}
+	}
+	if authID == "" {
+		authID = label
+	}
+
+	account := userConfig.FindAccount(label)
+	if account == nil {
+		userConfig.Accounts = append(userConfig.Accounts, AccountProfile{Label: label})
+		account = &userConfig.Accounts[len(userConfig.Accounts)-1]
+	}
+	account.AccountID = accountID
+	account.AuthID = authID
+	if accountClientIDFlag != "" {
+		account.ClientID = strings.TrimSpace(accountClientIDFlag)
+	}
+	if accountCertificateIDFlag != "" {
+		account.CertificateID = strings.TrimSpace(accountCertificateIDFlag)
+	}
+	if accountPrivateKeyPathFlag != "" {
+		keyPath, err := filepath.Abs(strings.TrimSpace(accountPrivateKeyPathFlag))
+		if err != nil {
+			return fmt.Errorf("error resolving private key path: %v", err)
+		}
+		account.PrivateKeyPath = keyPath
+	}
+
+	if accountDefaultFlag || userConfig.DefaultAccount() == nil {
+		for i := range userConfig.Accounts {
+			userConfig.Accounts[i].Default = false
+		}
+		account.Default = true
+	}
+
+	if err := SaveUserConfig(userConfig); err != nil {
+		return err
+	}
+	logInfo("Account profile '%s' saved", account.Label)
+	return nil
+}
+
+// resolveAccount returns the account profile with the given label, or the default profile when
+// label is empty.
+func resolveAccount(label string) (*AccountProfile, error) {
+	userConfig, err := LoadUserConfig()
+	if err != nil {
+		return nil, err
+	}
+	if userConfig == nil || len(userConfig.Accounts) == 0 {
+		return nil, fmt.Errorf("no account profiles defined, use 'netsuite-cli account add' to add one")
+	}
+
+	if label == "" {
+		account := userConfig.DefaultAccount()
+		if account == nil {
+			return nil, fmt.Errorf("no default account profile, use 'netsuite-cli account use <label>' to select one")
+		}
+		return account, nil
+	}
+
+	account := userConfig.FindAccount(label)
+	if account == nil {
+		return nil, fmt.Errorf("account profile '%s' not found", label)
+	}
+	return account, nil
+}
+
+// newAuthClient returns an OAuth 2.0 client for the account profile, caching tokens in the
+// credential store. A private key imported with 'auth login --private-key' takes precedence
+// over the key file of the profile.
+func newAuthClient(account *AccountProfile) (*auth.Client, error) {
+	creds := auth.Credentials{
+		AccountID:      account.AccountID,
+		ClientID:       account.ClientID,
+		CertificateID:  account.CertificateID,
+		PrivateKeyPath: account.PrivateKeyPath,
+		PrivateKey:     storedPrivateKey(account),
+	}
+	if err := creds.Validate(); err != nil {
+		return nil, fmt.Errorf("account profile '%s' has no OAuth 2.0 credentials (%v), use 'netsuite-cli account add %s --client-id ... --certificate-id ...' and 'netsuite-cli auth login %s --private-key ...'", account.Label, err, account.Label, account.Label)
+	}
+
+	removeLegacyTokenCache()
+	return auth.NewClient(creds, credentialStore()), nil
+}
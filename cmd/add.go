@@ -2,172 +2,217 @@ package cmd
 
 import (
 	"bufio"
-	"bytes"
-	"embed"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strconv"
 	"strings"
-	"text/template"
 	"time"
-	"unicode"
+
+	"netsuite-cli/pkg/scaffold"
 
 	"github.com/spf13/cobra"
 )
 
-var scriptTypeConfigs = []struct {
-	name  string
-	usage string
-}{
-	{"bundle", "Bundle scripts can be of type customization or configuration, allowing you to group related scripts together"},
-	{"client", "Client scripts are executed by predefined event triggers in the client browser, enabling you to customize the user interface"},
-	{"formclient", "Form Client scripts are attached to forms, allowing you to add custom logic and functionality to form submissions"},
-	{"mapreduce", "Map/Reduce scripts are designed to handle large amounts of data, making them ideal for data processing and analysis tasks"},
-	{"massupdate", "Mass update scripts allow you to programmatically perform custom updates to fields that are not available through general mass updates"},
-	{"portlet", "Portlet scripts are run on the server and are rendered in the NetSuite dashboard, providing a way to customize the dashboard with custom functionality"},
-	{"restlet", "RESTlet is a SuiteScript that you make available for other applications to call, enabling integration with external services and systems"},
-	{"scheduled", "Scheduled scripts are executed (processed) with SuiteCloud Processors, allowing you to automate tasks and processes at specific times or intervals"},
-	{"suitelet", "Suitelets are extensions of the SuiteScript API that allow you to build custom NetSuite pages and backend logic"},
-	{"userevent", "User event scripts are executed when users perform actions on records, such as create, load, update, copy, delete, or submit, enabling you to automate tasks"},
-	{"workflowaction", "Workflow action scripts are good for custom logic or managing sublist fields which are not currently available"},
-	{"common", "Holds TypeScript definitions for your scripts, providing a way to define the structure and types of your code"},
-}
-
-// ScriptTemplates holds the content for TypeScript and XML templates.
-type ScriptTemplates struct {
-	TypeScript string
-	XML        string
-}
-
-// getRecordType maps a script type to its corresponding NetSuite record type.
-func getRecordType(scriptType string) string {
-	recordTypeMap := map[string]string{
-		"client":         "clientscript",
-		"mapreduce":      "mapreducescript",
-		"massupdate":     "massupdatescript",
-		"portlet":        "portlet",
-		"restlet":        "restlet",
-		"scheduled":      "scheduledscript",
-		"suitelet":       "suitelet",
-		"userevent":      "usereventscript",
-		"workflowaction": "workflowactionscript",
-	}
-	if recordType, ok := recordTypeMap[scriptType]; ok {
-		return recordType
-	}
-	return ""
-}
-
-// toSnakeCase converts a string to snake_case.
-func toSnakeCase(s string) string {
-	if s == "" {
-		return ""
+// projectTemplates returns the script templates, with the templates ejected into the project's
+// .netsuite-cli-templates/ folder, the overrides in its templates/ folder, the template pack
+// pinned by the project and the user's templates folder taking precedence over the embedded
+// templates, in that order.
+func projectTemplates() scaffold.Templates {
+	var dirs []string
+	if cwd, err := os.Getwd(); err == nil {
+		dirs = append(dirs, filepath.Join(cwd, ejectedTemplatesDir), filepath.Join(cwd, "templates"))
 	}
-
-	var result strings.Builder
-	runes := []rune(s)
-	prevLower := false
-
-	for i, r := range runes {
-		if unicode.IsUpper(r) {
-			if i > 0 && prevLower {
-				result.WriteRune('_')
-			}
-			result.WriteRune(unicode.ToLower(r))
-			prevLower = false
-		} else if unicode.IsLower(r) || unicode.IsDigit(r) {
-			result.WriteRune(r)
-			prevLower = true
-		} else {
-			if i > 0 && result.Len() > 0 && result.String()[result.Len()-1] != '_' {
-				result.WriteRune('_')
-			}
-			prevLower = false
+	var dates DateFormat
+	if config, err := LoadConfig(); err == nil {
+		if dir := pinnedTemplatePackDir(config); dir != "" {
+			dirs = append(dirs, dir)
 		}
+		dates = config.Dates()
 	}
-
-	snake := result.String()
-	re := regexp.MustCompile(`_+`)
-	snake = re.ReplaceAllString(snake, "_")
-	snake = strings.Trim(snake, "_")
-
-	return strings.ToLower(snake)
-}
-
-var templateFS embed.FS
-
-// GetTemplates retrieves the TypeScript and XML templates for a given script type.
-func GetTemplates(scriptType string) ScriptTemplates {
-	tsPath := fmt.Sprintf("templates/%s.ts.tmpl", scriptType)
-	xmlPath := fmt.Sprintf("templates/%s.xml.tmpl", scriptType)
-
-	tsContent, err := templateFS.ReadFile(tsPath)
-	if err != nil {
-		fmt.Printf("Warning: Could not read TypeScript template for %s: %v\n", scriptType, err)
-		tsContent = []byte("")
+	if userDir, err := UserConfigDir(); err == nil {
+		dirs = append(dirs, filepath.Join(userDir, "templates"))
 	}
+	logDebug("template folders: %s", strings.Join(dirs, ", "))
+	return scaffold.Templates{Dirs: dirs, Dates: dates, Logf: logDebug}
+}
 
-	xmlContent, err := templateFS.ReadFile(xmlPath)
-	if err != nil {
-		fmt.Printf("Warning: Could not read XML template for %s: %v\n", scriptType, err)
-		xmlContent = []byte("")
-	}
+// readTemplate reads a template file, preferring project and user overrides over the embedded templates.
+func readTemplate(name string) ([]byte, error) {
+	return projectTemplates().Read(name)
+}
 
-	return ScriptTemplates{
-		TypeScript: string(tsContent),
-		XML:        string(xmlContent),
+// containsString reports whether values contains s.
+func containsString(values []string, s string) bool {
+	for _, value := range values {
+		if value == s {
+			return true
+		}
 	}
+	return false
 }
 
+var (
+	descriptionFlag      string
+	recordTypeFlag       string
+	folderFlag           string
+	paramFlags           []string
+	entryPointsFlag      []string
+	typedStagesFlag      bool
+	scheduleFlag         string
+	startTimeFlag        string
+	daysFlag             string
+	intervalFlag         string
+	variantFlag          string
+	yesFlag              bool
+	forceFlag            bool
+	skipTestFlag         bool
+	noDeployXMLFlag      bool
+	langFlag             string
+	withSearchFlag       bool
+	searchFilters        []string
+	returnTypeFlag       string
+	returnRecordTypeFlag string
+	formFlag             string
+	audienceFlag         string
+	deployStatusFlag     string
+	logLevelFlag         string
+	rolesFlag            []string
+)
+
 // addCmd represents the add command
 var addCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add a new NetSuite script",
-	Long:  `Generate a new NetSuite script from a template.`,
+	Long: `Generate a new NetSuite script from a template. With --spec, generate every script
+listed in a JSON or YAML spec file in one run.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if specFlag == "" {
+			return cmd.Help()
+		}
+		return runAddSpec(specFlag)
+	},
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if !dryRunFlag {
+			beginGeneration(strings.Join(append([]string{cmd.CommandPath()}, args...), " "))
+		}
+		return runAddHook(cmd, "preAdd", nil)
+	},
+	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+		generation := currentGeneration
+		if err := commitGeneration("."); err != nil {
+			logWarn("Failed to update %s: %v", lockFileName, err)
+		}
+		if generation == nil || len(generation.Files) == 0 {
+			return nil
+		}
+		files := make([]string, len(generation.Files))
+		for i, file := range generation.Files {
+			files[i] = file.Path
+		}
+		return runAddHook(cmd, "postAdd", files)
+	},
+}
+
+// runAddHook runs the preAdd or postAdd hook of the project for an add command. Nothing is run
+// for 'add' without --spec, which only prints the help, or outside a project, where the command
+// fails by itself.
+func runAddHook(cmd *cobra.Command, name string, files []string) error {
+	if cmd.Parent() == rootCmd && specFlag == "" {
+		return nil
+	}
+	config, err := LoadConfig()
+	if err != nil {
+		return nil
+	}
+	return runLifecycleHook(config, name, HookData{
+		Command: strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" "),
+		Files:   files,
+	})
 }
 
 func init() {
+	addCmd.PersistentFlags().StringVarP(&descriptionFlag, "description", "d", "", "Script description")
+	addCmd.PersistentFlags().StringVarP(&recordTypeFlag, "record-type", "r", "", "Record type for userevent, workflowaction and massupdate scripts (e.g., CUSTOMER)")
+	addCmd.PersistentFlags().StringVarP(&folderFlag, "folder", "f", "", "Folder under SuiteScripts to place the script in (use '/' for the root)")
+	addCmd.PersistentFlags().StringArrayVar(&paramFlags, "param", nil, "Script parameter in the form name:type[:label] (repeatable)")
+	addCmd.PersistentFlags().StringSliceVarP(&entryPointsFlag, "entrypoints", "e", nil, "Comma separated entry points to generate (e.g., beforeLoad,afterSubmit)")
+	addCmd.PersistentFlags().StringArrayVar(&varFlags, "var", nil, "Template variable in the form name=value (repeatable)")
+	addCmd.PersistentFlags().StringVar(&langFlag, "lang", "", "Language of the generated script: ts or js (default: the project language)")
+	addCmd.PersistentFlags().BoolVar(&typedStagesFlag, "typed", false, "Generate typed interfaces for map/reduce stage payloads")
+	addCmd.PersistentFlags().StringVar(&scheduleFlag, "schedule", "", "Recurrence for scheduled scripts: none, single, daily, weekly or minutes")
+	addCmd.PersistentFlags().StringVar(&startTimeFlag, "start-time", "", "Schedule start time in UTC (HH:MM)")
+	addCmd.PersistentFlags().StringVar(&daysFlag, "days", "", "Comma separated weekdays for weekly schedules (e.g., mon,wed)")
+	addCmd.PersistentFlags().StringVar(&intervalFlag, "interval", "", "Repeat interval in minutes for 'minutes' schedules")
+	addCmd.PersistentFlags().BoolVar(&withSearchFlag, "with-search", false, "Also generate a companion saved search providing the default filter of massupdate scripts")
+	addCmd.PersistentFlags().StringArrayVar(&searchFilters, "search-filter", nil, "Filter of the companion saved search in the form field:operator[:value,...] (repeatable)")
+	addCmd.PersistentFlags().StringVar(&returnTypeFlag, "return-type", "", "Return type of workflowaction scripts: none, "+strings.Join(scaffold.ReturnTypeNames(), ", "))
+	addCmd.PersistentFlags().StringVar(&returnRecordTypeFlag, "return-record-type", "", "Record type returned by workflowaction scripts with a select return type (e.g., -2 for customer)")
+	addCmd.PersistentFlags().StringVar(&audienceFlag, "audience", "", "Deployment audience of suitelet, restlet and portlet scripts: allroles, allemployees, allpartners or roles")
+	addCmd.PersistentFlags().StringSliceVar(&rolesFlag, "roles", nil, "Comma separated roles of the roles audience: role names, custom role script IDs or internal IDs (e.g., ADMINISTRATOR,customrole_ap)")
+	addCmd.PersistentFlags().StringVar(&deployStatusFlag, "deploy-status", "", "Deployment status: TESTING or RELEASED (default: the project deployStatus, else the template status)")
+	addCmd.PersistentFlags().StringVar(&logLevelFlag, "log-level", "", "Deployment log level: DEBUG, AUDIT, ERROR or EMERGENCY (default: the project logLevel, else the template level)")
+	addCmd.PersistentFlags().StringVar(&formFlag, "form", "", "Custom form (script ID) or record type whose form formclient scripts are attached to")
+	addCmd.PersistentFlags().StringVar(&variantFlag, "variant", "", "Template variant: generic, form, list or json for suitelet, generic or task for restlet, html, list, links or form for portlet scripts")
+	addCmd.PersistentFlags().BoolVarP(&yesFlag, "yes", "y", false, "Accept defaults and skip all interactive prompts")
+	addCmd.PersistentFlags().BoolVar(&skipTestFlag, "skip-test", false, "Do not generate a unit test stub when the project is set up for tests")
+	addCmd.PersistentFlags().BoolVar(&noDeployXMLFlag, "no-deployxml", false, "Do not add the generated files to deploy.xml")
+	addCmd.PersistentFlags().BoolVar(&forceFlag, "force", false, "Overwrite existing files without asking")
+	addCmd.Flags().StringVar(&specFlag, "spec", "", "JSON or YAML file listing scripts to generate in one run")
+	addCmd.PersistentFlags().BoolVar(&dryRunFlag, "dry-run", false, "Print the files and directories that would be created without writing them")
+
 	rootCmd.AddCommand(addCmd)
 
-	for _, config := range scriptTypeConfigs {
-		c := config
+	for _, scriptType := range scaffold.ScriptTypes {
+		t := scriptType
+		var aliases []string
+		for alias, name := range scaffold.ScriptTypeAliases {
+			if name == t.Name {
+				aliases = append(aliases, alias)
+			}
+		}
 		subCmd := &cobra.Command{
-			Use:   c.name + " [name]",
-			Short: c.usage,
-			Args:  cobra.MaximumNArgs(1),
-			Run: func(cmd *cobra.Command, args []string) {
-				runAdd(c.name, args)
+			Use:     t.Name + " [name]",
+			Aliases: aliases,
+			Short:   t.Usage,
+			Args:    cobra.MaximumNArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return runAdd(t.Name, args)
 			},
 		}
 		addCmd.AddCommand(subCmd)
 	}
 }
 
-// TemplateData holds the data used to render script templates.
-type TemplateData struct {
-	Project      string
-	ProjectName  string
-	Description  string
-	Date         string
-	CompanyName  string
-	UserName     string
-	UserEmail    string
-	ScriptName   string
-	ScriptId     string
-	ScriptPath   string
-	DeploymentId string
-	RecordType   string
+// scriptOptions holds the values given for a script on the command line.
+type scriptOptions struct {
+	description string
+	recordType  string
 }
 
 // runAdd executes the logic for adding a new script.
-func runAdd(scriptType string, args []string) {
-	config, err := LoadConfig()
+func runAdd(scriptType string, args []string) error {
+	return addScript(bufio.NewReader(os.Stdin), scriptType, args, scriptOptions{
+		description: descriptionFlag,
+		recordType:  recordTypeFlag,
+	})
+}
+
+// addScript generates a script, prompting with reader for what the options leave out.
+func addScript(reader *bufio.Reader, scriptType string, args []string, opts scriptOptions) error {
+	config, err := loadProjectConfig()
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-		fmt.Println("Not a project folder. Please run 'netsuite-cli create'")
-		os.Exit(1)
+		return err
+	}
+
+	language := config.ScriptLanguage()
+	if langFlag != "" {
+		language = strings.ToLower(strings.TrimSpace(langFlag))
+	}
+	if err := ValidateLanguage(language, config.SuiteScriptVersion()); err != nil {
+		return validationError("%v", err)
 	}
 
 	scriptName := ""
@@ -176,20 +221,22 @@ func runAdd(scriptType string, args []string) {
 	}
 
 	projectName := config.ProjectName
-	defaultScriptName := toSnakeCase(projectName)
+	defaultScriptName := scaffold.SnakeCase(projectName)
+
+	if scriptName == "" && yesFlag {
+		scriptName = defaultScriptName
+	}
 
 	if scriptName == "" {
-		reader := bufio.NewReader(os.Stdin)
-		fmt.Print("Enter script name")
+		promptf("Enter script name")
 		if defaultScriptName != "" {
-			fmt.Printf(" (default: %s)", defaultScriptName)
+			promptf(" (default: %s)", defaultScriptName)
 		}
-		fmt.Print(": ")
+		promptf(": ")
 		var err error
 		scriptName, err = reader.ReadString('\n')
 		if err != nil {
-			fmt.Printf("Error reading script name: %v\n", err)
-			os.Exit(1)
+			return fmt.Errorf("error reading script name: %v", err)
 		}
 		scriptName = strings.TrimSpace(scriptName)
 		if scriptName == "" {
@@ -198,156 +245,552 @@ func runAdd(scriptType string, args []string) {
 	}
 
 	if scriptName == "" {
-		fmt.Println("Error: Script name is required")
-		os.Exit(1)
+		return errors.New("script name is required")
 	}
-	companyName := config.CompanyName
-	userName := config.UserName
-	userEmail := config.UserEmail
-
-	reader := bufio.NewReader(os.Stdin)
-	defaultDescription := scriptName + " description"
-	fmt.Print("Enter script description")
-	if defaultDescription != "" {
-		fmt.Printf(" (default: %s)", defaultDescription)
+	if _, err := config.ScriptNaming(scriptName, scriptType); err != nil {
+		return namingError(err)
 	}
-	fmt.Print(": ")
-	description, err := reader.ReadString('\n')
-	if err != nil {
-		fmt.Printf("Error reading description: %v\n", err)
-		os.Exit(1)
+	defaultDescription := scriptName + " description"
+	description := strings.TrimSpace(opts.description)
+	if description == "" && !yesFlag {
+		promptf("Enter script description")
+		if defaultDescription != "" {
+			promptf(" (default: %s)", defaultDescription)
+		}
+		promptf(": ")
+		description, err = reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("error reading description: %v", err)
+		}
+		description = strings.TrimSpace(description)
 	}
-	description = strings.TrimSpace(description)
 	if description == "" {
 		description = defaultDescription
 	}
 
 	recordType := ""
-	if scriptType == "userevent" || scriptType == "workflowaction" {
-		fmt.Print("Enter record type (e.g., CUSTOMER, SALESORDER, INVOICE): ")
-		recordTypeInput, err := reader.ReadString('\n')
+	if scaffold.UsesRecordType(scriptType) {
+		if recordType, err = resolveScriptRecordType(reader, scriptType, opts.recordType); err != nil {
+			return err
+		}
+	}
+
+	var entryPoints []string
+	if available, ok := scaffold.EntryPoints[scriptType]; ok {
+		selected := entryPointsFlag
+		if len(selected) == 0 && !yesFlag {
+			promptf("Enter entry points to generate [%s] (comma separated, default: all): ", strings.Join(available, ", "))
+			input, err := reader.ReadString('\n')
+			if err != nil {
+				return fmt.Errorf("error reading entry points: %v", err)
+			}
+			selected = strings.Split(strings.TrimSpace(input), ",")
+		}
+		entryPoints, err = scaffold.ResolveEntryPoints(scriptType, selected)
 		if err != nil {
-			fmt.Printf("Error reading record type: %v\n", err)
-			os.Exit(1)
+			return err
 		}
-		recordType = strings.TrimSpace(recordTypeInput)
-		if recordType == "" {
-			fmt.Println("Error: Record type is required for " + scriptType + " scripts")
-			os.Exit(1)
+	}
+
+	typedStages := typedStagesFlag
+	if scriptType == "mapreduce" && !typedStages && !yesFlag {
+		promptf("Generate typed interfaces for map/reduce payloads? (y/n, default: n): ")
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("error reading response: %v", err)
 		}
+		response = strings.TrimSpace(strings.ToLower(response))
+		typedStages = response == "y" || response == "yes"
 	}
 
-	scriptId := strings.ReplaceAll(strings.ToLower(scriptName), " ", "_")
-	deploymentId := "customdeploy_" + scriptId
+	variant := ""
+	if available, ok := scaffold.Variants[scriptType]; ok {
+		input := variantFlag
+		if input == "" && !yesFlag {
+			// The variants of portlets are the portlet types of NetSuite.
+			kind := "variant"
+			if scriptType == "portlet" {
+				kind = "type"
+			}
+			promptf("Enter %s %s [%s] (default: %s): ", scriptType, kind, strings.Join(available, ", "), available[0])
+			input, err = reader.ReadString('\n')
+			if err != nil {
+				return fmt.Errorf("error reading variant: %v", err)
+			}
+		}
+		variant, err = scaffold.ResolveVariant(scriptType, input)
+		if err != nil {
+			return err
+		}
+	}
 
-	companyPrefix := GetCompanyPrefix(companyName)
+	returnType, returnRecordType := "", ""
+	if scriptType == "workflowaction" {
+		returnType = strings.TrimSpace(returnTypeFlag)
+		for returnType == "" && !yesFlag {
+			returnType, err = promptLine(reader, fmt.Sprintf("Enter return type [none, %s] (default: none): ", strings.Join(scaffold.ReturnTypeNames(), ", ")))
+			if err != nil {
+				return err
+			}
+			if returnType == "" {
+				break
+			}
+			if _, err := scaffold.ResolveReturnType(returnType); err != nil {
+				promptf("Invalid return type: %v\n", err)
+				returnType = ""
+			}
+		}
+		resolved, err := scaffold.ResolveReturnType(returnType)
+		if err != nil {
+			return err
+		}
+		if resolved.FieldType == "SELECT" {
+			returnRecordType = strings.TrimSpace(returnRecordTypeFlag)
+			if returnRecordType == "" && !yesFlag {
+				returnRecordType, err = promptLine(reader, "Enter return record type (e.g., -2 for customer, customrecord_x): ")
+				if err != nil {
+					return err
+				}
+			}
+			if returnRecordType == "" {
+				return errors.New("select return types require a return record type")
+			}
+		}
+	}
+
+	var audience scaffold.DeploymentAudience
+	if scaffold.UsesAudience(scriptType) {
+		audience, err = resolveDeploymentAudience(reader, scriptType)
+		if err != nil {
+			return err
+		}
+	}
+
+	form := ""
+	if scriptType == "formclient" {
+		form = strings.TrimSpace(formFlag)
+		if form == "" && !yesFlag {
+			if form, err = promptLine(reader, "Enter the custom form (script ID) or record type to attach the script to (leave empty to skip): "); err != nil {
+				return err
+			}
+		}
+	}
 
-	prefixedFileName := companyPrefix + "_" + scriptName
-	tsFileNameWithType := prefixedFileName + "_" + scriptType
+	var schedule scaffold.DeploymentSchedule
+	if scriptType == "scheduled" {
+		if scheduleFlag != "" || yesFlag {
+			schedule, err = scaffold.NewDeploymentSchedule(scheduleFlag, startTimeFlag, daysFlag, intervalFlag)
+			if err != nil {
+				return err
+			}
+		} else {
+			schedule, err = promptDeploymentSchedule(reader)
+			if err != nil {
+				return err
+			}
+		}
+	}
 
-	data := TemplateData{
-		Project:      projectName,
-		ProjectName:  projectName,
-		Description:  description,
-		Date:         time.Now().Format("2006-01-02"),
-		CompanyName:  companyName,
-		UserName:     userName,
-		UserEmail:    userEmail,
-		ScriptName:   scriptName,
-		ScriptId:     "customscript_" + scriptId,
-		ScriptPath:   "SuiteScripts/" + projectName + "/" + tsFileNameWithType + ".ts",
-		DeploymentId: deploymentId,
-		RecordType:   recordType,
+	var params []scaffold.ScriptParam
+	if scriptType != "common" {
+		for _, spec := range paramFlags {
+			param, err := scaffold.ParseScriptParam(spec)
+			if err != nil {
+				return fmt.Errorf("invalid --param '%s': %v", spec, err)
+			}
+			params = append(params, param)
+		}
+		if len(params) == 0 && !yesFlag {
+			params, err = promptScriptParams(reader)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
-	templates := GetTemplates(scriptType)
+	vars, err := resolveTemplateVars(config, reader)
+	if err != nil {
+		return err
+	}
 
-	suiteScriptsDir, err := findSuiteScriptsDir()
+	scriptName, _, err = resolveScriptNameCollision(config, reader, scriptName, scriptType)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+		return err
 	}
 
-	selectedFolder, scriptPathPrefix := selectScriptFolder(suiteScriptsDir)
+	searchId := ""
+	if scriptType == "massupdate" {
+		withSearch := withSearchFlag || len(searchFilters) > 0
+		if !withSearch && !yesFlag {
+			if withSearch, err = promptConfirm(reader, "Generate a companion saved search providing the default filter? (y/n, default: n): "); err != nil {
+				return err
+			}
+		}
+		if withSearch {
+			if searchId, err = addCompanionSearch(reader, scriptName, recordType); err != nil {
+				return err
+			}
+			param, err := scaffold.NewScriptParam(config.ScriptId(scriptName)+"_search", "text", "Filter Search")
+			if err != nil {
+				return err
+			}
+			param.Key = "search"
+			param.Default = searchId
+			params = append(params, param)
+		}
+	}
 
-	osPath := strings.ReplaceAll(selectedFolder, "/", string(filepath.Separator))
-	targetDir := filepath.Join(suiteScriptsDir, osPath)
+	suiteScriptsDir, err := findSuiteScriptsDir()
+	if err != nil {
+		return err
+	}
 
-	if err := os.MkdirAll(targetDir, 0755); err != nil {
-		fmt.Printf("Error creating directory %s: %v\n", targetDir, err)
-		os.Exit(1)
+	var lib *sharedLibrary
+	if scriptType == "common" && folderFlag == "" {
+		if lib, err = findSharedLib(); err != nil {
+			return err
+		}
 	}
 
-	if selectedFolder != "" {
-		data.ScriptPath = scriptPathPrefix + selectedFolder + "/" + tsFileNameWithType + ".ts"
+	var selectedFolder string
+	if lib != nil {
+		logInfo("Adding to the shared library %s, build copies it into SuiteScripts/%s", filepath.ToSlash(lib.Dir), lib.Folder)
+	} else if folderFlag != "" || yesFlag {
+		folder := folderFlag
+		if folder == "" {
+			folder = config.DefaultFolder
+		}
+		selectedFolder = normalizeFolderPath(folder)
 	} else {
-		data.ScriptPath = scriptPathPrefix + tsFileNameWithType + ".ts"
+		selectedFolder, _, err = selectScriptFolder(reader, suiteScriptsDir)
+		if err != nil {
+			return err
+		}
 	}
 
-	tsFileName := tsFileNameWithType + ".ts"
-	tsPath := filepath.Join(targetDir, tsFileName)
+	withTest := !skipTestFlag && scriptType != "common" && language == languageTypeScript && testsConfigured()
+	if withTest && !yesFlag {
+		if withTest, err = promptYesDefault(reader, "Generate a unit test stub? (Y/n): "); err != nil {
+			return err
+		}
+	}
+
+	generator := scaffold.Generator{
+		Config:          config,
+		Templates:       projectTemplates(),
+		SuiteScriptsDir: suiteScriptsDir,
+		TestsDir:        testsDir,
+	}
+	if lib != nil {
+		generator.SuiteScriptsDir = lib.Dir
+	}
+	if scaffold.ObjectType(scriptType) != "" {
+		if generator.ObjectsDir, err = findObjectsDir(); err != nil {
+			return err
+		}
+	} else if scriptType != "common" && scriptType != "formclient" {
+		logWarn("No record type found for script type '%s'. XML file not created.", scriptType)
+	}
 
-	renderAndWrite(tsPath, templates.TypeScript, data)
-	fmt.Printf("Created %s\n", tsPath)
+	files, err := generator.Script(scaffold.Script{
+		Type:             scriptType,
+		Name:             scriptName,
+		Description:      description,
+		RecordType:       recordType,
+		SearchId:         searchId,
+		ReturnType:       returnType,
+		ReturnRecordType: returnRecordType,
+		Folder:           selectedFolder,
+		Params:           params,
+		EntryPoints:      entryPoints,
+		TypedStages:      typedStages,
+		Schedule:         schedule,
+		Audience:         audience,
+		DeployStatus:     deployStatusFlag,
+		LogLevel:         logLevelFlag,
+		Variant:          variant,
+		Language:         language,
+		Vars:             vars,
+		Test:             withTest,
+	})
+	if err != nil {
+		return err
+	}
 
-	if templates.XML != "" && scriptType != "common" {
-		objectsDir, err := findObjectsDir()
+	var deployPaths []string
+	for _, file := range files {
+		if lib != nil && file.Deploy != "" {
+			file.Deploy = filepath.Join(suiteScriptsDir, filepath.FromSlash(lib.Folder), filepath.Base(file.Deploy))
+		}
+		dir := filepath.Dir(file.Path)
+		if err := makeDir(dir); err != nil {
+			return fmt.Errorf("error creating directory %s: %v", dir, err)
+		}
+		written, err := writeGenerated(reader, file.Path, file.Content)
 		if err != nil {
-			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
+			return err
+		}
+		if written != "" {
+			reportFile("Created", written, "")
+		}
+		if file.Deploy != "" && (written == file.Path || dryRunFlag) {
+			deployPaths = append(deployPaths, file.Deploy)
 		}
+	}
+
+	registerInDeployXML(deployPaths...)
+	if len(deployPaths) > 0 {
+		ensureManifestFeatures(scriptType)
+	}
+	if form != "" {
+		for _, deployPath := range deployPaths {
+			if sdfPath := toSDFPath(deployPath); strings.HasPrefix(sdfPath, "~/FileCabinet/") && strings.HasSuffix(sdfPath, ".js") {
+				return bindFormClientScript(reader, form, strings.TrimPrefix(sdfPath, "~/FileCabinet/"))
+			}
+		}
+	}
+	return nil
+}
 
-		recordType := getRecordType(scriptType)
+// resolveScriptRecordType returns the given record type, asking for it until a valid one is
+// entered when it is empty.
+func resolveScriptRecordType(reader *bufio.Reader, scriptType, recordType string) (string, error) {
+	recordType = strings.TrimSpace(recordType)
+	for recordType == "" && !yesFlag {
+		promptf("Enter record type (e.g., CUSTOMER, SALESORDER, INVOICE): ")
+		recordTypeInput, err := reader.ReadString('\n')
+		if err != nil {
+			return "", fmt.Errorf("error reading record type: %v", err)
+		}
+		recordType = strings.TrimSpace(recordTypeInput)
 		if recordType == "" {
-			fmt.Printf("Warning: No record type found for script type '%s'. XML file not created.\n", scriptType)
-		} else {
-			xmlTargetDir := filepath.Join(objectsDir, projectName, recordType)
-			if err := os.MkdirAll(xmlTargetDir, 0755); err != nil {
-				fmt.Printf("Error creating XML directory %s: %v\n", xmlTargetDir, err)
-				os.Exit(1)
+			break
+		}
+		if _, err := resolveRecordType(recordType); err != nil {
+			promptf("Invalid record type: %v\n", err)
+			recordType = ""
+		}
+	}
+	if recordType == "" {
+		return "", fmt.Errorf("record type is required for %s scripts", scriptType)
+	}
+	return resolveRecordType(recordType)
+}
+
+// resolveDeploymentAudience returns the deployment audience given with --audience and --roles,
+// asking for it when neither is given. Roles given as internal IDs that are not known standard
+// roles are kept as is with a warning, since SDF expects role names or custom role script IDs.
+func resolveDeploymentAudience(reader *bufio.Reader, scriptType string) (scaffold.DeploymentAudience, error) {
+	input, roles := audienceFlag, rolesFlag
+	if input == "" && len(roles) == 0 && !yesFlag {
+		defaultAudience := scaffold.DefaultAudience(scriptType)
+		for {
+			answer, err := promptLine(reader, fmt.Sprintf("Enter deployment audience [%s] (default: %s): ", strings.Join(scaffold.AudienceTypes, ", "), defaultAudience))
+			if err != nil {
+				return scaffold.DeploymentAudience{}, err
+			}
+			if answer == "" {
+				return scaffold.DeploymentAudience{}, nil
+			}
+			if input = strings.ToLower(answer); containsString(scaffold.AudienceTypes, input) {
+				break
 			}
+			promptf("Invalid audience '%s'\n", answer)
+		}
+		if input == "roles" {
+			answer, err := promptLine(reader, "Enter roles (comma separated, e.g., ADMINISTRATOR, customrole_ap, 3): ")
+			if err != nil {
+				return scaffold.DeploymentAudience{}, err
+			}
+			roles = strings.Split(answer, ",")
+		}
+	}
 
-			xmlFileName := prefixedFileName + ".xml"
-			xmlPath := filepath.Join(xmlTargetDir, xmlFileName)
-			renderAndWrite(xmlPath, templates.XML, data)
-			fmt.Printf("Created %s\n", xmlPath)
+	audience, err := scaffold.NewDeploymentAudience(input, roles)
+	if err != nil {
+		return scaffold.DeploymentAudience{}, err
+	}
+	for _, role := range audience.Roles {
+		if _, err := strconv.Atoi(role); err == nil {
+			logWarn("Role %s is an internal ID; SDF deployments expect role names (e.g., ADMINISTRATOR) or custom role script IDs.", role)
 		}
 	}
+	return audience, nil
 }
 
-// renderAndWrite renders a template with data and writes it to the specified path.
-func renderAndWrite(path string, tmplStr string, data TemplateData) {
-	tmpl, err := template.New("script").Parse(tmplStr)
+// registerInDeployXML adds the given project files to deploy.xml, if the project has one, so
+// they are included in the next deployment. Compiled scripts are registered with their .js
+// path. Nothing is changed with --no-deployxml. undo drops the references again when it
+// deletes the files.
+func registerInDeployXML(paths ...string) {
+	if noDeployXMLFlag || len(paths) == 0 {
+		return
+	}
+	deployXMLPath, ok := findDeployXML()
+	if !ok {
+		return
+	}
+
+	sdfPaths := make([]string, len(paths))
+	for i, path := range paths {
+		sdfPaths[i] = toSDFPath(path)
+	}
+
+	added, err := addDeployXMLReferences(deployXMLPath, sdfPaths, !dryRunFlag)
 	if err != nil {
-		fmt.Printf("Error parsing template: %v\n", err)
-		os.Exit(1)
+		logWarn("Failed to update %s: %v", deployXMLPath, err)
+		return
 	}
+	for _, sdfPath := range added {
+		if dryRunFlag {
+			fmt.Printf("Would add %s to %s\n", sdfPath, deployXMLPath)
+		} else {
+			logInfo("Added %s to %s", sdfPath, deployXMLPath)
+		}
+	}
+}
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		fmt.Printf("Error executing template: %v\n", err)
-		os.Exit(1)
+// objectGenerator returns a generator of the object XML of custom records, fields, searches and
+// workflows in the Objects folder of the project.
+func objectGenerator(config *ProjectConfig) (scaffold.Generator, error) {
+	objectsDir, err := findObjectsDir()
+	if err != nil {
+		return scaffold.Generator{}, err
 	}
+	return scaffold.Generator{Config: config, Templates: projectTemplates(), ObjectsDir: objectsDir}, nil
+}
 
-	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
-		fmt.Printf("Error writing file %s: %v\n", path, err)
-		os.Exit(1)
+// writeObject writes an object XML rendered by the generator, creating its folder, and adds it
+// to deploy.xml. It reports whether the object was written, or would be with --dry-run.
+func writeObject(reader *bufio.Reader, file scaffold.File) (bool, error) {
+	dir := filepath.Dir(file.Path)
+	if err := makeDir(dir); err != nil {
+		return false, fmt.Errorf("error creating XML directory %s: %v", dir, err)
+	}
+	written, err := writeGenerated(reader, file.Path, file.Content)
+	if err != nil {
+		return false, err
 	}
+	if written != "" {
+		reportFile("Created", written, "")
+	}
+	if written != file.Path && !dryRunFlag {
+		return false, nil
+	}
+	registerInDeployXML(file.Deploy)
+	return true, nil
 }
 
-// findSuiteScriptsDir locates the SuiteScripts directory in the project.
-func findSuiteScriptsDir() (string, error) {
-	possiblePaths := []string{
-		"src/FileCabinet/SuiteScripts",
-		"src/SuiteScripts",
-		"SuiteScripts",
+// renderAndWrite renders a template with data and writes it to the specified path, with the
+// license header of the project for TypeScript and JavaScript files. It returns the path the
+// file was written to, or an empty string if the user chose to keep an existing file.
+func renderAndWrite(reader *bufio.Reader, path string, tmplStr string, data any) (string, error) {
+	content, err := projectTemplates().Render(path, tmplStr, data)
+	if err != nil {
+		return "", err
 	}
+	if ext := filepath.Ext(path); ext == ".ts" || ext == ".js" {
+		if config, err := LoadConfig(); err == nil {
+			content = scaffold.ApplyHeader(content, scaffold.LicenseHeader(config, time.Now().Year()))
+		}
+	}
+	return writeGenerated(reader, path, content)
+}
 
-	for _, path := range possiblePaths {
-		if info, err := os.Stat(path); err == nil && info.IsDir() {
+// writeGenerated writes generated content to path, asking before overwriting an existing file
+// and recording the file in the lock. It returns the path the file was written to, or an empty
+// string if it was skipped or in dry-run mode.
+func writeGenerated(reader *bufio.Reader, path string, content []byte) (string, error) {
+	if dryRunFlag {
+		reportDryRunFile(path, len(content))
+		return "", nil
+	}
+
+	path, err := resolveOverwrite(reader, path, string(content))
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		return "", nil
+	}
+
+	previous, err := os.ReadFile(path)
+	recordGeneratedFile(path, previous, err == nil)
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return "", fmt.Errorf("error writing file %s: %v", path, err)
+	}
+	return path, nil
+}
+
+// resolveOverwrite decides where to write generated content when the target file already
+// exists. It shows a diff against the existing file and asks whether to overwrite it, skip it
+// or write the new content alongside it. An empty string means the file should be skipped.
+func resolveOverwrite(reader *bufio.Reader, path, content string) (string, error) {
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		logDebug("%s does not exist, writing it", path)
+		return path, nil
+	}
+	if forceFlag {
+		logDebug("%s exists, overwriting it (--force)", path)
+		return path, nil
+	}
+
+	diff := unifiedDiff(path, path+" (new)", string(existing), content)
+	if diff == "" {
+		reportFile("Unchanged", path, "")
+		return "", nil
+	}
+	if yesFlag {
+		return "", fmt.Errorf("%s already exists, use --force to overwrite it", path)
+	}
+
+	out := promptOutput()
+	fmt.Fprintf(out, "\n%s\n\n", colorize(out, colorYellow, path+" already exists:"))
+	printColoredDiff(out, diff)
+	fmt.Fprintln(out)
+	alongside := alongsidePath(path)
+	for {
+		answer, err := promptLine(reader, fmt.Sprintf("[o]verwrite, [s]kip or [w]rite as %s? (default: s): ", filepath.Base(alongside)))
+		if err != nil {
+			return "", err
+		}
+		switch strings.ToLower(answer) {
+		case "o", "overwrite":
+			logDebug("overwriting %s", path)
 			return path, nil
+		case "", "s", "skip":
+			reportFile("Skipped", path, "")
+			return "", nil
+		case "w", "write":
+			logDebug("writing the new content of %s to %s", path, alongside)
+			return alongside, nil
+		}
+	}
+}
+
+// alongsidePath returns a free path next to path, e.g. acm_foo_suitelet.new.ts.
+func alongsidePath(path string) string {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	for n := 1; ; n++ {
+		suffix := ".new"
+		if n > 1 {
+			suffix = fmt.Sprintf(".new%d", n)
+		}
+		candidate := base + suffix + ext
+		if _, err := os.Stat(candidate); os.IsNotExist(err) {
+			return candidate
 		}
 	}
+}
+
+// findSuiteScriptsDir locates the SuiteScripts directory in the project, creating it if missing.
+func findSuiteScriptsDir() (string, error) {
+	if path, ok := locateSuiteScriptsDir(); ok {
+		return path, nil
+	}
 
 	var basePath string
 	if _, err := os.Stat("src/FileCabinet"); err == nil {
@@ -358,13 +801,29 @@ func findSuiteScriptsDir() (string, error) {
 		basePath = "SuiteScripts"
 	}
 
-	if err := os.MkdirAll(basePath, 0755); err != nil {
+	if err := makeDir(basePath); err != nil {
 		return "", fmt.Errorf("failed to create SuiteScripts directory: %v", err)
 	}
 
 	return basePath, nil
 }
 
+// locateSuiteScriptsDir returns the existing SuiteScripts directory of the project.
+func locateSuiteScriptsDir() (string, bool) {
+	possiblePaths := []string{
+		"src/FileCabinet/SuiteScripts",
+		"src/SuiteScripts",
+		"SuiteScripts",
+	}
+
+	for _, path := range possiblePaths {
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
 // findObjectsDir locates the Objects directory in the project.
 func findObjectsDir() (string, error) {
 	possiblePaths := []string{
@@ -385,7 +844,7 @@ func findObjectsDir() (string, error) {
 		basePath = "Objects"
 	}
 
-	if err := os.MkdirAll(basePath, 0755); err != nil {
+	if err := makeDir(basePath); err != nil {
 		return "", fmt.Errorf("failed to create Objects directory: %v", err)
 	}
 
@@ -400,28 +859,70 @@ type FolderOption struct {
 }
 
 // selectScriptFolder allows the user to interactively select a folder for the script.
-func selectScriptFolder(suiteScriptsDir string) (string, string) {
+func selectScriptFolder(reader *bufio.Reader, suiteScriptsDir string) (string, string, error) {
 	folders := findAllFolders(suiteScriptsDir, "")
 
 	scriptPathPrefix := "SuiteScripts/"
 
 	if len(folders) == 0 {
-		reader := bufio.NewReader(os.Stdin)
-		fmt.Print("\nNo folders found under SuiteScripts. Place script in SuiteScripts root? (y/n): ")
+		promptf("\nNo folders found under SuiteScripts. Place script in SuiteScripts root? (y/n, 'c' to create a new folder): ")
 		response, err := reader.ReadString('\n')
 		if err != nil {
-			fmt.Printf("Error reading response: %v\n", err)
-			os.Exit(1)
+			return "", "", fmt.Errorf("error reading response: %v", err)
 		}
 		response = strings.TrimSpace(strings.ToLower(response))
+		if response == "c" {
+			folder, err := promptNewFolder(reader, suiteScriptsDir)
+			return folder, scriptPathPrefix, err
+		}
 		if response != "y" && response != "yes" {
 			fmt.Println("Cancelled. Script not created.")
-			os.Exit(0)
+			return "", "", errCancelled
 		}
-		return "", scriptPathPrefix
+		return "", scriptPathPrefix, nil
 	}
 
-	return displayScrollableMenu(folders, scriptPathPrefix)
+	return displayScrollableMenu(reader, folders, suiteScriptsDir, scriptPathPrefix)
+}
+
+// promptNewFolder asks for a folder path, creates it under SuiteScripts and returns its SuiteScripts relative path.
+func promptNewFolder(reader *bufio.Reader, suiteScriptsDir string) (string, error) {
+	for {
+		promptf("Enter new folder path (e.g., MyProject/lib): ")
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			return "", fmt.Errorf("error reading folder path: %v", err)
+		}
+
+		folder := normalizeFolderPath(input)
+		if folder == "" {
+			promptf("Folder path cannot be empty.\n")
+			continue
+		}
+		if strings.ContainsAny(folder, `<>:"|?*`) || strings.Contains("/"+folder+"/", "/../") {
+			promptf("Folder path contains invalid characters.\n")
+			continue
+		}
+
+		targetDir := filepath.Join(suiteScriptsDir, filepath.FromSlash(folder))
+		if err := makeDir(targetDir); err != nil {
+			return "", fmt.Errorf("error creating directory %s: %v", targetDir, err)
+		}
+		if !dryRunFlag {
+			logInfo("Created folder %s", targetDir)
+		}
+		return folder, nil
+	}
+}
+
+// normalizeFolderPath converts a user supplied folder into a SuiteScripts relative path using '/' separators.
+func normalizeFolderPath(folder string) string {
+	folder = strings.ReplaceAll(strings.TrimSpace(folder), "\\", "/")
+	folder = strings.Trim(folder, "/")
+	if folder == "SuiteScripts" {
+		return ""
+	}
+	return strings.TrimPrefix(folder, "SuiteScripts/")
 }
 
 // findAllFolders recursively finds all directories starting from baseDir.
@@ -469,17 +970,16 @@ func findAllFolders(baseDir string, relativePath string) []FolderOption {
 }
 
 // displayScrollableMenu shows a scrollable menu of folder options to the user.
-func displayScrollableMenu(folders []FolderOption, scriptPathPrefix string) (string, string) {
+func displayScrollableMenu(reader *bufio.Reader, folders []FolderOption, suiteScriptsDir string, scriptPathPrefix string) (string, string, error) {
 	const pageSize = 20
-	reader := bufio.NewReader(os.Stdin)
 	currentPage := 0
 	totalPages := (len(folders) + pageSize - 1) / pageSize
 
 	for {
-		fmt.Print("\n")
-		fmt.Println("Available folders under SuiteScripts:")
-		fmt.Println("  0. SuiteScripts (root)")
-		fmt.Println(strings.Repeat("-", 60))
+		promptf("\n")
+		promptf("Available folders under SuiteScripts:\n")
+		promptf("  0. SuiteScripts (root)\n")
+		promptf("%s\n", strings.Repeat("-", 60))
 
 		start := currentPage * pageSize
 		end := start + pageSize
@@ -488,40 +988,46 @@ func displayScrollableMenu(folders []FolderOption, scriptPathPrefix string) (str
 		}
 
 		for i := start; i < end; i++ {
-			fmt.Printf("  %d. %s\n", i+1, folders[i].Display)
+			promptf("  %d. %s\n", i+1, folders[i].Display)
 		}
 
 		if totalPages > 1 {
-			fmt.Printf("\nPage %d of %d", currentPage+1, totalPages)
+			promptf("\nPage %d of %d", currentPage+1, totalPages)
 			if currentPage > 0 {
-				fmt.Print(" (p: previous page")
+				promptf(" (p: previous page")
 			}
 			if currentPage < totalPages-1 {
 				if currentPage > 0 {
-					fmt.Print(", n: next page")
+					promptf(", n: next page")
 				} else {
-					fmt.Print(" (n: next page")
+					promptf(" (n: next page")
 				}
 			}
 			if currentPage > 0 || currentPage < totalPages-1 {
-				fmt.Print(")")
+				promptf(")")
 			}
 		}
 
-		fmt.Print("\nSelect folder (0 for root, number to select")
+		promptf("\n  c. Create new folder...\n")
+
+		promptf("\nSelect folder (0 for root, number to select, 'c' to create a new folder")
 		if totalPages > 1 {
-			fmt.Print(", 'n' for next page, 'p' for previous page")
+			promptf(", 'n' for next page, 'p' for previous page")
 		}
-		fmt.Print("): ")
+		promptf("): ")
 
 		input, err := reader.ReadString('\n')
 		if err != nil {
-			fmt.Printf("Error reading selection: %v\n", err)
-			os.Exit(1)
+			return "", "", fmt.Errorf("error reading selection: %v", err)
 		}
 
 		input = strings.TrimSpace(strings.ToLower(input))
 
+		if input == "c" {
+			folder, err := promptNewFolder(reader, suiteScriptsDir)
+			return folder, scriptPathPrefix, err
+		}
+
 		if totalPages > 1 {
 			if input == "n" && currentPage < totalPages-1 {
 				currentPage++
@@ -535,25 +1041,25 @@ func displayScrollableMenu(folders []FolderOption, scriptPathPrefix string) (str
 
 		selection, err := strconv.Atoi(input)
 		if err != nil {
-			fmt.Printf("Invalid selection. Please enter a number")
+			promptf("Invalid selection. Please enter a number")
 			if totalPages > 1 {
-				fmt.Print(" or 'n'/'p' for navigation")
+				promptf(" or 'n'/'p' for navigation")
 			}
-			fmt.Println()
+			promptf("\n")
 			time.Sleep(1 * time.Second)
 			continue
 		}
 
 		if selection == 0 {
-			return "", scriptPathPrefix
+			return "", scriptPathPrefix, nil
 		}
 
 		if selection < 1 || selection > len(folders) {
-			fmt.Printf("Invalid selection. Please choose between 0 and %d\n", len(folders))
+			promptf("Invalid selection. Please choose between 0 and %d\n", len(folders))
 			time.Sleep(1 * time.Second)
 			continue
 		}
 
-		return folders[selection-1].Path, scriptPathPrefix
+		return folders[selection-1].Path, scriptPathPrefix, nil
 	}
 }
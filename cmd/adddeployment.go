@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"netsuite-cli/pkg/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+var deploymentIdFlag string
+
+var (
+	objectRootRe       = regexp.MustCompile(`<([a-z]+)\s[^>]*scriptid="`)
+	scriptDeploymentRe = regexp.MustCompile(`(?s)[ \t]*<scriptdeployment\s+scriptid="[^"]*">.*?</scriptdeployment>`)
+)
+
+// addDeploymentCmd represents the add deployment command
+var addDeploymentCmd = &cobra.Command{
+	Use:   "deployment <script-name>",
+	Short: "Add another deployment to an existing script",
+	Long: `Append a <scriptdeployment> to the object XML of an existing script, e.g. to deploy a user
+event script to another record type. The deployment is rendered from the template of the script
+type with the record type, status, log level and audience given with the add flags, and gets the
+next free deployment ID (customdeploy_x_2, customdeploy_x_3, ...) unless --id is given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAddDeployment(args[0])
+	},
+}
+
+func init() {
+	addDeploymentCmd.Flags().StringVar(&deploymentIdFlag, "id", "", "Script ID of the new deployment (default: the next free customdeploy_..._N)")
+
+	addCmd.AddCommand(addDeploymentCmd)
+}
+
+// runAddDeployment appends a deployment to the object XML of a script.
+func runAddDeployment(scriptName string) error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+	files, err := locateScript(config, scriptName)
+	if err != nil {
+		return err
+	}
+	if files.XMLPath == "" {
+		return fmt.Errorf("no object XML found for script '%s'", scriptName)
+	}
+	data, err := os.ReadFile(files.XMLPath)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", files.XMLPath, err)
+	}
+	content := string(data)
+
+	scriptType := ""
+	if m := objectRootRe.FindStringSubmatch(content); m != nil {
+		scriptType = scaffold.ScriptTypeOf(m[1])
+	}
+	if scriptType == "" {
+		return fmt.Errorf("%s is not the object XML of a script", files.XMLPath)
+	}
+	naming, err := config.ScriptNaming(files.Name, scriptType)
+	if err != nil {
+		return err
+	}
+
+	deploymentId := strings.TrimSpace(deploymentIdFlag)
+	existing := existingObjectIds()
+	if deploymentId == "" {
+		deploymentId = nextDeploymentId(existing, naming.DeploymentId)
+	} else if path, ok := existing[deploymentId]; ok {
+		return fmt.Errorf("deployment ID '%s' is already used in %s", deploymentId, path)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	recordType := ""
+	if scaffold.UsesRecordType(scriptType) {
+		if recordType, err = resolveScriptRecordType(reader, scriptType, recordTypeFlag); err != nil {
+			return err
+		}
+	}
+	var audience scaffold.DeploymentAudience
+	if scaffold.UsesAudience(scriptType) {
+		if audience, err = resolveDeploymentAudience(reader, scriptType); err != nil {
+			return err
+		}
+	}
+	var schedule scaffold.DeploymentSchedule
+	if scriptType == "scheduled" && scheduleFlag != "" {
+		if schedule, err = scaffold.NewDeploymentSchedule(scheduleFlag, startTimeFlag, daysFlag, intervalFlag); err != nil {
+			return err
+		}
+	}
+
+	generator := scaffold.Generator{
+		Config:     config,
+		Templates:  projectTemplates(),
+		ObjectsDir: filepath.Dir(files.XMLPath),
+	}
+	rendered, err := generator.Script(scaffold.Script{
+		Type:         scriptType,
+		Name:         files.Name,
+		RecordType:   recordType,
+		Audience:     audience,
+		DeployStatus: deployStatusFlag,
+		LogLevel:     logLevelFlag,
+		Schedule:     schedule,
+		Language:     strings.ToLower(strings.TrimSpace(langFlag)),
+	})
+	if err != nil {
+		return err
+	}
+	var deployment string
+	for _, file := range rendered {
+		if filepath.Ext(file.Path) == ".xml" {
+			deployment = scriptDeploymentRe.FindString(string(file.Content))
+		}
+	}
+	if deployment == "" {
+		return fmt.Errorf("the %s template has no script deployment", scriptType)
+	}
+	deployment = strings.Replace(deployment, `scriptid="`+naming.DeploymentId+`"`, `scriptid="`+deploymentId+`"`, 1)
+
+	updated, err := appendScriptDeployment(content, deployment)
+	if err != nil {
+		return fmt.Errorf("error updating %s: %v", files.XMLPath, err)
+	}
+	if dryRunFlag {
+		fmt.Printf("Would add deployment %s to %s\n", deploymentId, files.XMLPath)
+		return nil
+	}
+	recordGeneratedFile(files.XMLPath, data, true)
+	if err := os.WriteFile(files.XMLPath, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", files.XMLPath, err)
+	}
+	addJSONFile(JSONFile{Path: files.XMLPath, Action: "updated"})
+	logInfo("Added deployment %s to %s", deploymentId, files.XMLPath)
+	registerInDeployXML(files.XMLPath)
+	return nil
+}
+
+// nextDeploymentId returns the first of base_2, base_3, ... not used by an object of the project.
+func nextDeploymentId(existing map[string]string, base string) string {
+	for n := 2; ; n++ {
+		id := base + "_" + strconv.Itoa(n)
+		if _, ok := existing[id]; !ok {
+			return id
+		}
+	}
+}
+
+// appendScriptDeployment adds a <scriptdeployment> block after the last deployment of a script
+// object XML, re-indented to match it. A <scriptdeployments> element is created before the closing
+// tag of the script when there is none.
+func appendScriptDeployment(content, deployment string) (string, error) {
+	lines := strings.Split(content, "\n")
+	block := strings.Split(deployment, "\n")
+	blockIndent := lineIndent(block[0])
+
+	reindent := func(indent string) []string {
+		out := make([]string, len(block))
+		for i, line := range block {
+			out[i] = indent + strings.TrimPrefix(line, blockIndent)
+		}
+		return out
+	}
+
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.TrimSpace(lines[i]) != "</scriptdeployments>" {
+			continue
+		}
+		insert := reindent(lineIndent(lines[i]) + "  ")
+		return strings.Join(append(lines[:i], append(insert, lines[i:]...)...), "\n"), nil
+	}
+
+	m := objectRootRe.FindStringSubmatch(content)
+	if m == nil {
+		return "", fmt.Errorf("no script object found")
+	}
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.TrimSpace(lines[i]) != "</"+m[1]+">" {
+			continue
+		}
+		indent := lineIndent(lines[i]) + "  "
+		insert := append([]string{indent + "<scriptdeployments>"}, reindent(indent+"  ")...)
+		insert = append(insert, indent+"</scriptdeployments>")
+		return strings.Join(append(lines[:i], append(insert, lines[i:]...)...), "\n"), nil
+	}
+	return "", fmt.Errorf("no closing </%s> tag", m[1])
+}
@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"netsuite-cli/pkg/scaffold"
+)
+
+// specFlag is the spec file listing the scripts generated by 'add --spec'.
+var specFlag string
+
+// ScriptSpec describes a script generated by 'add --spec'. The fields match the add flags.
+type ScriptSpec struct {
+	Type             string   `json:"type"`
+	Name             string   `json:"name"`
+	Description      string   `json:"description,omitempty"`
+	RecordType       string   `json:"recordType,omitempty"`
+	Folder           string   `json:"folder,omitempty"`
+	Params           []string `json:"params,omitempty"`
+	EntryPoints      []string `json:"entryPoints,omitempty"`
+	Variant          string   `json:"variant,omitempty"`
+	ReturnType       string   `json:"returnType,omitempty"`
+	ReturnRecordType string   `json:"returnRecordType,omitempty"`
+	Audience         string   `json:"audience,omitempty"`
+	Roles            []string `json:"roles,omitempty"`
+	DeployStatus     string   `json:"deployStatus,omitempty"`
+	LogLevel         string   `json:"logLevel,omitempty"`
+	Schedule         string   `json:"schedule,omitempty"`
+	Typed            bool     `json:"typed,omitempty"`
+	Lang             string   `json:"lang,omitempty"`
+	Vars             []string `json:"vars,omitempty"`
+}
+
+// loadScriptSpecs reads the scripts listed in a JSON or YAML spec file. The file holds either
+// a list of scripts or an object with a scripts list.
+func loadScriptSpecs(path string) ([]ScriptSpec, error) {
+	path = projectRelativePath(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", path, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+	case ".yaml", ".yml":
+		items, err := parseSpecYAML(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %v", path, err)
+		}
+		if data, err = json.Marshal(items); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %v", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported spec file %s, use a .json, .yaml or .yml file", path)
+	}
+
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var wrapper struct {
+			Scripts json.RawMessage `json:"scripts"`
+		}
+		if err := json.Unmarshal(trimmed, &wrapper); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %v", path, err)
+		}
+		data = wrapper.Scripts
+	}
+
+	var specs []ScriptSpec
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&specs); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", path, err)
+	}
+	for i := range specs {
+		specs[i].Type = scaffold.ResolveScriptType(specs[i].Type)
+	}
+	return specs, nil
+}
+
+// parseSpecYAML parses the subset of YAML used by spec files: a list of mappings, optionally
+// under a top-level scripts key, whose values are scalars, inline lists such as [a, b] or
+// block lists of scalars.
+func parseSpecYAML(content string) ([]map[string]any, error) {
+	var items []map[string]any
+	var current map[string]any
+	itemIndent, listKey := -1, ""
+
+	for n, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(stripYAMLComment(line), " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if indent == 0 && trimmed == "scripts:" {
+			continue
+		}
+
+		if trimmed == "-" || strings.HasPrefix(trimmed, "- ") {
+			rest := strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
+			if listKey != "" && indent > itemIndent {
+				current[listKey] = append(current[listKey].([]any), yamlScalar(rest))
+				continue
+			}
+			current = make(map[string]any)
+			items = append(items, current)
+			itemIndent, listKey = indent, ""
+			if rest == "" {
+				continue
+			}
+			trimmed = rest
+		} else if current == nil || indent <= itemIndent {
+			return nil, fmt.Errorf("line %d: expected a list item starting with '-'", n+1)
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("line %d: expected 'key: value'", n+1)
+		}
+		value = strings.TrimSpace(value)
+		listKey = ""
+		switch {
+		case value == "":
+			current[key] = []any{}
+			listKey = key
+		case strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]"):
+			list := []any{}
+			for _, item := range strings.Split(value[1:len(value)-1], ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					list = append(list, yamlScalar(item))
+				}
+			}
+			current[key] = list
+		default:
+			current[key] = yamlScalar(value)
+		}
+	}
+	return items, nil
+}
+
+// stripYAMLComment removes a trailing # comment that is not inside a quoted string.
+func stripYAMLComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// yamlScalar converts a YAML scalar to a string or, for true and false, a boolean.
+func yamlScalar(value string) any {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	return value
+}
+
+// runAddSpec generates every script listed in a spec file. The specs are checked before anything
+// is written, and all scripts are recorded as one generation so a single undo removes them.
+func runAddSpec(path string) error {
+	specs, err := loadScriptSpecs(path)
+	if err != nil {
+		return err
+	}
+	if len(specs) == 0 {
+		logInfo("No scripts listed in %s", path)
+		return nil
+	}
+
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+	if err := validateScriptSpecs(config, specs); err != nil {
+		return err
+	}
+
+	defaults := ScriptSpec{
+		Description:      descriptionFlag,
+		RecordType:       recordTypeFlag,
+		Folder:           folderFlag,
+		Params:           paramFlags,
+		EntryPoints:      entryPointsFlag,
+		Variant:          variantFlag,
+		ReturnType:       returnTypeFlag,
+		ReturnRecordType: returnRecordTypeFlag,
+		Audience:         audienceFlag,
+		Roles:            rolesFlag,
+		DeployStatus:     deployStatusFlag,
+		LogLevel:         logLevelFlag,
+		Schedule:         scheduleFlag,
+		Typed:            typedStagesFlag,
+		Lang:             langFlag,
+		Vars:             varFlags,
+	}
+	yesFlag = true
+	for i, spec := range specs {
+		descriptionFlag = firstNonEmpty(spec.Description, defaults.Description)
+		recordTypeFlag = firstNonEmpty(spec.RecordType, defaults.RecordType)
+		folderFlag = firstNonEmpty(spec.Folder, defaults.Folder)
+		variantFlag = firstNonEmpty(spec.Variant, defaults.Variant)
+		returnTypeFlag = firstNonEmpty(spec.ReturnType, defaults.ReturnType)
+		returnRecordTypeFlag = firstNonEmpty(spec.ReturnRecordType, defaults.ReturnRecordType)
+		audienceFlag = firstNonEmpty(spec.Audience, defaults.Audience)
+		deployStatusFlag = firstNonEmpty(spec.DeployStatus, defaults.DeployStatus)
+		logLevelFlag = firstNonEmpty(spec.LogLevel, defaults.LogLevel)
+		rolesFlag = defaults.Roles
+		if len(spec.Roles) > 0 {
+			rolesFlag = spec.Roles
+		}
+		scheduleFlag = firstNonEmpty(spec.Schedule, defaults.Schedule)
+		langFlag = firstNonEmpty(spec.Lang, defaults.Lang)
+		paramFlags = defaults.Params
+		if len(spec.Params) > 0 {
+			paramFlags = spec.Params
+		}
+		entryPointsFlag = defaults.EntryPoints
+		if len(spec.EntryPoints) > 0 {
+			entryPointsFlag = spec.EntryPoints
+		}
+		typedStagesFlag = spec.Typed || defaults.Typed
+		varFlags = append(append([]string(nil), defaults.Vars...), spec.Vars...)
+
+		logInfo("\n[%d/%d] %s %s", i+1, len(specs), spec.Type, spec.Name)
+		if err := runAdd(spec.Type, []string{spec.Name}); err != nil {
+			return err
+		}
+	}
+	logInfo("\n✓ Generated %d script(s) from %s", len(specs), path)
+	return nil
+}
+
+// validateScriptSpecs returns an error if a spec has an unknown type, no name, or IDs already
+// used in the project or by another spec. Every problem is printed.
+func validateScriptSpecs(config *ProjectConfig, specs []ScriptSpec) error {
+	existing := existingObjectIds()
+	failed := false
+	for i, spec := range specs {
+		label := fmt.Sprintf("script %d", i+1)
+		if spec.Name != "" {
+			label += " (" + spec.Name + ")"
+		}
+		known := false
+		for _, c := range scaffold.ScriptTypes {
+			known = known || c.Name == spec.Type
+		}
+		switch {
+		case !known:
+			logError("%s has unknown type '%s'", label, spec.Type)
+			failed = true
+			continue
+		case strings.TrimSpace(spec.Name) == "":
+			logError("%s has no name", label)
+			failed = true
+			continue
+		}
+
+		naming, err := config.ScriptNaming(spec.Name, spec.Type)
+		if err != nil {
+			logError("%s: %v", label, err)
+			failed = true
+			continue
+		}
+		if id, path, ok := findCollision(existing, naming.ObjectFileName+".xml", naming.ScriptId, naming.DeploymentId); ok {
+			logError("%s: ID '%s' is already used in %s", label, id, path)
+			failed = true
+			continue
+		}
+		if spec.Type != "common" {
+			existing[naming.ScriptId] = label
+			existing[naming.DeploymentId] = label
+		}
+	}
+	if failed {
+		return validationError("invalid spec file")
+	}
+	return nil
+}
+
+// firstNonEmpty returns the first of the values that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"netsuite-cli/pkg/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	xmlScriptIdFlag     string
+	xmlDeploymentIdFlag string
+)
+
+var (
+	scriptTypeTagRe  = regexp.MustCompile(`@NScriptType\s+(\w+)`)
+	scriptNameTagRe  = regexp.MustCompile(`@NScriptName[ \t]+([^\r\n]+)`)
+	scriptIdTagRe    = regexp.MustCompile(`@NScriptId[ \t]+(\w+)`)
+	descriptionTagRe = regexp.MustCompile(`@description:?[ \t]+([^\r\n]+)`)
+)
+
+// addXMLCmd represents the add xml command
+var addXMLCmd = &cobra.Command{
+	Use:   "xml <script-file>",
+	Short: "Generate the object XML of an existing script file",
+	Long: `Generate only the object XML of a TypeScript or JavaScript file under SuiteScripts, e.g. a
+hand-written script being brought under SDF management. The script type is read from the
+@NScriptType tag of the file and the name, script ID and description from the @NScriptName,
+@NScriptId and @description tags when present. The script and deployment IDs are asked for,
+defaulting to the naming patterns of the project, and the scriptfile path is the file given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAddXML(args[0])
+	},
+}
+
+func init() {
+	addXMLCmd.Flags().StringVar(&xmlScriptIdFlag, "script-id", "", "Script ID of the script record (default: @NScriptId, else the project naming pattern)")
+	addXMLCmd.Flags().StringVar(&xmlDeploymentIdFlag, "deployment-id", "", "Script ID of the deployment (default: the project naming pattern)")
+
+	addCmd.AddCommand(addXMLCmd)
+}
+
+// runAddXML writes the object XML of an existing script file.
+func runAddXML(scriptFile string) error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+	scriptFile = projectRelativePath(scriptFile)
+	data, err := os.ReadFile(scriptFile)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", scriptFile, err)
+	}
+	content := string(data)
+	ext := filepath.Ext(scriptFile)
+	if ext != ".ts" && ext != ".js" {
+		return fmt.Errorf("%s is not a TypeScript or JavaScript file", scriptFile)
+	}
+
+	m := scriptTypeTagRe.FindStringSubmatch(content)
+	if m == nil {
+		return fmt.Errorf("%s has no @NScriptType tag", scriptFile)
+	}
+	scriptType := scaffold.ScriptTypeOfTag(m[1])
+	if scriptType == "" {
+		return fmt.Errorf("script type '%s' of %s has no object XML", m[1], scriptFile)
+	}
+
+	suiteScriptsDir, err := findSuiteScriptsDir()
+	if err != nil {
+		return err
+	}
+	folder, err := scriptFolder(suiteScriptsDir, scriptFile)
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	stem := strings.TrimSuffix(filepath.Base(scriptFile), ext)
+	name := strings.TrimSuffix(strings.TrimPrefix(stem, config.Prefix()+"_"), "_"+scriptType)
+	if m := scriptNameTagRe.FindStringSubmatch(content); m != nil {
+		name = strings.TrimSpace(m[1])
+	} else if !yesFlag {
+		if name, err = promptWithDefault(reader, "script name", name); err != nil {
+			return err
+		}
+	}
+	naming, err := config.ScriptNaming(name, scriptType)
+	if err != nil {
+		return err
+	}
+
+	scriptId := strings.TrimSpace(xmlScriptIdFlag)
+	if m := scriptIdTagRe.FindStringSubmatch(content); scriptId == "" && m != nil {
+		scriptId = m[1]
+	}
+	if scriptId == "" {
+		scriptId = naming.ScriptId
+		if !yesFlag {
+			if scriptId, err = promptWithDefault(reader, "script ID", scriptId); err != nil {
+				return err
+			}
+		}
+	}
+	deploymentId := strings.TrimSpace(xmlDeploymentIdFlag)
+	if deploymentId == "" {
+		deploymentId = naming.DeploymentId
+		if !yesFlag {
+			if deploymentId, err = promptWithDefault(reader, "deployment ID", deploymentId); err != nil {
+				return err
+			}
+		}
+	}
+	existing := existingObjectIds()
+	if _, usedIn, ok := findCollision(existing, naming.ObjectFileName+".xml", scriptId, deploymentId); ok {
+		return fmt.Errorf("an object ID of %s is already used in %s", scriptFile, usedIn)
+	}
+
+	description := strings.TrimSpace(descriptionFlag)
+	if m := descriptionTagRe.FindStringSubmatch(content); description == "" && m != nil {
+		description = strings.TrimSpace(m[1])
+	}
+	if description == "" {
+		description = name
+	}
+
+	recordType := ""
+	if scaffold.UsesRecordType(scriptType) {
+		if recordType, err = resolveScriptRecordType(reader, scriptType, recordTypeFlag); err != nil {
+			return err
+		}
+	}
+	var audience scaffold.DeploymentAudience
+	if scaffold.UsesAudience(scriptType) {
+		if audience, err = resolveDeploymentAudience(reader, scriptType); err != nil {
+			return err
+		}
+	}
+	variant := ""
+	if scriptType == "portlet" {
+		input := variantFlag
+		if input == "" && !yesFlag {
+			available := scaffold.Variants[scriptType]
+			if input, err = promptLine(reader, fmt.Sprintf("Enter portlet type [%s] (default: %s): ", strings.Join(available, ", "), available[0])); err != nil {
+				return err
+			}
+		}
+		if variant, err = scaffold.ResolveVariant(scriptType, input); err != nil {
+			return err
+		}
+	}
+	var schedule scaffold.DeploymentSchedule
+	if scriptType == "scheduled" && scheduleFlag != "" {
+		if schedule, err = scaffold.NewDeploymentSchedule(scheduleFlag, startTimeFlag, daysFlag, intervalFlag); err != nil {
+			return err
+		}
+	}
+
+	objectsDir, err := findObjectsDir()
+	if err != nil {
+		return err
+	}
+	generator := scaffold.Generator{
+		Config:     config,
+		Templates:  projectTemplates(),
+		ObjectsDir: objectsDir,
+		Override: func(data *scaffold.TemplateData) error {
+			data.ScriptId = scriptId
+			data.DeploymentId = deploymentId
+			data.ScriptPath = path.Join("SuiteScripts", folder, filepath.Base(scriptFile))
+			return nil
+		},
+	}
+	files, err := generator.Script(scaffold.Script{
+		Type:             scriptType,
+		Name:             name,
+		Description:      description,
+		RecordType:       recordType,
+		ReturnType:       returnTypeFlag,
+		ReturnRecordType: returnRecordTypeFlag,
+		Folder:           folder,
+		Schedule:         schedule,
+		Audience:         audience,
+		DeployStatus:     deployStatusFlag,
+		LogLevel:         logLevelFlag,
+		Variant:          variant,
+		Language:         strings.TrimPrefix(ext, "."),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if filepath.Ext(file.Path) != ".xml" {
+			continue
+		}
+		if err := makeDir(filepath.Dir(file.Path)); err != nil {
+			return fmt.Errorf("error creating directory %s: %v", filepath.Dir(file.Path), err)
+		}
+		written, err := writeGenerated(reader, file.Path, file.Content)
+		if err != nil {
+			return err
+		}
+		if written != "" {
+			reportFile("Created", written, "")
+		}
+		if written == file.Path || dryRunFlag {
+			registerInDeployXML(strings.TrimSuffix(scriptFile, ext)+".js", file.Path)
+			ensureManifestFeatures(scriptType)
+		}
+	}
+	return nil
+}
+
+// scriptFolder returns the folder of a script file under the SuiteScripts directory, with '/'
+// separators and empty for the root, or an error if the file is not under it.
+func scriptFolder(suiteScriptsDir, scriptFile string) (string, error) {
+	root, err := filepath.Abs(suiteScriptsDir)
+	if err != nil {
+		return "", err
+	}
+	file, err := filepath.Abs(scriptFile)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(root, filepath.Dir(file))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is not under %s", scriptFile, suiteScriptsDir)
+	}
+	if rel == "." {
+		return "", nil
+	}
+	return filepath.ToSlash(rel), nil
+}
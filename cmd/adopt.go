@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	adoptNameFlag  string
+	adoptForceFlag bool
+)
+
+// adoptCmd represents the adopt command
+var adoptCmd = &cobra.Command{
+	Use:   "adopt",
+	Short: "Use netsuite-cli in an existing SuiteCloud project",
+	Long: `Adopt an existing SDF project created with the SuiteCloud CLI or another tool.
+The project name is inferred from deploy.xml or manifest.xml, and the company and
+user details are prompted for, then the .netsuite-cli file is written so the other
+commands can be used in the project.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdopt()
+	},
+}
+
+func init() {
+	adoptCmd.Flags().StringVarP(&adoptNameFlag, "name", "n", "", "Project name (default: inferred from deploy.xml or manifest.xml)")
+	adoptCmd.Flags().BoolVar(&adoptForceFlag, "force", false, "Overwrite an existing .netsuite-cli file")
+
+	rootCmd.AddCommand(adoptCmd)
+}
+
+var (
+	manifestProjectNameRe = regexp.MustCompile(`<projectname>\s*(.*?)\s*</projectname>`)
+	deployScriptsFolderRe = regexp.MustCompile(`<path>\s*~/FileCabinet/SuiteScripts/([^/*<]+)/`)
+)
+
+// findManifestXML returns the path of the project manifest, if the current directory holds an SDF project.
+func findManifestXML() (string, bool) {
+	for _, path := range []string{"src/manifest.xml", "manifest.xml"} {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// inferProjectName guesses the project name from the SuiteScripts folder referenced by
+// deploy.xml, then from the manifest project name.
+func inferProjectName(manifestPath string) string {
+	if deployXMLPath, ok := findDeployXML(); ok {
+		if data, err := os.ReadFile(deployXMLPath); err == nil {
+			if m := deployScriptsFolderRe.FindSubmatch(data); m != nil {
+				return string(m[1])
+			}
+		}
+	}
+
+	if data, err := os.ReadFile(manifestPath); err == nil {
+		if m := manifestProjectNameRe.FindSubmatch(data); m != nil {
+			return strings.Map(func(r rune) rune {
+				if strings.ContainsRune(`<>:"/\|?*`, r) {
+					return -1
+				}
+				return r
+			}, string(m[1]))
+		}
+	}
+
+	return ""
+}
+
+// promptWithDefault prompts for a value, returning defaultValue when the input is empty.
+func promptWithDefault(reader *bufio.Reader, label, defaultValue string) (string, error) {
+	prompt := "Enter " + label
+	if defaultValue != "" {
+		prompt += fmt.Sprintf(" (default: %s)", defaultValue)
+	}
+	value, err := promptLine(reader, prompt+": ")
+	if err != nil || value != "" {
+		return value, err
+	}
+	return defaultValue, nil
+}
+
+// runAdopt writes the project configuration of an existing SDF project.
+func runAdopt() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("error getting current directory: %v", err)
+	}
+
+	if path := projectConfigFile(cwd); path != "" && !adoptForceFlag {
+		return fmt.Errorf("this project already has a %s file, use --force to overwrite it", filepath.Base(path))
+	}
+
+	manifestPath, ok := findManifestXML()
+	if !ok {
+		return errors.New("manifest.xml not found, run 'netsuite-cli adopt' from the root of a SuiteCloud project")
+	}
+
+	userConfig, err := LoadUserConfig()
+	if err != nil {
+		logWarn("Failed to load user configuration: %v", err)
+	}
+	if userConfig == nil {
+		userConfig = &UserConfig{}
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+
+	projectName := strings.TrimSpace(adoptNameFlag)
+	if projectName == "" {
+		if projectName, err = promptWithDefault(reader, "project name", inferProjectName(manifestPath)); err != nil {
+			return err
+		}
+	}
+	if projectName == "" {
+		return errors.New("project name cannot be empty")
+	}
+	if strings.ContainsAny(projectName, `<>:"/\|?*`) {
+		return errors.New("project name contains invalid characters")
+	}
+
+	defaultUserName := userConfig.UserName
+	if defaultUserName == "" {
+		if currentUser, err := user.Current(); err == nil && currentUser != nil {
+			parts := strings.Split(currentUser.Username, "\\")
+			defaultUserName = parts[len(parts)-1]
+		}
+	}
+
+	config := &ProjectConfig{ProjectName: projectName}
+	if config.CompanyName, err = promptWithDefault(reader, "company name", userConfig.CompanyName); err != nil {
+		return err
+	}
+	if config.UserName, err = promptWithDefault(reader, "user name", defaultUserName); err != nil {
+		return err
+	}
+	if config.UserEmail, err = promptWithDefault(reader, "user email", userConfig.UserEmail); err != nil {
+		return err
+	}
+	if config.CompanyName == "" || config.UserName == "" || config.UserEmail == "" {
+		return errors.New("company name, user name and user email are required")
+	}
+	config.CompanyPrefix = GetCompanyPrefix(config.CompanyName)
+
+	if err := SaveConfig(cwd, config); err != nil {
+		return err
+	}
+
+	logInfo("\n✓ Project '%s' adopted, configuration saved to .netsuite-cli", projectName)
+	logInfo("You can now run 'netsuite-cli add' in this project.")
+	return nil
+}
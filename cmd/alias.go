@@ -0,0 +1,350 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// aliasCmd represents the alias command
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage module path aliases",
+	Long: `Manage the module aliases of the project, such as @lib for the lib folder under
+SuiteScripts, so scripts can import "@lib/util" instead of a relative path. Aliases are
+written to the tsconfig paths and to an amdconfig.json file resolving them in NetSuite,
+referenced by the @NAmdConfig tag of the scripts generated afterwards.`,
+}
+
+// aliasListCmd represents the alias list command
+var aliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the module aliases",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAliasList()
+	},
+}
+
+// aliasAddCmd represents the alias add command
+var aliasAddCmd = &cobra.Command{
+	Use:   "add <alias> <folder>",
+	Short: "Add or update a module alias for a folder under SuiteScripts",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAliasAdd(args[0], args[1])
+	},
+}
+
+// aliasRemoveCmd represents the alias remove command
+var aliasRemoveCmd = &cobra.Command{
+	Use:   "remove <alias>",
+	Short: "Remove a module alias",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAliasRemove(args[0])
+	},
+}
+
+// aliasSyncCmd represents the alias sync command
+var aliasSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Rewrite the tsconfig paths and amdconfig.json from the configured aliases",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := loadProjectConfig()
+		if err != nil {
+			return err
+		}
+		return writeAliasFiles(".", config)
+	},
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasListCmd)
+	aliasCmd.AddCommand(aliasAddCmd)
+	aliasCmd.AddCommand(aliasRemoveCmd)
+	aliasCmd.AddCommand(aliasSyncCmd)
+	rootCmd.AddCommand(aliasCmd)
+}
+
+// normalizeAlias returns an alias without the trailing /* of the tsconfig form.
+func normalizeAlias(alias string) (string, error) {
+	alias = strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(alias), "/*"), "/")
+	switch {
+	case alias == "":
+		return "", errors.New("alias cannot be empty")
+	case strings.ContainsAny(alias, "* \t"):
+		return "", fmt.Errorf("alias '%s' cannot contain spaces or wildcards", alias)
+	case alias == "N" || strings.HasPrefix(alias, "N/") || strings.HasPrefix(alias, "."):
+		return "", fmt.Errorf("alias '%s' conflicts with the N modules or relative imports", alias)
+	}
+	return alias, nil
+}
+
+// parseAliases parses alias=folder pairs into a map of aliases.
+func parseAliases(values []string) (map[string]string, error) {
+	aliases := make(map[string]string)
+	for _, value := range values {
+		alias, folder, ok := strings.Cut(value, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid alias '%s', expected alias=folder", value)
+		}
+		alias, err := normalizeAlias(alias)
+		if err != nil {
+			return nil, err
+		}
+		if folder = normalizeFolderPath(folder); folder == "" {
+			return nil, fmt.Errorf("alias '%s' needs a folder under SuiteScripts", alias)
+		}
+		aliases[alias] = folder
+	}
+	return aliases, nil
+}
+
+// sortedAliases returns the aliases of the map in order.
+func sortedAliases(aliases map[string]string) []string {
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runAliasList prints the module aliases of the project.
+func runAliasList() error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+	names := sortedAliases(config.Aliases)
+
+	if jsonFlag {
+		type alias struct {
+			Alias  string `json:"alias"`
+			Folder string `json:"folder"`
+		}
+		aliases := make([]alias, 0, len(names))
+		for _, name := range names {
+			aliases = append(aliases, alias{Alias: name, Folder: config.Aliases[name]})
+		}
+		setJSONResult(aliases)
+		return nil
+	}
+
+	if len(names) == 0 {
+		fmt.Println("No aliases defined. Use 'netsuite-cli alias add <alias> <folder>' to add one.")
+		return nil
+	}
+	for _, name := range names {
+		fmt.Printf("%s/*\tSuiteScripts/%s/*\n", name, config.Aliases[name])
+	}
+	return nil
+}
+
+// runAliasAdd adds or updates a module alias and rewrites the alias files.
+func runAliasAdd(alias, folder string) error {
+	aliases, err := parseAliases([]string{alias + "=" + folder})
+	if err != nil {
+		return validationError("%v", err)
+	}
+	alias = sortedAliases(aliases)[0]
+	folder = aliases[alias]
+
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+	if suiteScriptsDir, ok := locateSuiteScriptsDir(); ok {
+		if info, err := os.Stat(filepath.Join(suiteScriptsDir, filepath.FromSlash(folder))); err != nil || !info.IsDir() {
+			logWarn("SuiteScripts/%s does not exist yet", folder)
+		}
+	}
+	if config.Aliases == nil {
+		config.Aliases = make(map[string]string)
+	}
+	config.Aliases[alias] = folder
+	if err := saveProjectConfig(config); err != nil {
+		return err
+	}
+	logInfo("Alias '%s' now points to SuiteScripts/%s", alias, folder)
+	return writeAliasFiles(".", config)
+}
+
+// runAliasRemove removes a module alias and rewrites the alias files.
+func runAliasRemove(alias string) error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+	name, err := normalizeAlias(alias)
+	if err != nil {
+		return validationError("%v", err)
+	}
+	if _, ok := config.Aliases[name]; !ok {
+		return fmt.Errorf("alias '%s' is not defined", name)
+	}
+	delete(config.Aliases, name)
+	if err := saveProjectConfig(config); err != nil {
+		return err
+	}
+	logInfo("Alias '%s' removed", name)
+	return writeAliasFiles(".", config)
+}
+
+// amdConfigFile returns the local path of the amdconfig.json file of the project in projectDir.
+func amdConfigFile(projectDir, projectName string) string {
+	return filepath.Join(projectDir, "src", "FileCabinet", "SuiteScripts", projectName, "amdconfig.json")
+}
+
+// writeAliasFiles writes the aliases of the project in projectDir to the paths of its tsconfig
+// or jsconfig and to its amdconfig.json. The amdconfig.json file is removed once no alias is left.
+func writeAliasFiles(projectDir string, config *ProjectConfig) error {
+	for _, name := range []string{"tsconfig.json", "jsconfig.json"} {
+		if err := updateTsconfigPaths(filepath.Join(projectDir, name), config.Aliases); err != nil {
+			return err
+		}
+	}
+
+	amdConfigPath := amdConfigFile(projectDir, config.ProjectName)
+	deployXMLPath := filepath.Join(projectDir, "src", "deploy.xml")
+	if len(config.Aliases) == 0 {
+		if _, err := os.Stat(amdConfigPath); err != nil {
+			return nil
+		}
+		if err := os.Remove(amdConfigPath); err != nil {
+			return fmt.Errorf("error removing %s: %v", amdConfigPath, err)
+		}
+		if fileExists(deployXMLPath) {
+			if _, err := removeDeployXMLReferences(deployXMLPath, []string{toSDFPath(amdConfigPath)}); err != nil {
+				logWarn("Failed to update %s: %v", deployXMLPath, err)
+			}
+		}
+		reportFile("Removed", amdConfigPath, "")
+		return nil
+	}
+
+	paths := make(map[string]string)
+	for alias, folder := range config.Aliases {
+		paths[alias] = path.Join("/SuiteScripts", folder)
+	}
+	data, err := json.MarshalIndent(map[string]any{"paths": paths}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling %s: %v", amdConfigPath, err)
+	}
+	data = append(data, '\n')
+
+	previous, readErr := os.ReadFile(amdConfigPath)
+	if readErr == nil && bytes.Equal(previous, data) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(amdConfigPath), 0755); err != nil {
+		return fmt.Errorf("error creating %s: %v", filepath.Dir(amdConfigPath), err)
+	}
+	recordGeneratedFile(amdConfigPath, previous, readErr == nil)
+	if err := os.WriteFile(amdConfigPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", amdConfigPath, err)
+	}
+	reportFile("Updated", amdConfigPath, "")
+
+	if fileExists(deployXMLPath) && !noDeployXMLFlag {
+		added, err := addDeployXMLReferences(deployXMLPath, []string{toSDFPath(amdConfigPath)}, true)
+		if err != nil {
+			logWarn("Failed to update %s: %v", deployXMLPath, err)
+		}
+		for _, sdfPath := range added {
+			logInfo("Added %s to %s", sdfPath, deployXMLPath)
+		}
+	}
+	return nil
+}
+
+// updateTsconfigPaths rewrites the alias entries of the compilerOptions paths of a tsconfig
+// file. Entries pointing into SuiteScripts are replaced by the aliases; others, such as the N
+// typings, are kept. A missing tsconfig is skipped.
+func updateTsconfigPaths(tsconfigPath string, aliases map[string]string) error {
+	data, err := os.ReadFile(tsconfigPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", tsconfigPath, err)
+	}
+
+	var tsconfig, compilerOptions, paths jsonObject
+	if err := json.Unmarshal(data, &tsconfig); err != nil {
+		return fmt.Errorf("error parsing %s: %v", tsconfigPath, err)
+	}
+	if raw, ok := tsconfig.Get("compilerOptions"); ok {
+		if err := json.Unmarshal(raw, &compilerOptions); err != nil {
+			return fmt.Errorf("error parsing %s: 'compilerOptions' is not an object: %v", tsconfigPath, err)
+		}
+	}
+	if raw, ok := compilerOptions.Get("paths"); ok {
+		if err := json.Unmarshal(raw, &paths); err != nil {
+			return fmt.Errorf("error parsing %s: 'paths' is not an object: %v", tsconfigPath, err)
+		}
+	}
+
+	// Paths are relative to baseUrl when set, and to the tsconfig directory otherwise.
+	baseDir := filepath.Dir(tsconfigPath)
+	if raw, ok := compilerOptions.Get("baseUrl"); ok {
+		var baseUrl string
+		if err := json.Unmarshal(raw, &baseUrl); err == nil {
+			baseDir = filepath.Join(baseDir, filepath.FromSlash(baseUrl))
+		}
+	}
+	suiteScripts, err := filepath.Rel(baseDir, filepath.Join(filepath.Dir(tsconfigPath), "src", "FileCabinet", "SuiteScripts"))
+	if err != nil {
+		return fmt.Errorf("error resolving the SuiteScripts folder from %s: %v", tsconfigPath, err)
+	}
+	suiteScripts = filepath.ToSlash(suiteScripts)
+
+	var updated jsonObject
+	for _, field := range paths {
+		var targets []string
+		managed := json.Unmarshal(field.Value, &targets) == nil && len(targets) > 0
+		for _, target := range targets {
+			if !strings.HasPrefix(target, suiteScripts+"/") {
+				managed = false
+			}
+		}
+		if _, alias := aliases[strings.TrimSuffix(field.Key, "/*")]; !managed && !alias {
+			updated = append(updated, field)
+		}
+	}
+	for _, alias := range sortedAliases(aliases) {
+		if err := updated.Set(alias+"/*", []string{suiteScripts + "/" + aliases[alias] + "/*"}); err != nil {
+			return err
+		}
+	}
+	if err := compilerOptions.Set("paths", updated); err != nil {
+		return err
+	}
+	if err := tsconfig.Set("compilerOptions", compilerOptions); err != nil {
+		return err
+	}
+
+	content, err := marshalJSONUnescaped(tsconfig, "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling %s: %v", tsconfigPath, err)
+	}
+	if bytes.Equal(bytes.TrimSpace(content), bytes.TrimSpace(data)) {
+		return nil
+	}
+	recordGeneratedFile(tsconfigPath, data, true)
+	if err := os.WriteFile(tsconfigPath, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", tsconfigPath, err)
+	}
+	logInfo("Updated the paths of %s", tsconfigPath)
+	return nil
+}
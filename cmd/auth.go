@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"netsuite-cli/internal/auth"
+	"netsuite-cli/internal/credentials"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	authPrivateKeyFlag string
+	authAllFlag        bool
+)
+
+// authCmd represents the auth command
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage the OAuth 2.0 credentials of the account profiles",
+	Long: `Manage the credentials the REST based commands authenticate with. Access tokens and
+imported private keys are kept in the credential store of the operating system (the
+Keychain on macOS, the Secret Service through libsecret on Linux and DPAPI encrypted
+files on Windows) rather than in plain files.`,
+}
+
+// authLoginCmd represents the auth login command
+var authLoginCmd = &cobra.Command{
+	Use:   "login [label]",
+	Short: "Request an access token for an account profile",
+	Long: `Request an access token with the OAuth 2.0 client credentials flow using the
+integration client ID, certificate ID and private key of the account profile (default:
+the default profile), and store it in the credential store. The token is reused by the
+REST based commands until it expires.
+
+With --private-key the key is imported into the credential store and the path is removed
+from the profile, so the key file can be deleted afterwards.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		label := ""
+		if len(args) > 0 {
+			label = args[0]
+		}
+		return runAuthLogin(label)
+	},
+}
+
+// authLogoutCmd represents the auth logout command
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout [label]",
+	Short: "Remove the access token and imported private key of an account profile",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		label := ""
+		if len(args) > 0 {
+			label = args[0]
+		}
+		return runAuthLogout(label)
+	},
+}
+
+// authStatusCmd represents the auth status command
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the credentials stored for each account profile",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAuthStatus()
+	},
+}
+
+func init() {
+	authLoginCmd.Flags().StringVar(&authPrivateKeyFlag, "private-key", "", "PEM private key to import into the credential store")
+	authLogoutCmd.Flags().BoolVar(&authAllFlag, "all", false, "Log out of every account profile")
+
+	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authLogoutCmd)
+	authCmd.AddCommand(authStatusCmd)
+	rootCmd.AddCommand(authCmd)
+}
+
+// openedCredentialStore is the credential store returned by credentialStore.
+var openedCredentialStore credentials.Store
+
+// credentialStore returns the credential store of the operating system.
+func credentialStore() credentials.Store {
+	if openedCredentialStore == nil {
+		userDir, _ := UserConfigDir()
+		openedCredentialStore = credentials.Open(userDir)
+	}
+	return openedCredentialStore
+}
+
+// privateKeyName returns the key the imported private key of an account profile is stored
+// under in the credential store.
+func privateKeyName(label string) string {
+	return "private-key:" + strings.ToLower(label)
+}
+
+// storedPrivateKey returns the private key of the account profile imported into the credential
+// store, or nil when none was imported.
+func storedPrivateKey(account *AccountProfile) []byte {
+	key, err := credentialStore().Get(privateKeyName(account.Label))
+	if err != nil {
+		return nil
+	}
+	return key
+}
+
+// runAuthLogin imports the private key given with --private-key, if any, and requests an access
+// token for the account profile.
+func runAuthLogin(label string) error {
+	userConfig, err := loadUserConfig()
+	if err != nil {
+		return err
+	}
+	account, err := resolveAccount(label)
+	if err != nil {
+		return err
+	}
+	account = userConfig.FindAccount(account.Label)
+	store := credentialStore()
+
+	if authPrivateKeyFlag != "" {
+		key, err := os.ReadFile(authPrivateKeyFlag)
+		if err != nil {
+			return fmt.Errorf("error reading private key: %v", err)
+		}
+		if _, err := auth.ParsePrivateKey(key); err != nil {
+			return validationError("invalid private key %s: %v", authPrivateKeyFlag, err)
+		}
+		if err := store.Set(privateKeyName(account.Label), key); err != nil {
+			return configError(err)
+		}
+		if account.PrivateKeyPath != "" {
+			account.PrivateKeyPath = ""
+			if err := SaveUserConfig(userConfig); err != nil {
+				return err
+			}
+		}
+		logInfo("Imported the private key into the %s, %s can be deleted", store.Name(), authPrivateKeyFlag)
+	}
+
+	client, err := newAuthClient(account)
+	if err != nil {
+		return err
+	}
+	client.Invalidate()
+
+	ctx, cancel := context.WithTimeout(runContext, 30*time.Second)
+	defer cancel()
+
+	token, err := client.Token(ctx)
+	if err != nil {
+		return err
+	}
+	if client.Cached() == nil {
+		logWarn("The access token could not be stored (%s) and will be requested again by every command.", store.Name())
+	}
+
+	logInfo("✓ Authenticated to %s, token valid until %s", account.AccountID, token.ExpiresAt.Format(time.Kitchen))
+	return nil
+}
+
+// runAuthLogout removes the access token and imported private key of the account profile, or of
+// every profile with --all.
+func runAuthLogout(label string) error {
+	var accounts []AccountProfile
+	if authAllFlag {
+		userConfig, err := loadUserConfig()
+		if err != nil {
+			return err
+		}
+		accounts = userConfig.Accounts
+	} else {
+		account, err := resolveAccount(label)
+		if err != nil {
+			return err
+		}
+		accounts = []AccountProfile{*account}
+	}
+
+	store := credentialStore()
+	for _, account := range accounts {
+		hadKey := storedPrivateKey(&account) != nil
+		if err := store.Delete(auth.TokenKey(account.AccountID, account.ClientID)); err != nil {
+			return configError(err)
+		}
+		if err := store.Delete(privateKeyName(account.Label)); err != nil {
+			return configError(err)
+		}
+		logInfo("Logged out of '%s'", account.Label)
+		if hadKey && account.PrivateKeyPath == "" {
+			logInfo("  The imported private key was removed, import it again with 'netsuite-cli auth login %s --private-key <path>'", account.Label)
+		}
+	}
+	return nil
+}
+
+// AuthStatus describes the credentials of an account profile.
+type AuthStatus struct {
+	Label      string     `json:"label"`
+	AccountID  string     `json:"accountId"`
+	PrivateKey string     `json:"privateKey"`
+	ExpiresAt  *time.Time `json:"tokenExpiresAt,omitempty"`
+	Valid      bool       `json:"tokenValid"`
+}
+
+// runAuthStatus prints where the private key of each account profile comes from and whether a
+// valid access token is stored for it.
+func runAuthStatus() error {
+	userConfig, err := loadUserConfig()
+	if err != nil {
+		return err
+	}
+	store := credentialStore()
+
+	var statuses []AuthStatus
+	for i := range userConfig.Accounts {
+		account := &userConfig.Accounts[i]
+		if account.ClientID == "" && account.PrivateKeyPath == "" && storedPrivateKey(account) == nil {
+			continue
+		}
+		status := AuthStatus{Label: account.Label, AccountID: account.AccountID, PrivateKey: "missing"}
+		switch {
+		case storedPrivateKey(account) != nil:
+			status.PrivateKey = "credential store"
+		case account.PrivateKeyPath != "":
+			status.PrivateKey = account.PrivateKeyPath
+		}
+		client := auth.NewClient(auth.Credentials{AccountID: account.AccountID, ClientID: account.ClientID}, store)
+		if token := client.Cached(); token != nil {
+			status.ExpiresAt = &token.ExpiresAt
+			status.Valid = token.Valid()
+		}
+		statuses = append(statuses, status)
+	}
+
+	if jsonFlag {
+		setJSONResult(map[string]any{"store": store.Name(), "accounts": statuses})
+		return nil
+	}
+
+	fmt.Printf("Credential store: %s\n", store.Name())
+	if len(statuses) == 0 {
+		fmt.Println("No account profiles with OAuth 2.0 credentials. Use 'netsuite-cli account add <label> --client-id ... --certificate-id ...' to add them.")
+		return nil
+	}
+	for _, status := range statuses {
+		token := "no token"
+		switch {
+		case status.Valid:
+			token = "token valid until " + status.ExpiresAt.Format(time.Kitchen)
+		case status.ExpiresAt != nil:
+			token = "token expired"
+		}
+		fmt.Printf("  %s\t%s\tkey: %s\t%s\n", status.Label, status.AccountID, status.PrivateKey, token)
+	}
+	return nil
+}
+
+// removeLegacyTokenCache removes the access tokens earlier versions cached in plain files under
+// the user configuration directory, now that they are kept in the credential store.
+func removeLegacyTokenCache() {
+	userDir, err := UserConfigDir()
+	if err != nil {
+		return
+	}
+	if err := os.RemoveAll(filepath.Join(userDir, "tokens")); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logWarn("Failed to remove the plain token cache: %v", err)
+	}
+}
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupTypeFlag         []string
+	backupPrefixFlag       string
+	backupOutputFlag       string
+	backupBranchFlag       string
+	backupExcludeFilesFlag bool
+)
+
+// backupCmd represents the backup command
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Snapshot the customization objects of the account",
+	Long: `Import all customization objects of the account, or only those of the given types, with
+'suitecloud object:import' and store them in a timestamped folder under backups, or commit them
+to a separate git branch with --branch. Useful before big releases or sandbox refreshes. The
+project files are not modified.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBackup()
+	},
+}
+
+func init() {
+	backupCmd.Flags().StringSliceVarP(&backupTypeFlag, "type", "t", nil, "Comma separated object types to back up (default: all)")
+	backupCmd.Flags().StringVar(&backupPrefixFlag, "prefix", "", "Only back up objects whose script ID starts with this prefix")
+	backupCmd.Flags().StringVarP(&backupOutputFlag, "output", "o", "", "Folder to write the backup to (default: backups/<timestamp>)")
+	backupCmd.Flags().StringVar(&backupBranchFlag, "branch", "", "Commit the backup to this git branch instead of writing a folder")
+	backupCmd.Flags().BoolVar(&backupExcludeFilesFlag, "exclude-files", false, "Do not back up the files referenced by the objects")
+	backupCmd.Flags().StringVarP(&envFlag, "env", "e", "", "Project environment to back up")
+
+	rootCmd.AddCommand(backupCmd)
+}
+
+// runBackup imports the account objects into a temporary project and stores them as a backup.
+func runBackup() error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	if backupOutputFlag != "" && backupBranchFlag != "" {
+		return errors.New("--output and --branch cannot be used together")
+	}
+	if backupBranchFlag != "" {
+		if err := externalCommand("git", "check-ref-format", "--branch", backupBranchFlag).Run(); err != nil {
+			return fmt.Errorf("invalid branch name '%s'", backupBranchFlag)
+		}
+		if err := externalCommand("git", "rev-parse", "--git-dir").Run(); err != nil {
+			return errors.New("--branch requires the project to be a git repository")
+		}
+	}
+
+	suiteCloudCmd, err := ensureSuiteCloudCommand()
+	if err != nil {
+		return err
+	}
+
+	restore, err := activateEnvironment(config, envFlag)
+	if err != nil {
+		return err
+	}
+	tempDir, err := newTempProject()
+	restore()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	logInfo("Listing objects in the account...")
+	objects, err := listAccountObjects(suiteCloudCmd, backupTypeFlag, backupPrefixFlag)
+	if err != nil {
+		return err
+	}
+	if len(objects) == 0 {
+		logInfo("No importable objects found.")
+		return nil
+	}
+
+	byType := make(map[string][]string)
+	var types []string
+	for _, object := range objects {
+		if _, ok := byType[object.Type]; !ok {
+			types = append(types, object.Type)
+		}
+		byType[object.Type] = append(byType[object.Type], object.ScriptId)
+	}
+
+	for _, objectType := range types {
+		destination := "/Objects/" + objectType
+		if err := os.MkdirAll(filepath.Join(tempDir, "src", "Objects", objectType), 0755); err != nil {
+			return fmt.Errorf("error creating temporary folder: %v", err)
+		}
+		args := []string{"object:import", "--type", objectType, "--destinationfolder", destination, "--scriptid"}
+		args = append(args, byType[objectType]...)
+		if backupExcludeFilesFlag {
+			args = append(args, "--excludefiles")
+		}
+
+		logInfo("Importing %d %s object(s)...", len(byType[objectType]), objectType)
+		var output bytes.Buffer
+		importCmd := suiteCloudExec(suiteCloudCmd, args...)
+		importCmd.Dir = tempDir
+		importCmd.Stdout = &output
+		importCmd.Stderr = &output
+		logCommand(importCmd)
+		if err := importCmd.Run(); err != nil {
+			fmt.Print(output.String())
+			return toolError("suitecloud", fmt.Errorf("error importing %s objects: %v", objectType, err))
+		}
+	}
+
+	snapshotDir := filepath.Join(tempDir, "src")
+	if err := os.Remove(filepath.Join(snapshotDir, "manifest.xml")); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	timestamp := time.Now()
+	if backupBranchFlag != "" {
+		message := fmt.Sprintf("Backup of %d object(s) on %s", len(objects), timestamp.Format("2006-01-02 15:04:05"))
+		commit, err := commitBackupToBranch(snapshotDir, backupBranchFlag, message, config.UserName, config.UserEmail)
+		if err != nil {
+			return err
+		}
+		logInfo("\n✓ Backed up %d object(s) to branch %s (%s).", len(objects), backupBranchFlag, commit[:min(len(commit), 7)])
+		return nil
+	}
+
+	outputDir := backupOutputFlag
+	if outputDir == "" {
+		outputDir = filepath.Join("backups", timestamp.Format("20060102-150405"))
+	} else {
+		outputDir = projectRelativePath(outputDir)
+	}
+	if err := copyDir(snapshotDir, outputDir); err != nil {
+		return err
+	}
+	logInfo("\n✓ Backed up %d object(s) to %s.", len(objects), outputDir)
+	return nil
+}
+
+// copyDir copies the files under src to dst, creating dst if needed.
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %v", path, err)
+		}
+		if err := os.WriteFile(target, data, 0644); err != nil {
+			return fmt.Errorf("error writing %s: %v", target, err)
+		}
+		return nil
+	})
+}
+
+// commitBackupToBranch commits the content of dir to branch with git plumbing commands, using a
+// temporary index so the working tree, index and current branch are left untouched. The commit
+// is added on top of the branch if it already exists. The project user is the author when git
+// has no identity configured. It returns the new commit hash.
+func commitBackupToBranch(dir, branch, message, userName, userEmail string) (string, error) {
+	index, err := os.CreateTemp("", "netsuite-cli-backup-index-")
+	if err != nil {
+		return "", fmt.Errorf("error creating temporary index: %v", err)
+	}
+	index.Close()
+	os.Remove(index.Name())
+	defer os.Remove(index.Name())
+
+	env := append(os.Environ(), "GIT_INDEX_FILE="+index.Name())
+	if out, _ := externalCommand("git", "config", "user.email").Output(); strings.TrimSpace(string(out)) == "" && userEmail != "" {
+		env = append(env, "GIT_AUTHOR_NAME="+userName, "GIT_AUTHOR_EMAIL="+userEmail, "GIT_COMMITTER_NAME="+userName, "GIT_COMMITTER_EMAIL="+userEmail)
+	}
+	git := func(args ...string) (string, error) {
+		gitCmd := externalCommand("git", args...)
+		gitCmd.Env = env
+		var stderr bytes.Buffer
+		gitCmd.Stderr = &stderr
+		logCommand(gitCmd)
+		out, err := gitCmd.Output()
+		if err != nil {
+			return "", toolError("git", fmt.Errorf("git %s failed: %v %s", args[0], err, strings.TrimSpace(stderr.String())))
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	gitDir, err := git("rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", err
+	}
+	if _, err := git("-C", absDir, "--git-dir="+gitDir, "--work-tree="+absDir, "add", "--all", "--force", "."); err != nil {
+		return "", err
+	}
+	tree, err := git("write-tree")
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{"commit-tree", tree, "-m", message}
+	if parent, err := git("rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil && parent != "" {
+		args = append(args, "-p", parent)
+	}
+	commit, err := git(args...)
+	if err != nil {
+		return "", err
+	}
+	if _, err := git("update-ref", "refs/heads/"+branch, commit); err != nil {
+		return "", err
+	}
+	return commit, nil
+}
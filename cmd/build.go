@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var buildTsconfigFlag string
+
+// buildCmd represents the build command
+var buildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Compile TypeScript sources into the FileCabinet layout",
+	Long: `Run the TypeScript compiler with the project's tsconfig so the compiled
+JavaScript is emitted next to the TypeScript files under src/FileCabinet/SuiteScripts,
+ready for 'suitecloud project:deploy'. With --bundle, every entry script is bundled with
+esbuild into a single AMD file instead, including its relative imports and keeping the N
+modules external.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBuild()
+	},
+}
+
+func init() {
+	buildCmd.Flags().StringVar(&buildTsconfigFlag, "tsconfig", "tsconfig.json", "Path to the tsconfig file")
+	buildCmd.Flags().BoolVar(&bundleFlag, "bundle", false, "Bundle each entry script with its imports into a single AMD file using esbuild")
+
+	rootCmd.AddCommand(buildCmd)
+}
+
+// getNpxCommand checks for the availability of the npx command.
+func getNpxCommand() string {
+	if _, err := exec.LookPath("npx"); err == nil {
+		return "npx"
+	}
+	if _, err := exec.LookPath("npx.cmd"); err == nil {
+		return "npx.cmd"
+	}
+	return ""
+}
+
+// runBuild executes the TypeScript compilation.
+func runBuild() error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := runLifecycleHook(config, "preBuild", HookData{Command: "build"}); err != nil {
+		return err
+	}
+	if err := buildSources(config, buildTsconfigFlag); err != nil {
+		return err
+	}
+
+	logInfo("✓ Build completed successfully.")
+	return runLifecycleHook(config, "postBuild", HookData{Command: "build"})
+}
+
+// buildSources compiles the TypeScript sources with tsconfig, or bundles the entry scripts with
+// --bundle. JavaScript projects without the tsconfig are deployed as written, so only the
+// shared library of the workspace is synced.
+func buildSources(config *ProjectConfig, tsconfig string) error {
+	switch {
+	case bundleFlag:
+		return bundleScripts(config)
+	case config.ScriptLanguage() == languageJavaScript && !fileExists(tsconfig):
+		logInfo("JavaScript project, nothing to compile.")
+		return syncSharedLib()
+	default:
+		return compileTypeScript(tsconfig)
+	}
+}
+
+// compileTypeScript runs the TypeScript compiler with the given tsconfig file, after syncing the
+// shared library of the workspace into the project.
+func compileTypeScript(tsconfig string) error {
+	if _, err := os.Stat(tsconfig); err != nil {
+		return fmt.Errorf("tsconfig file %s not found", tsconfig)
+	}
+	if err := syncSharedLib(); err != nil {
+		return err
+	}
+
+	npxCmd := getNpxCommand()
+	if npxCmd == "" {
+		return toolError("npx", errors.New("npx is not available in the command line, install Node.js and npm"))
+	}
+
+	tscCmd := externalCommand(npxCmd, "tsc", "-p", filepath.Clean(tsconfig))
+	tscCmd.Stdout = os.Stdout
+	tscCmd.Stderr = os.Stderr
+
+	logCommand(tscCmd)
+	if err := tscCmd.Run(); err != nil {
+		return toolError("tsc", fmt.Errorf("TypeScript compilation failed: %v", err))
+	}
+	return nil
+}
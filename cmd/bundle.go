@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// bundleFlag makes build and deploy bundle every entry script with esbuild instead of compiling
+// the sources with tsc.
+var bundleFlag bool
+
+// bundleHeaderRe matches the JSDoc blocks of a script, the one holding the SuiteScript tags is
+// copied to the top of the bundle.
+var bundleHeaderRe = regexp.MustCompile(`(?s)/\*\*.*?\*/`)
+
+// findEntryScripts returns the TypeScript scripts under suiteScriptsDir declaring a script type.
+// Libraries, declaration files and tests are left out.
+func findEntryScripts(suiteScriptsDir string) ([]string, error) {
+	var entries []string
+	err := filepath.WalkDir(suiteScriptsDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "node_modules" || d.Name() == testsDir || d.Name() == "__mocks__" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".ts" || strings.HasSuffix(path, ".d.ts") || strings.HasSuffix(path, ".test.ts") {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if graphScriptTypeRe.Match(content) {
+			entries = append(entries, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", suiteScriptsDir, err)
+	}
+	return entries, nil
+}
+
+// bundleScripts bundles every entry script of the project with its relative imports into a
+// single AMD module written next to it, in place of the file tsc would emit. The N modules are
+// kept external and loaded through define. esbuild does not check types.
+func bundleScripts(config *ProjectConfig) error {
+	if config.ScriptLanguage() == languageJavaScript {
+		return configError(errors.New("bundling is only available for TypeScript projects, JavaScript scripts are deployed as written"))
+	}
+	if config.SuiteScriptVersion() == "2.0" {
+		return configError(errors.New("bundling requires SuiteScript 2.1 or 2.x, esbuild cannot compile to the ES5 used by 2.0 scripts"))
+	}
+	npxCmd := getNpxCommand()
+	if npxCmd == "" {
+		return toolError("npx", errors.New("npx is not available in the command line, install Node.js and npm"))
+	}
+	if err := syncSharedLib(); err != nil {
+		return err
+	}
+
+	suiteScriptsDir, err := findSuiteScriptsDir()
+	if err != nil {
+		return err
+	}
+	entries, err := findEntryScripts(suiteScriptsDir)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		logInfo("No entry scripts found in %s.", suiteScriptsDir)
+		return nil
+	}
+
+	for _, entry := range entries {
+		if err := bundleScript(npxCmd, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bundleScript bundles a single entry script into the .js file next to it.
+func bundleScript(npxCmd, entry string) error {
+	var stdout, stderr bytes.Buffer
+	esbuildCmd := externalCommand(npxCmd, "esbuild", entry,
+		"--bundle",
+		"--format=cjs",
+		"--platform=neutral",
+		"--main-fields=module,main",
+		"--target=es2019",
+		"--external:N",
+		"--external:N/*",
+		"--log-level=warning",
+	)
+	esbuildCmd.Stdout = &stdout
+	esbuildCmd.Stderr = &stderr
+	logCommand(esbuildCmd)
+	if err := esbuildCmd.Run(); err != nil {
+		os.Stderr.Write(stderr.Bytes())
+		return toolError("esbuild", fmt.Errorf("bundling %s failed: %v", entry, err))
+	}
+	os.Stderr.Write(stderr.Bytes())
+
+	source, err := os.ReadFile(entry)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", entry, err)
+	}
+
+	output := strings.TrimSuffix(entry, ".ts") + ".js"
+	if err := os.WriteFile(output, []byte(wrapAMDBundle(string(source), stdout.String())), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", output, err)
+	}
+	logInfo("Bundled %s", output)
+	return nil
+}
+
+// wrapAMDBundle wraps a CommonJS bundle into an AMD module loading the N modules it requires,
+// preceded by the SuiteScript JSDoc tags of the entry source.
+func wrapAMDBundle(source, bundle string) string {
+	var b strings.Builder
+	for _, header := range bundleHeaderRe.FindAllString(source, -1) {
+		if strings.Contains(header, "@NApiVersion") || strings.Contains(header, "@NScriptType") {
+			b.WriteString(header + "\n")
+			break
+		}
+	}
+
+	dependencies := []string{`"require"`, `"exports"`, `"module"`}
+	seen := make(map[string]bool)
+	for _, m := range graphRequireRe.FindAllStringSubmatch(bundle, -1) {
+		if module := m[1]; (module == "N" || strings.HasPrefix(module, "N/")) && !seen[module] {
+			seen[module] = true
+			dependencies = append(dependencies, `"`+module+`"`)
+		}
+	}
+
+	fmt.Fprintf(&b, "define([%s], function (require, exports, module) {\n", strings.Join(dependencies, ", "))
+	b.WriteString(bundle)
+	b.WriteString("return module.exports;\n});\n")
+	return b.String()
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"netsuite-cli/pkg/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+// cloneCmd represents the clone command
+var cloneCmd = &cobra.Command{
+	Use:   "clone <script-name> [new-name]",
+	Short: "Create a new script as a copy of an existing one",
+	Long: `Copy the TypeScript file, object XML and unit test of an existing script to a new script,
+rewriting the scriptid, deploymentid, name and scriptfile path. The copies are placed next to
+the originals. The new name is asked for when it is not given.`,
+	Args: cobra.RangeArgs(1, 2),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if !dryRunFlag {
+			beginGeneration(strings.Join(append([]string{cmd.CommandPath()}, args...), " "))
+		}
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		newName := ""
+		if len(args) > 1 {
+			newName = args[1]
+		}
+		return runClone(args[0], newName)
+	},
+	PostRun: func(cmd *cobra.Command, args []string) {
+		if err := commitGeneration("."); err != nil {
+			logWarn("Failed to update %s: %v", lockFileName, err)
+		}
+	},
+}
+
+func init() {
+	cloneCmd.Flags().BoolVar(&forceFlag, "force", false, "Overwrite existing files without asking")
+	cloneCmd.Flags().BoolVar(&noDeployXMLFlag, "no-deployxml", false, "Do not add the new files to deploy.xml")
+	cloneCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Print the files that would be created without writing them")
+
+	rootCmd.AddCommand(cloneCmd)
+}
+
+// runClone copies the files of an existing script to a new script.
+func runClone(sourceName, newName string) error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	files, err := locateScript(config, sourceName)
+	if err != nil {
+		return err
+	}
+	if files.ScriptType == "" {
+		return fmt.Errorf("no TypeScript file found for script '%s'", sourceName)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		newName, err = promptLine(reader, fmt.Sprintf("Enter the name of the copy of %s: ", files.Name))
+		if err != nil {
+			return err
+		}
+	}
+	if newName == "" {
+		return errors.New("new script name is required")
+	}
+	if newName == files.Name {
+		return errors.New("the new script name must differ from the original")
+	}
+
+	oldNaming, err := config.ScriptNaming(files.Name, files.ScriptType)
+	if err != nil {
+		return err
+	}
+	newNaming, err := config.ScriptNaming(newName, files.ScriptType)
+	if err != nil {
+		return namingError(err)
+	}
+
+	oldBase := strings.TrimSuffix(filepath.Base(files.TSPath), ".ts")
+	newBase := newNaming.FileName
+	replacer := strings.NewReplacer(
+		oldNaming.ScriptId, newNaming.ScriptId,
+		oldNaming.DeploymentId, newNaming.DeploymentId,
+		oldBase+".ts", newBase+".ts",
+		oldBase+".js", newBase+".js",
+		"/"+oldBase+"'", "/"+newBase+"'",
+		"/"+oldBase+"\"", "/"+newBase+"\"",
+	)
+
+	var deployPaths []string
+	newTSPath := filepath.Join(filepath.Dir(files.TSPath), newBase+".ts")
+	content, err := readScriptFile(files.TSPath)
+	if err != nil {
+		return err
+	}
+	content = replacer.Replace(content)
+	content = strings.Replace(content, "@NScriptName "+files.Name+"\n", "@NScriptName "+newName+"\n", 1)
+	written, err := writeGenerated(reader, newTSPath, []byte(content))
+	if err != nil {
+		return err
+	}
+	if written != "" {
+		reportFile("Created", written, "")
+		if written == newTSPath {
+			deployPaths = append(deployPaths, strings.TrimSuffix(newTSPath, ".ts")+".js")
+		}
+	} else if dryRunFlag {
+		deployPaths = append(deployPaths, strings.TrimSuffix(newTSPath, ".ts")+".js")
+	}
+
+	testPath := filepath.Join(testsDir, oldBase+".test.ts")
+	if _, err := os.Stat(testPath); err == nil {
+		content, err := readScriptFile(testPath)
+		if err != nil {
+			return err
+		}
+		newTestPath := filepath.Join(testsDir, newBase+".test.ts")
+		written, err := writeGenerated(reader, newTestPath, []byte(replacer.Replace(content)))
+		if err != nil {
+			return err
+		}
+		if written != "" {
+			reportFile("Created", written, "")
+		}
+	}
+
+	if files.XMLPath != "" {
+		newXMLPath := filepath.Join(filepath.Dir(files.XMLPath), newNaming.ObjectFileName+".xml")
+		content, err := readScriptFile(files.XMLPath)
+		if err != nil {
+			return err
+		}
+		content = replacer.Replace(content)
+		content = strings.Replace(content, "<name>"+scaffold.EscapeXML(files.Name)+"</name>", "<name>"+scaffold.EscapeXML(newName)+"</name>", 1)
+		content = strings.ReplaceAll(content, "<title>"+scaffold.EscapeXML(files.Name)+"</title>", "<title>"+scaffold.EscapeXML(newName)+"</title>")
+		written, err := writeGenerated(reader, newXMLPath, []byte(content))
+		if err != nil {
+			return err
+		}
+		if written != "" {
+			reportFile("Created", written, "")
+		}
+		if written == newXMLPath || dryRunFlag {
+			deployPaths = append(deployPaths, newXMLPath)
+		}
+	}
+
+	registerInDeployXML(deployPaths...)
+	return nil
+}
+
+// readScriptFile returns the content of a script file.
+func readScriptFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %v", path, err)
+	}
+	return string(data), nil
+}
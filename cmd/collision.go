@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var objectScriptIdRe = regexp.MustCompile(`\sscriptid="([^"]+)"`)
+
+// existingObjectIds returns the script IDs declared in the object XML files of the project,
+// including the IDs of nested objects such as deployments and fields, mapped to their files.
+func existingObjectIds() map[string]string {
+	ids := make(map[string]string)
+
+	objectsDir, err := findObjectsDir()
+	if err != nil {
+		return ids
+	}
+
+	filepath.WalkDir(objectsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".xml" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		for _, m := range objectScriptIdRe.FindAllSubmatch(data, -1) {
+			id := string(m[1])
+			if _, ok := ids[id]; !ok {
+				ids[id] = path
+			}
+		}
+		return nil
+	})
+	return ids
+}
+
+// findCollision returns the first of the given IDs already declared in the project, and the file
+// declaring it. IDs declared in objectFile are ignored, since that file is regenerated in place.
+func findCollision(existing map[string]string, objectFile string, ids ...string) (string, string, bool) {
+	for _, id := range ids {
+		if path, ok := existing[id]; ok && filepath.Base(path) != objectFile {
+			return id, path, true
+		}
+	}
+	return "", "", false
+}
+
+// checkObjectIdAvailable returns an error if an object ID is already declared in the project
+// by a file other than the object's own <id>.xml.
+func checkObjectIdAvailable(id string) error {
+	if _, path, ok := findCollision(existingObjectIds(), id+".xml", id); ok {
+		return fmt.Errorf("object ID '%s' is already used in %s", id, path)
+	}
+	return nil
+}
+
+// resolveScriptNameCollision checks the IDs generated for a script against the objects of the
+// project. On a collision the user is offered the next free numbered name, e.g. order_sync_2;
+// with --yes the command fails instead.
+func resolveScriptNameCollision(config *ProjectConfig, reader *bufio.Reader, scriptName, scriptType string) (string, ScriptNaming, error) {
+	existing := existingObjectIds()
+
+	naming, err := config.ScriptNaming(scriptName, scriptType)
+	if err != nil {
+		return "", ScriptNaming{}, err
+	}
+
+	id, path, ok := findCollision(existing, naming.ObjectFileName+".xml", naming.ScriptId, naming.DeploymentId)
+	if !ok {
+		return scriptName, naming, nil
+	}
+	fmt.Printf("ID '%s' is already used in %s\n", id, path)
+	if yesFlag {
+		return "", ScriptNaming{}, errors.New("choose a different script name")
+	}
+
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s_%d", scriptName, n)
+		candidateNaming, err := config.ScriptNaming(candidate, scriptType)
+		if err != nil {
+			return "", ScriptNaming{}, err
+		}
+		if _, _, ok := findCollision(existing, candidateNaming.ObjectFileName+".xml", candidateNaming.ScriptId, candidateNaming.DeploymentId); ok {
+			continue
+		}
+
+		use, err := promptConfirm(reader, fmt.Sprintf("Use '%s' instead? (y/n): ", candidate))
+		if err != nil {
+			return "", ScriptNaming{}, err
+		}
+		if !use {
+			return "", ScriptNaming{}, errors.New("aborted, choose a different script name")
+		}
+		return candidate, candidateNaming, nil
+	}
+}
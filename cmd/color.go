@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+)
+
+// noColorFlag disables colored output, as does a non-empty NO_COLOR environment variable.
+var noColorFlag bool
+
+// ANSI escape sequences of the colors used in the output.
+const (
+	colorReset   = "\033[0m"
+	colorRed     = "\033[31m"
+	colorGreen   = "\033[32m"
+	colorYellow  = "\033[33m"
+	colorCyan    = "\033[36m"
+	colorGray    = "\033[90m"
+	colorBoldRed = "\033[1;31m"
+)
+
+// colorEnabled reports whether output written to file is colored: only when it is a terminal,
+// neither --no-color, --json nor NO_COLOR (https://no-color.org) is set and TERM is not dumb.
+func colorEnabled(file *os.File) bool {
+	if noColorFlag || jsonFlag || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return isTerminal(file)
+}
+
+// isTerminal reports whether file is a terminal rather than a pipe or a regular file.
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// colorize wraps text in the color when output written to file is colored.
+func colorize(file *os.File, color, text string) string {
+	if color == "" || !colorEnabled(file) {
+		return text
+	}
+	return color + text + colorReset
+}
+
+// infoColor returns the color of a progress message: green for the files created and the
+// successful steps, yellow for the files skipped because they already exist.
+func infoColor(message string) string {
+	switch {
+	case strings.HasPrefix(message, "Created "), strings.HasPrefix(message, "✓"):
+		return colorGreen
+	case strings.HasPrefix(message, "Skipped "):
+		return colorYellow
+	}
+	return ""
+}
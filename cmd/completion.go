@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// completionCmd represents the completion command
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate the shell completion script",
+	Long: `Generate the completion script for the given shell. Besides commands and flags, it
+completes script types after 'add', folders under SuiteScripts for --folder, record types
+for --record-type (from the metadata cache when synced) and project environments for --env.
+
+  bash:       source <(netsuite-cli completion bash)
+  zsh:        netsuite-cli completion zsh > "${fpath[1]}/_netsuite-cli"
+  fish:       netsuite-cli completion fish > ~/.config/fish/completions/netsuite-cli.fish
+  powershell: netsuite-cli completion powershell | Out-String | Invoke-Expression`,
+	ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		default:
+			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
+
+// flagCompletions maps flag names to the functions completing their values, for every command
+// defining a flag of that name.
+var flagCompletions = map[string]cobra.CompletionFunc{
+	"env":         completeEnvironments,
+	"folder":      completeFolders,
+	"lang":        cobra.FixedCompletions([]string{languageTypeScript, languageJavaScript}, cobra.ShellCompDirectiveNoFileComp),
+	"project":     completeWorkspaceProjects,
+	"record-type": completeRecordTypes,
+}
+
+// registerCompletions registers the flag completion functions on the command tree. It runs from
+// Execute, once every command has been added.
+func registerCompletions(cmd *cobra.Command) {
+	for name, complete := range flagCompletions {
+		if cmd.LocalFlags().Lookup(name) == nil {
+			continue
+		}
+		if _, exists := cmd.GetFlagCompletionFunc(name); !exists {
+			_ = cmd.RegisterFlagCompletionFunc(name, complete)
+		}
+	}
+	for _, child := range cmd.Commands() {
+		registerCompletions(child)
+	}
+}
+
+// loadCompletionConfig loads the project configuration for a completion function. Completions
+// run without the pre-run hooks, so --project-dir is applied here.
+func loadCompletionConfig() (*ProjectConfig, error) {
+	if err := enterProjectDir(); err != nil {
+		return nil, err
+	}
+	if err := enterWorkspaceProject(); err != nil {
+		return nil, err
+	}
+	return LoadConfig()
+}
+
+// completeEnvironments completes the environments of the project.
+func completeEnvironments(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	config, err := loadCompletionConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for name, authID := range config.Environments {
+		if strings.HasPrefix(name, toComplete) {
+			names = append(names, fmt.Sprintf("%s\t%s", name, authID))
+		}
+	}
+	sort.Strings(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFolders completes the folders under the SuiteScripts directory of the project.
+func completeFolders(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if _, err := loadCompletionConfig(); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	suiteScriptsDir, ok := locateSuiteScriptsDir()
+	if !ok {
+		return []string{"/\tSuiteScripts root"}, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	folders := []string{"/\tSuiteScripts root"}
+	for _, folder := range findAllFolders(suiteScriptsDir, "") {
+		if strings.HasPrefix(folder.Path, toComplete) {
+			folders = append(folders, folder.Path)
+		}
+	}
+	return folders, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeWorkspaceProjects completes the projects of the workspace.
+func completeWorkspaceProjects(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if err := enterProjectDir(); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	_, workspace, err := loadWorkspace()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, project := range workspace.Projects {
+		if strings.HasPrefix(project.Name, toComplete) {
+			names = append(names, fmt.Sprintf("%s\t%s", project.Name, filepath.ToSlash(project.Path)))
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRecordTypes completes the standard record types and those of the metadata cache.
+func completeRecordTypes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if _, err := loadCompletionConfig(); err != nil {
+		return standardRecordTypes, cobra.ShellCompDirectiveNoFileComp
+	}
+	var types []string
+	for _, name := range knownRecordTypes() {
+		if strings.HasPrefix(name, strings.ToUpper(toComplete)) {
+			types = append(types, name)
+		}
+	}
+	return types, cobra.ShellCompDirectiveNoFileComp
+}
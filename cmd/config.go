@@ -1,123 +1,159 @@
 package cmd
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"netsuite-cli/pkg/config"
 )
 
-// ProjectConfig represents the configuration for a specific project.
-type ProjectConfig struct {
-	ProjectName string `json:"projectName"`
-	CompanyName string `json:"companyName"`
-	UserName    string `json:"userName"`
-	UserEmail   string `json:"userEmail"`
-}
+// The configuration types and helpers live in pkg/config. The command code refers to them by
+// these names, since most commands hold the loaded configuration in a variable named config.
+type (
+	ProjectConfig  = config.Project
+	UserConfig     = config.User
+	AccountProfile = config.AccountProfile
+	NamingData     = config.NamingData
+	ScriptNaming   = config.ScriptNaming
+	DateFormat     = config.Dates
+
+	InvalidScriptNameError = config.InvalidScriptNameError
+)
 
-// LoadConfig reads the project configuration from the .netsuite-cli file in the current directory.
-func LoadConfig() (*ProjectConfig, error) {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return nil, fmt.Errorf("error getting current directory: %v", err)
-	}
+var (
+	GetCompanyPrefix      = config.CompanyPrefix
+	ValidateCompanyPrefix = config.ValidateCompanyPrefix
+	ValidateApiVersion    = config.ValidateApiVersion
+	ValidateLanguage      = config.ValidateLanguage
+	ValidateCopyrightYear = config.ValidateCopyrightYear
+	ValidateDateFormat    = config.ValidateDateFormat
+	ValidateTimeZone      = config.ValidateTimeZone
+	ValidateLocale        = config.ValidateLocale
+	ValidateDeployStatus  = config.ValidateDeployStatus
+	ValidateLogLevel      = config.ValidateLogLevel
+	ValidateLicenseHeader = config.ValidateLicenseHeader
+	LoadUserConfig        = config.LoadUser
+	SaveUserConfig        = config.SaveUser
+	UserConfigDir         = config.UserDir
+	TemplateVars          = config.TemplateVars
+	projectConfigFile     = config.ProjectFile
+
+	applyNamingPattern = config.ApplyNamingPattern
+	toScriptId         = config.ToScriptId
+	apiVersions        = config.ApiVersions
+)
 
-	configPath := filepath.Join(cwd, ".netsuite-cli")
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf(".netsuite-cli file not found. Please run 'create' first")
-	}
+const (
+	teamConfigFile        = config.TeamFileName
+	defaultApiVersion     = config.DefaultApiVersion
+	defaultTypingsVersion = config.DefaultTypingsVersion
 
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return nil, fmt.Errorf("error reading config file: %v", err)
-	}
+	languageTypeScript = config.LanguageTypeScript
+	languageJavaScript = config.LanguageJavaScript
+)
 
-	var config ProjectConfig
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("error parsing config file: %v", err)
-	}
+// loadedTeamConfig is the team configuration merged by LoadConfig, used by SaveConfig to avoid
+// copying team values into the personal configuration.
+var loadedTeamConfig *ProjectConfig
 
-	return &config, nil
-}
+// invocationDir is the directory the CLI was started from, before LoadConfig moved to the project root.
+var invocationDir string
 
-// SaveConfig writes the project configuration to the .netsuite-cli file in the specified directory.
-func SaveConfig(dir string, config *ProjectConfig) error {
-	configPath := filepath.Join(dir, ".netsuite-cli")
-	data, err := json.MarshalIndent(config, "", "  ")
+// FindProjectRoot walks up from the current directory to the nearest directory holding a
+// .netsuite-cli project file.
+func FindProjectRoot() (string, error) {
+	cwd, err := os.Getwd()
 	if err != nil {
-		return fmt.Errorf("error marshaling config: %v", err)
+		return "", fmt.Errorf("error getting current directory: %v", err)
 	}
-
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
-		return fmt.Errorf("error writing config file: %v", err)
+	root, err := config.FindRoot(cwd)
+	if errors.Is(err, config.ErrNoProject) {
+		if workspaceRoot, wsErr := config.FindWorkspace(cwd); wsErr == nil {
+			if workspace, wsErr := config.LoadWorkspace(workspaceRoot); wsErr == nil && len(workspace.Projects) > 0 {
+				return "", fmt.Errorf("%w, or select a workspace project with --project (available: %s)", err, strings.Join(workspace.Names(), ", "))
+			}
+		}
 	}
-
-	return nil
+	return root, err
 }
 
-// UserConfig represents the global user configuration.
-type UserConfig struct {
-	CompanyName string `json:"companyName"`
-	UserName    string `json:"userName"`
-	UserEmail   string `json:"userEmail"`
-}
-
-// LoadUserConfig reads the user configuration from the .netsuite-cli file in the user's home directory.
-func LoadUserConfig() (*UserConfig, error) {
-	homeDir, err := os.UserHomeDir()
+// LoadConfig reads the project configuration from the .netsuite-cli file of the project containing
+// the current directory. The current directory is changed to the project root, so project paths
+// can be used relative to it.
+func LoadConfig() (*ProjectConfig, error) {
+	root, err := FindProjectRoot()
 	if err != nil {
-		return nil, fmt.Errorf("error getting home directory: %v", err)
+		return nil, err
 	}
 
-	configPath := filepath.Join(homeDir, ".netsuite-cli")
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return nil, nil
+	from, err := config.Migrate(root)
+	if err != nil {
+		return nil, err
+	}
+	if from < config.SchemaVersion {
+		name := filepath.Base(projectConfigFile(root))
+		logInfo("Migrated %s from version %d to %d, the original is saved as %s.v%d.bak", name, from, config.SchemaVersion, name, from)
 	}
 
-	data, err := os.ReadFile(configPath)
+	project, team, err := config.Load(root)
 	if err != nil {
-		return nil, fmt.Errorf("error reading config file: %v", err)
+		return nil, err
 	}
+	loadedTeamConfig = team
 
-	var config UserConfig
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("error parsing config file: %v", err)
+	if invocationDir == "" {
+		invocationDir, _ = os.Getwd()
+	}
+	if err := os.Chdir(root); err != nil {
+		return nil, fmt.Errorf("error changing to project root %s: %v", root, err)
 	}
 
-	return &config, nil
+	return project, nil
 }
 
-// SaveUserConfig writes the user configuration to the .netsuite-cli file in the user's home directory.
-func SaveUserConfig(config *UserConfig) error {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("error getting home directory: %v", err)
+// projectRelativePath converts a path given on the command line, relative to the directory the
+// CLI was started from, into a path relative to the project root.
+func projectRelativePath(path string) string {
+	if invocationDir == "" {
+		return path
 	}
-
-	configPath := filepath.Join(homeDir, ".netsuite-cli")
-	data, err := json.MarshalIndent(config, "", "  ")
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(invocationDir, path)
+	}
+	root, err := os.Getwd()
 	if err != nil {
-		return fmt.Errorf("error marshaling config: %v", err)
+		return path
 	}
-
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
-		return fmt.Errorf("error writing config file: %v", err)
+	if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
 	}
+	return path
+}
 
-	return nil
+// SaveConfig writes the project configuration to the .netsuite-cli file in the specified directory.
+// Values inherited from the team configuration loaded by LoadConfig are not copied into it.
+func SaveConfig(dir string, project *ProjectConfig) error {
+	return config.Save(dir, project, loadedTeamConfig)
 }
 
-// GetCompanyPrefix generates a 3-letter prefix from the company name.
-func GetCompanyPrefix(companyName string) string {
-	companyName = strings.TrimSpace(companyName)
-	if len(companyName) == 0 {
-		return "com"
+// SaveTeamConfig writes the team configuration to the .netsuite-cli.team file in the specified
+// directory and makes it the team configuration used by SaveConfig.
+func SaveTeamConfig(dir string, team *ProjectConfig) error {
+	if err := config.SaveTeam(dir, team); err != nil {
+		return err
 	}
+	loadedTeamConfig = team
+	return nil
+}
 
-	prefix := strings.ToLower(companyName)
-	if len(prefix) > 3 {
-		prefix = prefix[:3]
+// userDirHelp returns the path of name under the user directory for help texts, or where to
+// find it when the directory cannot be determined.
+func userDirHelp(name string) string {
+	if userDir, err := UserConfigDir(); err == nil {
+		return filepath.Join(userDir, name)
 	}
-	return prefix
+	return "netsuite-cli/" + name + " under the user configuration directory"
 }
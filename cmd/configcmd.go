@@ -0,0 +1,373 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	configGlobalFlag bool
+	configTeamFlag   bool
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "View and edit project and user settings",
+	Long: `View and edit the settings stored in the project .netsuite-cli file, with --team
+in the shared .netsuite-cli.team file, or with --global in the user .netsuite-cli file
+in your home directory. Project values take precedence over team values, and team
+values over user values.`,
+}
+
+// configGetCmd represents the config get command
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the value of a setting and where it comes from",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigGet(args[0])
+	},
+}
+
+// configSetCmd represents the config set command
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change the value of a setting",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigSet(args[0], args[1])
+	},
+}
+
+// configListCmd represents the config list command
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all settings and where they come from",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigList()
+	},
+}
+
+func init() {
+	configCmd.PersistentFlags().BoolVarP(&configGlobalFlag, "global", "g", false, "Use the user configuration in the home directory")
+	configSetCmd.Flags().BoolVarP(&configTeamFlag, "team", "t", false, "Set the value in the shared "+teamConfigFile+" file")
+
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configListCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+// configKey describes a setting editable with the config command. A nil accessor means the
+// setting does not exist at that level.
+type configKey struct {
+	project func(*ProjectConfig) *string
+	user    func(*UserConfig) *string
+}
+
+// configKeys lists the settings editable with the config command.
+var configKeys = map[string]configKey{
+	"projectName": {
+		project: func(c *ProjectConfig) *string { return &c.ProjectName },
+	},
+	"companyName": {
+		project: func(c *ProjectConfig) *string { return &c.CompanyName },
+		user:    func(c *UserConfig) *string { return &c.CompanyName },
+	},
+	"userName": {
+		project: func(c *ProjectConfig) *string { return &c.UserName },
+		user:    func(c *UserConfig) *string { return &c.UserName },
+	},
+	"userEmail": {
+		project: func(c *ProjectConfig) *string { return &c.UserEmail },
+		user:    func(c *UserConfig) *string { return &c.UserEmail },
+	},
+	"updateCheck": {
+		user: func(c *UserConfig) *string { return &c.UpdateCheck },
+	},
+	"defaultEnvironment": {
+		project: func(c *ProjectConfig) *string { return &c.DefaultEnvironment },
+	},
+	"companyPrefix": {
+		project: func(c *ProjectConfig) *string { return &c.CompanyPrefix },
+	},
+	"scriptIdPrefix": {
+		project: func(c *ProjectConfig) *string { return &c.ScriptIdPrefix },
+	},
+	"defaultFolder": {
+		project: func(c *ProjectConfig) *string { return &c.DefaultFolder },
+	},
+	"apiVersion": {
+		project: func(c *ProjectConfig) *string { return &c.ApiVersion },
+	},
+	"gitHooks": {
+		project: func(c *ProjectConfig) *string { return &c.GitHooks },
+	},
+	"language": {
+		project: func(c *ProjectConfig) *string { return &c.Language },
+	},
+	"deployStatus": {
+		project: func(c *ProjectConfig) *string { return &c.DeployStatus },
+	},
+	"logLevel": {
+		project: func(c *ProjectConfig) *string { return &c.LogLevel },
+	},
+	"dateFormat": {
+		project: func(c *ProjectConfig) *string { return &c.DateFormat },
+	},
+	"timeZone": {
+		project: func(c *ProjectConfig) *string { return &c.TimeZone },
+	},
+	"locale": {
+		project: func(c *ProjectConfig) *string { return &c.Locale },
+	},
+	"license": {
+		project: func(c *ProjectConfig) *string { return &c.License },
+	},
+	"copyrightHolder": {
+		project: func(c *ProjectConfig) *string { return &c.CopyrightHolder },
+	},
+	"copyrightYear": {
+		project: func(c *ProjectConfig) *string { return &c.CopyrightYear },
+	},
+	"licenseHeader": {
+		project: func(c *ProjectConfig) *string { return &c.LicenseHeader },
+	},
+	"templatePack": {
+		project: func(c *ProjectConfig) *string { return &c.TemplatePack },
+	},
+	"scriptIdPattern": {
+		project: func(c *ProjectConfig) *string { return &c.ScriptIdPattern },
+	},
+	"deploymentIdPattern": {
+		project: func(c *ProjectConfig) *string { return &c.DeploymentIdPattern },
+	},
+	"fileNamePattern": {
+		project: func(c *ProjectConfig) *string { return &c.FileNamePattern },
+	},
+	"objectFileNamePattern": {
+		project: func(c *ProjectConfig) *string { return &c.ObjectFileNamePattern },
+	},
+}
+
+// configKeyNames returns the sorted names of the editable settings.
+func configKeyNames() []string {
+	names := make([]string, 0, len(configKeys))
+	for name := range configKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupConfigKey returns the setting with the given name, matched case-insensitively.
+func lookupConfigKey(name string) (string, configKey, error) {
+	for key, accessor := range configKeys {
+		if strings.EqualFold(key, name) {
+			return key, accessor, nil
+		}
+	}
+	return "", configKey{}, fmt.Errorf("unknown setting '%s' (available: %s)", name, strings.Join(configKeyNames(), ", "))
+}
+
+// loadConfigLevels loads the project configuration, if inside a project, and the user configuration.
+func loadConfigLevels() (*ProjectConfig, *UserConfig, error) {
+	var project *ProjectConfig
+	if !configGlobalFlag {
+		project, _ = LoadConfig()
+	}
+	user, err := loadUserConfig()
+	return project, user, err
+}
+
+// resolveConfigValue returns the effective value of a setting and the file it comes from.
+func resolveConfigValue(key configKey, project *ProjectConfig, user *UserConfig) (string, string) {
+	if project != nil && key.project != nil {
+		if value := *key.project(project); value != "" {
+			if loadedTeamConfig != nil && *key.project(loadedTeamConfig) == value {
+				return value, "team"
+			}
+			return value, "project"
+		}
+	}
+	if key.user != nil {
+		if value := *key.user(user); value != "" {
+			return value, "global"
+		}
+	}
+	return "", ""
+}
+
+// runConfigGet prints the value of a setting and its source.
+func runConfigGet(name string) error {
+	_, key, err := lookupConfigKey(name)
+	if err != nil {
+		return err
+	}
+	project, user, err := loadConfigLevels()
+	if err != nil {
+		return err
+	}
+
+	value, source := resolveConfigValue(key, project, user)
+	if source == "" {
+		return fmt.Errorf("setting '%s' is not set", name)
+	}
+	fmt.Printf("%s\t(%s)\n", value, source)
+	return nil
+}
+
+// runConfigSet changes the value of a setting in the project or user configuration.
+func runConfigSet(name, value string) error {
+	name, key, err := lookupConfigKey(name)
+	if err != nil {
+		return err
+	}
+	value = strings.TrimSpace(value)
+	if configGlobalFlag && configTeamFlag {
+		return errors.New("--global and --team cannot be used together")
+	}
+
+	if configGlobalFlag {
+		if key.user == nil {
+			return fmt.Errorf("setting '%s' can only be set in a project", name)
+		}
+		user, err := loadUserConfig()
+		if err != nil {
+			return err
+		}
+		*key.user(user) = value
+		if err := SaveUserConfig(user); err != nil {
+			return err
+		}
+		logInfo("Set %s = %s (global)", name, value)
+		return nil
+	}
+
+	if key.project == nil {
+		return fmt.Errorf("setting '%s' can only be set with --global", name)
+	}
+	project, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+	if name == "defaultEnvironment" && value != "" {
+		if _, ok := project.Environments[value]; !ok {
+			return fmt.Errorf("environment '%s' is not defined", value)
+		}
+	}
+	if name == "companyPrefix" && value != "" {
+		value = strings.ToLower(value)
+		if err := ValidateCompanyPrefix(value); err != nil {
+			return err
+		}
+	}
+	if name == "apiVersion" && value != "" {
+		if err := ValidateApiVersion(value); err != nil {
+			return err
+		}
+	}
+	if name == "language" && value != "" {
+		if err := ValidateLanguage(value, project.SuiteScriptVersion()); err != nil {
+			return err
+		}
+	}
+	if name == "deployStatus" && value != "" {
+		value = strings.ToUpper(value)
+		if err := ValidateDeployStatus(value); err != nil {
+			return err
+		}
+	}
+	if name == "logLevel" && value != "" {
+		value = strings.ToUpper(value)
+		if err := ValidateLogLevel(value); err != nil {
+			return err
+		}
+	}
+	if name == "dateFormat" && value != "" {
+		if err := ValidateDateFormat(value); err != nil {
+			return err
+		}
+	}
+	if name == "timeZone" && value != "" {
+		if err := ValidateTimeZone(value); err != nil {
+			return err
+		}
+	}
+	if name == "locale" && value != "" {
+		if err := ValidateLocale(value); err != nil {
+			return err
+		}
+	}
+	if name == "copyrightYear" && value != "" {
+		if err := ValidateCopyrightYear(value); err != nil {
+			return err
+		}
+	}
+	if name == "licenseHeader" && value != "" {
+		if err := ValidateLicenseHeader(value); err != nil {
+			return err
+		}
+	}
+	if name == "gitHooks" && value != "" && !containsString(gitHookManagers, value) {
+		return fmt.Errorf("invalid git hook manager '%s' (supported: %s)", value, strings.Join(gitHookManagers, ", "))
+	}
+	if strings.HasSuffix(name, "Pattern") && value != "" {
+		if _, err := applyNamingPattern(value, NamingData{}); err != nil {
+			return fmt.Errorf("invalid pattern: %v", err)
+		}
+	}
+	if name == "projectName" && (value == "" || strings.ContainsAny(value, `<>:"/\|?*`)) {
+		return errors.New("project name is empty or contains invalid characters")
+	}
+	if configTeamFlag {
+		team := loadedTeamConfig
+		if team == nil {
+			team = &ProjectConfig{}
+		}
+		*key.project(team) = value
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("error getting current directory: %v", err)
+		}
+		if err := SaveTeamConfig(cwd, team); err != nil {
+			return err
+		}
+		logInfo("Set %s = %s (team)", name, value)
+		return nil
+	}
+	*key.project(project) = value
+	if err := saveProjectConfig(project); err != nil {
+		return err
+	}
+	logInfo("Set %s = %s (project)", name, value)
+	return nil
+}
+
+// runConfigList prints every setting with its effective value and source.
+func runConfigList() error {
+	project, user, err := loadConfigLevels()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range configKeyNames() {
+		key := configKeys[name]
+		if configGlobalFlag && key.user == nil {
+			continue
+		}
+		value, source := resolveConfigValue(key, project, user)
+		if source == "" {
+			source = "unset"
+		}
+		fmt.Printf("%-20s %-30s (%s)\n", name, value, source)
+	}
+	return nil
+}
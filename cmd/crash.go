@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"netsuite-cli/internal/update"
+
+	"github.com/spf13/cobra"
+)
+
+// issuesURL is where users are asked to report crashes.
+const issuesURL = "https://github.com/" + update.Repository + "/issues/new"
+
+// CrashError reports a panic recovered while running a command.
+type CrashError struct {
+	Value  any
+	Report string
+}
+
+func (e *CrashError) Error() string {
+	message := fmt.Sprintf("netsuite-cli crashed: %v", e.Value)
+	if e.Report != "" {
+		message += fmt.Sprintf("\nA crash report was written to %s.", e.Report)
+	}
+	return message + fmt.Sprintf("\nPlease open an issue at %s and attach the report.", issuesURL)
+}
+
+// sensitiveFlagRe matches the flags whose values are removed from crash reports.
+var sensitiveFlagRe = regexp.MustCompile(`(?i)^--?[\w-]*(token|secret|password|passwd|key|auth|credential|cert|body|data|header)[\w-]*`)
+
+// executeCommand runs the root command. A panic is recovered and returned as a CrashError
+// after writing a crash report to the log directory, instead of a Go stack trace being dumped
+// at the user.
+func executeCommand(args []string) (command *cobra.Command, err error) {
+	defer func() {
+		value := recover()
+		if value == nil {
+			return
+		}
+		stack := debug.Stack()
+		if command, _, _ = rootCmd.Find(args); command == nil {
+			command = rootCmd
+		}
+		writeLog(levelError, "panic: %v\n%s", value, stack)
+		report, reportErr := writeCrashReport(value, stack, sanitizeArgs(command, args))
+		if reportErr != nil {
+			writeLog(levelWarn, "error writing crash report: %v", reportErr)
+		}
+		err = &CrashError{Value: value, Report: report}
+	}()
+	return rootCmd.ExecuteC()
+}
+
+// writeCrashReport writes the panic, its stack, the build information and the sanitized command
+// line to a crash-<time>.txt file in the log directory and returns its path.
+func writeCrashReport(value any, stack []byte, args []string) (string, error) {
+	dir, err := logDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+
+	var report strings.Builder
+	fmt.Fprintf(&report, "netsuite-cli crash report\n\n")
+	fmt.Fprintf(&report, "Time:    %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&report, "Version: %s\n", buildInfo.Version)
+	if buildInfo.Commit != "" {
+		fmt.Fprintf(&report, "Commit:  %s\n", buildInfo.Commit)
+	}
+	fmt.Fprintf(&report, "Go:      %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&report, "Command: netsuite-cli %s\n\n", strings.Join(args, " "))
+	fmt.Fprintf(&report, "panic: %v\n\n%s", value, stack)
+
+	path := filepath.Join(dir, "crash-"+time.Now().Format("20060102-150405")+".txt")
+	if err := os.WriteFile(path, []byte(report.String()), 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// sanitizeArgs returns the command line without the values of flags that may hold secrets or
+// record data, and with the home directory shortened to ~. The flags of command tell boolean
+// flags, which take no value, apart.
+func sanitizeArgs(command *cobra.Command, args []string) []string {
+	home, _ := os.UserHomeDir()
+	sanitized := make([]string, 0, len(args))
+	redactNext := false
+	for _, arg := range args {
+		switch {
+		case redactNext:
+			arg = "[redacted]"
+			redactNext = false
+		case sensitiveFlagRe.MatchString(arg):
+			if name, _, ok := strings.Cut(arg, "="); ok {
+				arg = name + "=[redacted]"
+			} else if flag := command.Flags().Lookup(strings.TrimLeft(arg, "-")); flag == nil || flag.NoOptDefVal == "" {
+				redactNext = true
+			}
+		}
+		if home != "" {
+			arg = strings.ReplaceAll(arg, home, "~")
+		}
+		sanitized = append(sanitized, arg)
+	}
+	return sanitized
+}
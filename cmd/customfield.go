@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"netsuite-cli/pkg/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	customFieldKindFlag       string
+	customFieldTypeFlag       string
+	customFieldLabelFlag      string
+	customFieldAppliesToFlag  []string
+	customFieldSelectFlag     string
+	customFieldSourceListFlag string
+	customFieldSourceFromFlag string
+)
+
+// customFieldCmd represents the add customfield command
+var customFieldCmd = &cobra.Command{
+	Use:   "customfield [name]",
+	Short: "Custom fields extend entity, transaction body, transaction column, and item records with your own data",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAddCustomField(args)
+	},
+}
+
+func init() {
+	customFieldCmd.Flags().StringVarP(&customFieldKindFlag, "kind", "k", "", "Field kind: entity, body, column or item")
+	customFieldCmd.Flags().StringVarP(&customFieldTypeFlag, "type", "t", "", "Field type (e.g., text, select, checkbox)")
+	customFieldCmd.Flags().StringVar(&customFieldLabelFlag, "label", "", "Field label (default: the field name)")
+	customFieldCmd.Flags().StringSliceVar(&customFieldAppliesToFlag, "applies-to", nil, "Comma separated records the field applies to (e.g., customer,vendor)")
+	customFieldCmd.Flags().StringVar(&customFieldSelectFlag, "select-record-type", "", "Record type listed by select fields (e.g., -2 for customer)")
+	customFieldCmd.Flags().StringVar(&customFieldSourceListFlag, "source-list", "", "Field on the record the value is sourced through (e.g., STDENTITYCUSTOMER)")
+	customFieldCmd.Flags().StringVar(&customFieldSourceFromFlag, "source-from", "", "Field of the source record to copy the value from (e.g., STDENTITYEMAIL)")
+
+	addCmd.AddCommand(customFieldCmd)
+}
+
+// runAddCustomField executes the logic for adding a new custom field.
+func runAddCustomField(args []string) error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+
+	field := scaffold.CustomField{Kind: strings.ToLower(strings.TrimSpace(customFieldKindFlag))}
+	if field.Kind == "" && !yesFlag {
+		if field.Kind, err = promptLine(reader, fmt.Sprintf("Enter field kind [%s]: ", strings.Join(scaffold.CustomFieldKindNames(), ", "))); err != nil {
+			return err
+		}
+	}
+	kind, err := scaffold.ResolveCustomFieldKind(field.Kind)
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		field.Name = strings.TrimSpace(args[0])
+	}
+	if field.Name == "" && !yesFlag {
+		field.Name, err = promptLine(reader, "Enter field name: ")
+		if err != nil {
+			return err
+		}
+	}
+	fieldName := field.FieldName()
+	if fieldName == "" {
+		return errors.New("field name is required")
+	}
+
+	field.Label = strings.TrimSpace(customFieldLabelFlag)
+	if field.Label == "" && !yesFlag {
+		field.Label, err = promptLine(reader, fmt.Sprintf("Enter field label (default: %s): ", fieldName))
+		if err != nil {
+			return err
+		}
+	}
+
+	field.Description = strings.TrimSpace(descriptionFlag)
+	if field.Description == "" && !yesFlag {
+		field.Description, err = promptLine(reader, "Enter field description (optional): ")
+		if err != nil {
+			return err
+		}
+	}
+
+	field.Type = strings.ToLower(strings.TrimSpace(customFieldTypeFlag))
+	if field.Type == "" && !yesFlag {
+		if field.Type, err = promptLine(reader, fmt.Sprintf("Enter field type [%s] (default: text): ", strings.Join(scaffold.ParamTypeNames(), ", "))); err != nil {
+			return err
+		}
+		field.Type = strings.ToLower(field.Type)
+	}
+
+	field.SelectRecordType = strings.TrimSpace(customFieldSelectFlag)
+	if field.Type == "select" && field.SelectRecordType == "" && !yesFlag {
+		field.SelectRecordType, err = promptLine(reader, "Enter select record type (e.g., -2 for customer, customrecord_x): ")
+		if err != nil {
+			return err
+		}
+	}
+
+	field.AppliesTo = customFieldAppliesToFlag
+	if len(field.AppliesTo) == 0 && !yesFlag {
+		input, err := promptLine(reader, fmt.Sprintf("Applies to [%s] (comma separated): ", strings.Join(kind.AppliesToNames(), ", ")))
+		if err != nil {
+			return err
+		}
+		field.AppliesTo = strings.Split(input, ",")
+	}
+	if _, err := scaffold.ResolveAppliesTo(kind, field.AppliesTo); err != nil {
+		return err
+	}
+
+	sourceList := strings.TrimSpace(customFieldSourceListFlag)
+	sourceFrom := strings.TrimSpace(customFieldSourceFromFlag)
+	if sourceList == "" && sourceFrom == "" && !yesFlag {
+		sourced, err := promptConfirm(reader, "Source the value from another record? (y/n, default: n): ")
+		if err != nil {
+			return err
+		}
+		if sourced {
+			sourceList, err = promptLine(reader, "Source list (field holding the related record, e.g., STDENTITYCUSTOMER): ")
+			if err != nil {
+				return err
+			}
+			sourceFrom, err = promptLine(reader, "Source from (field to copy from the related record, e.g., STDENTITYEMAIL): ")
+			if err != nil {
+				return err
+			}
+		}
+	}
+	field.SourceList, field.SourceFrom = sourceList, sourceFrom
+
+	scriptId, err := field.ScriptId(config)
+	if err != nil {
+		return err
+	}
+	if err := checkObjectIdAvailable(scriptId); err != nil {
+		return err
+	}
+
+	generator, err := objectGenerator(config)
+	if err != nil {
+		return err
+	}
+	file, err := generator.CustomField(field)
+	if err != nil {
+		return err
+	}
+	_, err = writeObject(reader, file)
+	return err
+}
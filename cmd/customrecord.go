@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"netsuite-cli/pkg/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	customRecordLabelFlag       string
+	customRecordFieldFlags      []string
+	customRecordPermissionFlags []string
+	customRecordSublistFlags    []string
+)
+
+// customRecordCmd represents the add customrecord command
+var customRecordCmd = &cobra.Command{
+	Use:   "customrecord [name]",
+	Short: "Custom record types let you define your own records with custom fields, sublists, and role permissions",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAddCustomRecord(args)
+	},
+}
+
+func init() {
+	customRecordCmd.Flags().StringVar(&customRecordLabelFlag, "label", "", "Record label shown in NetSuite (default: the record name)")
+	customRecordCmd.Flags().StringArrayVar(&customRecordFieldFlags, "field", nil, "Field in the form id:type[:label[:selectrecordtype]] (repeatable)")
+	customRecordCmd.Flags().StringArrayVar(&customRecordPermissionFlags, "permission", nil, "Role permission in the form ROLE:LEVEL, e.g. ADMINISTRATOR:FULL (repeatable)")
+	customRecordCmd.Flags().StringArrayVar(&customRecordSublistFlags, "sublist", nil, "Saved search sublist in the form customsearch_id[:label] (repeatable)")
+
+	addCmd.AddCommand(customRecordCmd)
+}
+
+// runAddCustomRecord executes the logic for adding a new custom record type.
+func runAddCustomRecord(args []string) error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+
+	recordName := ""
+	if len(args) > 0 {
+		recordName = strings.TrimSpace(args[0])
+	}
+	if recordName == "" && !yesFlag {
+		recordName, err = promptLine(reader, "Enter custom record name: ")
+		if err != nil {
+			return err
+		}
+	}
+	if recordName == "" {
+		return errors.New("custom record name is required")
+	}
+	record := scaffold.CustomRecord{Name: recordName}
+	scriptId, err := record.ScriptId(config)
+	if err != nil {
+		return validationError("%v", err)
+	}
+
+	record.Label = strings.TrimSpace(customRecordLabelFlag)
+	if record.Label == "" && !yesFlag {
+		record.Label, err = promptLine(reader, fmt.Sprintf("Enter record label (default: %s): ", recordName))
+		if err != nil {
+			return err
+		}
+	}
+
+	record.Description = strings.TrimSpace(descriptionFlag)
+	if record.Description == "" && !yesFlag {
+		record.Description, err = promptLine(reader, fmt.Sprintf("Enter record description (default: %s description): ", recordName))
+		if err != nil {
+			return err
+		}
+	}
+
+	prefix := config.Prefix()
+
+	for _, spec := range customRecordFieldFlags {
+		field, err := scaffold.ParseCustomRecordField(spec, prefix)
+		if err != nil {
+			return fmt.Errorf("invalid --field '%s': %v", spec, err)
+		}
+		record.Fields = append(record.Fields, field)
+	}
+	if len(record.Fields) == 0 && !yesFlag {
+		for {
+			id, err := promptLine(reader, "Field id (leave empty to finish): ")
+			if err != nil {
+				return err
+			}
+			if id == "" {
+				break
+			}
+			fieldLabel, err := promptLine(reader, fmt.Sprintf("Field label (default: %s): ", id))
+			if err != nil {
+				return err
+			}
+			fieldType, err := promptLine(reader, fmt.Sprintf("Field type [%s] (default: text): ", strings.Join(scaffold.ParamTypeNames(), ", ")))
+			if err != nil {
+				return err
+			}
+			selectRecordType := ""
+			if strings.EqualFold(fieldType, "select") {
+				selectRecordType, err = promptLine(reader, "Select record type (e.g., -2 for customer, customrecord_x): ")
+				if err != nil {
+					return err
+				}
+			}
+			field, err := scaffold.NewCustomRecordField(id, fieldType, fieldLabel, selectRecordType, prefix)
+			if err != nil {
+				promptf("Invalid field: %v\n", err)
+				continue
+			}
+			record.Fields = append(record.Fields, field)
+		}
+	}
+
+	for _, spec := range customRecordPermissionFlags {
+		permission, err := scaffold.ParseCustomRecordPermission(spec)
+		if err != nil {
+			return fmt.Errorf("invalid --permission '%s': %v", spec, err)
+		}
+		record.Permissions = append(record.Permissions, permission)
+	}
+	if len(record.Permissions) == 0 && !yesFlag {
+		for {
+			role, err := promptLine(reader, "Permission role (e.g., ADMINISTRATOR, leave empty to finish): ")
+			if err != nil {
+				return err
+			}
+			if role == "" {
+				break
+			}
+			level, err := promptLine(reader, fmt.Sprintf("Permission level [%s] (default: FULL): ", strings.Join(scaffold.CustomRecordPermissionLevels, ", ")))
+			if err != nil {
+				return err
+			}
+			permission, err := scaffold.ParseCustomRecordPermission(role + ":" + level)
+			if err != nil {
+				promptf("Invalid permission: %v\n", err)
+				continue
+			}
+			record.Permissions = append(record.Permissions, permission)
+		}
+	}
+
+	for _, spec := range customRecordSublistFlags {
+		sublist, err := scaffold.ParseCustomRecordSublist(spec)
+		if err != nil {
+			return fmt.Errorf("invalid --sublist '%s': %v", spec, err)
+		}
+		record.Sublists = append(record.Sublists, sublist)
+	}
+	if len(record.Sublists) == 0 && !yesFlag {
+		for {
+			search, err := promptLine(reader, "Sublist saved search id (leave empty to finish): ")
+			if err != nil {
+				return err
+			}
+			if search == "" {
+				break
+			}
+			sublistLabel, err := promptLine(reader, fmt.Sprintf("Sublist label (default: %s): ", search))
+			if err != nil {
+				return err
+			}
+			sublist, err := scaffold.ParseCustomRecordSublist(search + ":" + sublistLabel)
+			if err != nil {
+				promptf("Invalid sublist: %v\n", err)
+				continue
+			}
+			record.Sublists = append(record.Sublists, sublist)
+		}
+	}
+
+	if err := checkObjectIdAvailable(scriptId); err != nil {
+		return err
+	}
+
+	generator, err := objectGenerator(config)
+	if err != nil {
+		return err
+	}
+	file, err := generator.CustomRecord(record)
+	if err != nil {
+		return err
+	}
+	if written, err := writeObject(reader, file); err != nil || !written {
+		return err
+	}
+	ensureManifestFeatures("customrecord")
+	return nil
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var deploySkipBuildFlag bool
+
+// deployCmd represents the deploy command
+var deployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Build and deploy the project using the SuiteCloud CLI",
+	Long: `Compile the TypeScript sources and run 'suitecloud project:deploy' against
+the selected environment. The output of the SuiteCloud CLI is summarized: the account and
+project, the number of validation steps passed, the objects and files deployed, warnings
+and errors. Pass --raw to see it unchanged.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDeploy()
+	},
+}
+
+func init() {
+	deployCmd.Flags().BoolVar(&deploySkipBuildFlag, "skip-build", false, "Skip the TypeScript build before deploying")
+	deployCmd.Flags().BoolVar(&bundleFlag, "bundle", false, "Bundle the entry scripts with esbuild instead of compiling them with tsc")
+	deployCmd.Flags().StringVarP(&envFlag, "env", "e", "", "Project environment to deploy to")
+
+	rootCmd.AddCommand(deployCmd)
+}
+
+// runDeploy executes the project deployment.
+func runDeploy() error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	env := envFlag
+	if env == "" {
+		env = config.DefaultEnvironment
+	}
+	hookData := HookData{Command: "deploy", Env: env}
+	if err := runLifecycleHook(config, "preDeploy", hookData); err != nil {
+		return err
+	}
+
+	suiteCloudCmd, err := ensureSuiteCloudCommand()
+	if err != nil {
+		return err
+	}
+
+	if !deploySkipBuildFlag {
+		if err := buildSources(config, "tsconfig.json"); err != nil {
+			return err
+		}
+	}
+
+	restore, err := activateEnvironment(config, envFlag)
+	if err != nil {
+		return err
+	}
+
+	deployProjectCmd := suiteCloudExec(suiteCloudCmd, "project:deploy")
+	attachTerminal(deployProjectCmd)
+	result, runErr := runSuiteCloud(deployProjectCmd)
+	restore()
+	if jsonFlag {
+		setJSONResult(result)
+	}
+
+	if runErr != nil {
+		recordCommandResult("deploy", config, false, runErr.Error())
+		return toolError("suitecloud", fmt.Errorf("error deploying project: %v", runErr))
+	}
+
+	recordCommandResult("deploy", config, true, "")
+	logInfo("\n✓ Deployment completed successfully.")
+	return runLifecycleHook(config, "postDeploy", hookData)
+}
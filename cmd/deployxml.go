@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// deployXMLEntryRe matches a <path> entry of deploy.xml wherever it is on its line.
+var deployXMLEntryRe = regexp.MustCompile(`<path>\s*(.*?)\s*</path>`)
+
+// findDeployXML locates the deploy.xml file in the project.
+func findDeployXML() (string, bool) {
+	possiblePaths := []string{
+		"src/deploy.xml",
+		"deploy.xml",
+	}
+
+	for _, path := range possiblePaths {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// toSDFPath converts a local FileCabinet or Objects path into the '~/' form used by deploy.xml.
+func toSDFPath(localPath string) string {
+	slashPath := filepath.ToSlash(localPath)
+	for _, root := range []string{"FileCabinet/", "Objects/"} {
+		if idx := strings.Index(slashPath, root); idx >= 0 && (idx == 0 || slashPath[idx-1] == '/') {
+			return "~/" + slashPath[idx:]
+		}
+	}
+	if strings.HasPrefix(slashPath, "SuiteScripts/") {
+		return "~/FileCabinet/" + slashPath
+	}
+	return "~/" + slashPath
+}
+
+// deployXMLPathLine returns the trimmed path referenced by a deploy.xml <path> line, if any.
+func deployXMLPathLine(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "<path>") || !strings.HasSuffix(trimmed, "</path>") {
+		return "", false
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(trimmed, "<path>"), "</path>")), true
+}
+
+// warnInlineDeployXMLEntries warns when deploy.xml has <path> entries sharing a line with other
+// tags, e.g. <files><path>~/FileCabinet/SuiteScripts/*</path></files>, which the line-based edits
+// of deploy.xml neither see nor change.
+func warnInlineDeployXMLEntries(deployXMLPath string, lines []string) {
+	for i, line := range lines {
+		if _, ok := deployXMLPathLine(line); !ok && deployXMLEntryRe.MatchString(line) {
+			logWarn("%s:%d has <path> entries that are not on a line of their own, check them by hand", deployXMLPath, i+1)
+			return
+		}
+	}
+}
+
+// removeDeployXMLReferences removes <path> entries matching any of the given SDF paths from deploy.xml.
+// It returns the number of entries removed.
+func removeDeployXMLReferences(deployXMLPath string, sdfPaths []string) (int, error) {
+	return rewriteDeployXMLReferences(deployXMLPath, func(path string) (string, bool) {
+		for _, p := range sdfPaths {
+			if path == p {
+				return "", false
+			}
+		}
+		return path, true
+	})
+}
+
+// rewriteDeployXMLReferences applies fn to every <path> entry in deploy.xml. Returning false removes
+// the entry, otherwise it is replaced with the returned path. It returns the number of entries changed.
+func rewriteDeployXMLReferences(deployXMLPath string, fn func(path string) (string, bool)) (int, error) {
+	data, err := os.ReadFile(deployXMLPath)
+	if err != nil {
+		return 0, fmt.Errorf("error reading %s: %v", deployXMLPath, err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	warnInlineDeployXMLEntries(deployXMLPath, lines)
+	result := make([]string, 0, len(lines))
+	changed := 0
+	for _, line := range lines {
+		path, ok := deployXMLPathLine(line)
+		if !ok {
+			result = append(result, line)
+			continue
+		}
+		newPath, keep := fn(path)
+		if !keep {
+			changed++
+			continue
+		}
+		if newPath != path {
+			line = strings.Replace(line, path, newPath, 1)
+			changed++
+		}
+		result = append(result, line)
+	}
+
+	if changed == 0 {
+		return 0, nil
+	}
+
+	if err := os.WriteFile(deployXMLPath, []byte(strings.Join(result, "\n")), 0644); err != nil {
+		return 0, fmt.Errorf("error writing %s: %v", deployXMLPath, err)
+	}
+	return changed, nil
+}
+
+// deployXMLCovers reports whether a deploy.xml <path> entry includes sdfPath, either exactly or
+// through a trailing '*' wildcard.
+func deployXMLCovers(entry, sdfPath string) bool {
+	if strings.HasSuffix(entry, "*") {
+		return strings.HasPrefix(sdfPath, strings.TrimSuffix(entry, "*"))
+	}
+	return entry == sdfPath
+}
+
+// deployXMLSection returns the deploy.xml section listing sdfPath.
+func deployXMLSection(sdfPath string) string {
+	if strings.HasPrefix(sdfPath, "~/Objects/") {
+		return "objects"
+	}
+	return "files"
+}
+
+// addDeployXMLReferences adds <path> entries for the given SDF paths to the <files> or <objects>
+// section of deploy.xml, creating the section if needed. Paths already listed or covered by a
+// wildcard are skipped. A section written on one line is left alone with a warning. When write is
+// false deploy.xml is left unchanged. It returns the paths that were, or would be, added.
+func addDeployXMLReferences(deployXMLPath string, sdfPaths []string, write bool) ([]string, error) {
+	data, err := os.ReadFile(deployXMLPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", deployXMLPath, err)
+	}
+	lines := strings.Split(string(data), "\n")
+	// Entries are matched wherever they are, since a file and an object path never cover each other.
+	var entries []string
+	for _, m := range deployXMLEntryRe.FindAllStringSubmatch(string(data), -1) {
+		entries = append(entries, m[1])
+	}
+
+	var added []string
+	for _, sdfPath := range sdfPaths {
+		if containsString(added, sdfPath) || slices.ContainsFunc(entries, func(entry string) bool { return deployXMLCovers(entry, sdfPath) }) {
+			continue
+		}
+		section := deployXMLSection(sdfPath)
+		opening, closing, lastPath, root := -1, -1, -1, -1
+		for i, line := range lines {
+			trimmed := strings.TrimSpace(line)
+			switch trimmed {
+			case "<" + section + ">":
+				opening = i
+			case "</" + section + ">":
+				closing = i
+			case "</deploy>":
+				root = i
+			}
+			if _, ok := deployXMLPathLine(line); ok && opening >= 0 && closing < opening {
+				lastPath = i
+			}
+		}
+		if opening < 0 && strings.Contains(string(data), "<"+section+">") {
+			logWarn("%s has its <%s> section on one line, add <path>%s</path> to it by hand", deployXMLPath, section, sdfPath)
+			continue
+		}
+
+		var insert []string
+		at := closing
+		switch {
+		case closing >= 0 && lastPath >= 0:
+			indent := lineIndent(lines[lastPath])
+			insert = []string{indent + "<path>" + sdfPath + "</path>"}
+		case closing >= 0:
+			indent := lineIndent(lines[closing])
+			insert = []string{indent + "    <path>" + sdfPath + "</path>"}
+		case root >= 0:
+			indent := lineIndent(lines[root]) + "    "
+			insert = []string{indent + "<" + section + ">", indent + "    <path>" + sdfPath + "</path>", indent + "</" + section + ">"}
+			at = root
+		default:
+			return nil, fmt.Errorf("%s has no closing </deploy> tag", deployXMLPath)
+		}
+		lines = append(lines[:at], append(insert, lines[at:]...)...)
+		added = append(added, sdfPath)
+	}
+
+	if len(added) == 0 || !write {
+		return added, nil
+	}
+	if err := os.WriteFile(deployXMLPath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return nil, fmt.Errorf("error writing %s: %v", deployXMLPath, err)
+	}
+	return added, nil
+}
+
+// lineIndent returns the leading whitespace of line.
+func lineIndent(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// captureWarnings collects the warnings logged by the test, as --json does.
+func captureWarnings(t *testing.T) func() []string {
+	t.Helper()
+	jsonFlag, jsonCapture.output = true, JSONOutput{}
+	t.Cleanup(func() { jsonFlag, jsonCapture.output = false, JSONOutput{} })
+	return func() []string { return jsonCapture.output.Warnings }
+}
+
+// writeDeployXML writes content to a deploy.xml in a temporary directory and returns its path.
+func writeDeployXML(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "deploy.xml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readDeployXML(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+const (
+	deployScript = "~/FileCabinet/SuiteScripts/acme_orders_suitelet.js"
+	deployObject = "~/Objects/customscript_acme_sl_orders.xml"
+)
+
+func TestAddDeployXMLReferences(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		paths   []string
+		added   []string
+		want    string
+	}{
+		"appended to the section": {
+			content: "<deploy>\n    <files>\n        <path>~/FileCabinet/SuiteScripts/lib.js</path>\n    </files>\n</deploy>\n",
+			paths:   []string{deployScript, deployScript},
+			added:   []string{deployScript},
+			want:    "<deploy>\n    <files>\n        <path>~/FileCabinet/SuiteScripts/lib.js</path>\n        <path>" + deployScript + "</path>\n    </files>\n</deploy>\n",
+		},
+		"covered by a wildcard": {
+			content: "<deploy>\n    <files>\n        <path>~/FileCabinet/SuiteScripts/*</path>\n    </files>\n</deploy>\n",
+			paths:   []string{deployScript},
+			want:    "<deploy>\n    <files>\n        <path>~/FileCabinet/SuiteScripts/*</path>\n    </files>\n</deploy>\n",
+		},
+		"already listed": {
+			content: "<deploy>\n    <objects>\n        <path>" + deployObject + "</path>\n    </objects>\n</deploy>\n",
+			paths:   []string{deployObject},
+			want:    "<deploy>\n    <objects>\n        <path>" + deployObject + "</path>\n    </objects>\n</deploy>\n",
+		},
+		"empty section": {
+			content: "<deploy>\n    <objects>\n    </objects>\n</deploy>\n",
+			paths:   []string{deployObject},
+			added:   []string{deployObject},
+			want:    "<deploy>\n    <objects>\n        <path>" + deployObject + "</path>\n    </objects>\n</deploy>\n",
+		},
+		"missing section": {
+			content: "<deploy>\n    <files>\n        <path>" + deployScript + "</path>\n    </files>\n</deploy>\n",
+			paths:   []string{deployObject},
+			added:   []string{deployObject},
+			want:    "<deploy>\n    <files>\n        <path>" + deployScript + "</path>\n    </files>\n    <objects>\n        <path>" + deployObject + "</path>\n    </objects>\n</deploy>\n",
+		},
+	}
+	for name, test := range tests {
+		path := writeDeployXML(t, test.content)
+		added, err := addDeployXMLReferences(path, test.paths, true)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(added, test.added) {
+			t.Errorf("%s: added %q, want %q", name, added, test.added)
+		}
+		if got := readDeployXML(t, path); got != test.want {
+			t.Errorf("%s: deploy.xml =\n%s\nwant\n%s", name, got, test.want)
+		}
+	}
+}
+
+func TestAddDeployXMLReferencesDryRun(t *testing.T) {
+	content := "<deploy>\n    <files>\n    </files>\n</deploy>\n"
+	path := writeDeployXML(t, content)
+	added, err := addDeployXMLReferences(path, []string{deployScript}, false)
+	if err != nil || !reflect.DeepEqual(added, []string{deployScript}) {
+		t.Errorf("added %q, %v, want %s", added, err, deployScript)
+	}
+	if got := readDeployXML(t, path); got != content {
+		t.Errorf("deploy.xml was written without write:\n%s", got)
+	}
+}
+
+func TestAddDeployXMLReferencesNoDeployTag(t *testing.T) {
+	path := writeDeployXML(t, "<deploy>\n    <files>\n        <path>~/FileCabinet/SuiteScripts/lib.js</path>\n    </files>\n")
+	if _, err := addDeployXMLReferences(path, []string{deployObject}, true); err == nil {
+		t.Error("a deploy.xml without </deploy> did not fail")
+	}
+}
+
+func TestAddDeployXMLReferencesOneLineSection(t *testing.T) {
+	warnings := captureWarnings(t)
+	content := "<deploy>\n    <files><path>~/FileCabinet/SuiteScripts/lib.js</path></files>\n</deploy>\n"
+	path := writeDeployXML(t, content)
+
+	added, err := addDeployXMLReferences(path, []string{deployScript}, true)
+	if err != nil || len(added) != 0 {
+		t.Errorf("added %q, %v, want nothing", added, err)
+	}
+	if got := readDeployXML(t, path); got != content {
+		t.Errorf("deploy.xml =\n%s\nwant it unchanged", got)
+	}
+	if len(warnings()) != 1 {
+		t.Errorf("warnings = %q, want one about the <files> section", warnings())
+	}
+
+	// An entry on one line still covers the path.
+	path = writeDeployXML(t, "<deploy>\n    <files><path>~/FileCabinet/SuiteScripts/*</path></files>\n</deploy>\n")
+	if added, err := addDeployXMLReferences(path, []string{deployScript}, true); err != nil || len(added) != 0 {
+		t.Errorf("added %q, %v, want the wildcard to cover it", added, err)
+	}
+}
+
+func TestRewriteDeployXMLReferences(t *testing.T) {
+	warnings := captureWarnings(t)
+	path := writeDeployXML(t, "<deploy>\n    <files>\n        <path>"+deployScript+"</path>\n        <path>~/FileCabinet/SuiteScripts/lib.js</path>\n    </files>\n    <objects>\n        <path>"+deployObject+"</path>\n    </objects>\n</deploy>\n")
+
+	changed, err := rewriteDeployXMLReferences(path, func(p string) (string, bool) {
+		switch p {
+		case deployScript:
+			return "~/FileCabinet/SuiteScripts/acme_sales_suitelet.js", true
+		case deployObject:
+			return "", false
+		}
+		return p, true
+	})
+	if err != nil || changed != 2 {
+		t.Errorf("changed %d, %v, want 2", changed, err)
+	}
+	want := "<deploy>\n    <files>\n        <path>~/FileCabinet/SuiteScripts/acme_sales_suitelet.js</path>\n        <path>~/FileCabinet/SuiteScripts/lib.js</path>\n    </files>\n    <objects>\n    </objects>\n</deploy>\n"
+	if got := readDeployXML(t, path); got != want {
+		t.Errorf("deploy.xml =\n%s\nwant\n%s", got, want)
+	}
+	if len(warnings()) != 0 {
+		t.Errorf("warnings = %q, want none", warnings())
+	}
+
+	path = writeDeployXML(t, "<deploy>\n    <objects><path>"+deployObject+"</path></objects>\n</deploy>\n")
+	if changed, err := removeDeployXMLReferences(path, []string{deployObject}); err != nil || changed != 0 {
+		t.Errorf("removed %d, %v, want 0 from a one-line section", changed, err)
+	}
+	if len(warnings()) != 1 {
+		t.Errorf("warnings = %q, want one about the one-line entry", warnings())
+	}
+}
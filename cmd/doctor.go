@@ -0,0 +1,407 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the development environment and project health",
+	Long: `Verify that the tools required by SuiteCloud development are installed (SuiteCloud CLI,
+Node.js and Java), and when run inside a project, that the project structure, the
+.netsuite-cli configuration and the account authentication are valid. Every failed
+check comes with a suggestion to fix it.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDoctor()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+const (
+	// minNodeVersion is the oldest Node.js major version supported by the SuiteCloud CLI.
+	minNodeVersion = 18
+	// minJavaVersion is the oldest Java major version supported by the SuiteCloud CLI.
+	minJavaVersion = 17
+	// doctorCommandTimeout bounds every external command run by doctor.
+	doctorCommandTimeout = 30 * time.Second
+)
+
+var (
+	doctorVersionRe     = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?`)
+	doctorJavaVersionRe = regexp.MustCompile(`version "([^"]+)"`)
+)
+
+// DoctorCheck is the outcome of a single doctor check.
+type DoctorCheck struct {
+	Name    string
+	Status  string
+	Message string
+	Fix     string
+}
+
+// Doctor check statuses.
+const (
+	doctorOK      = "ok"
+	doctorWarning = "warning"
+	doctorFailed  = "failed"
+)
+
+// runDoctor runs all checks and prints their outcome.
+func runDoctor() error {
+	var checks []DoctorCheck
+	checks = append(checks, checkSuiteCloudCLI(), checkNodeVersion(), checkJavaVersion())
+	checks = append(checks, checkUserDirs()...)
+	checks = append(checks, checkProject()...)
+
+	failed := 0
+	for _, check := range checks {
+		symbol, color := "✓", colorGreen
+		switch check.Status {
+		case doctorWarning:
+			symbol, color = "!", colorYellow
+		case doctorFailed:
+			symbol, color = "✗", colorRed
+			failed++
+		}
+		fmt.Printf("%s %s: %s\n", colorize(os.Stdout, color, symbol), check.Name, check.Message)
+		if check.Status != doctorOK && check.Fix != "" {
+			fmt.Printf("    Fix: %s\n", check.Fix)
+		}
+	}
+
+	fmt.Println()
+	if failed > 0 {
+		return validationError("%d check(s) failed", failed)
+	}
+	logInfo("✓ No problems found.")
+	return nil
+}
+
+// runVersionCommand runs a space separated command line and returns its combined output.
+func runVersionCommand(commandLine string) (string, error) {
+	ctx, cancel := context.WithTimeout(runContext, doctorCommandTimeout)
+	defer cancel()
+	fields := strings.Fields(commandLine)
+	logDebug("running %s", commandLine)
+	output, err := exec.CommandContext(ctx, fields[0], fields[1:]...).CombinedOutput()
+	return strings.TrimSpace(string(output)), err
+}
+
+// parseMajorVersion returns the major version of the first version number in text. Legacy
+// Java versions such as 1.8 are reported as 8.
+func parseMajorVersion(text string) (int, bool) {
+	m := doctorVersionRe.FindStringSubmatch(text)
+	if m == nil {
+		return 0, false
+	}
+	major, _ := strconv.Atoi(m[1])
+	if major == 1 {
+		major, _ = strconv.Atoi(m[2])
+	}
+	return major, true
+}
+
+// firstLine returns the first line of text.
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return strings.TrimSpace(line)
+}
+
+// checkUserDirs reports where the user templates and the netsuite-cli logs are kept, which
+// depends on the operating system.
+func checkUserDirs() []DoctorCheck {
+	userDir, err := UserConfigDir()
+	if err != nil {
+		return []DoctorCheck{{
+			Name:    "User directory",
+			Status:  doctorWarning,
+			Message: err.Error(),
+			Fix:     "Set the HOME environment variable (%AppData% on Windows)",
+		}}
+	}
+	return []DoctorCheck{
+		{Name: "User templates", Status: doctorOK, Message: filepath.Join(userDir, "templates")},
+		{Name: "Logs", Status: doctorOK, Message: filepath.Join(userDir, "logs")},
+	}
+}
+
+// checkSuiteCloudCLI checks that the SuiteCloud CLI is installed and reports its version.
+func checkSuiteCloudCLI() DoctorCheck {
+	check := DoctorCheck{Name: "SuiteCloud CLI"}
+	suiteCloudCmd := getSuiteCloudCommand()
+	if suiteCloudCmd == "" {
+		check.Status = doctorFailed
+		check.Message = "not found"
+		check.Fix = "npm install -g " + suiteCloudPackage + ", or pass --npx to run it through npx"
+		return check
+	}
+
+	output, err := runVersionCommand(suiteCloudCmd + " --version")
+	if err != nil {
+		check.Status = doctorFailed
+		check.Message = fmt.Sprintf("'%s --version' failed: %v", suiteCloudCmd, err)
+		check.Fix = "Reinstall it with: npm install -g " + suiteCloudPackage
+		return check
+	}
+	check.Status = doctorOK
+	check.Message = firstLine(output)
+	return check
+}
+
+// checkNodeVersion checks that a supported Node.js version is installed.
+func checkNodeVersion() DoctorCheck {
+	check := DoctorCheck{Name: "Node.js", Fix: fmt.Sprintf("Install Node.js %d or later from https://nodejs.org", minNodeVersion)}
+	output, err := runVersionCommand("node --version")
+	if err != nil {
+		check.Status = doctorFailed
+		check.Message = "not found"
+		return check
+	}
+
+	major, ok := parseMajorVersion(output)
+	switch {
+	case !ok:
+		check.Status = doctorWarning
+		check.Message = fmt.Sprintf("could not parse version '%s'", firstLine(output))
+	case major < minNodeVersion:
+		check.Status = doctorFailed
+		check.Message = fmt.Sprintf("%s is too old", firstLine(output))
+	default:
+		check.Status = doctorOK
+		check.Message = firstLine(output)
+	}
+	return check
+}
+
+// checkJavaVersion checks that a Java version supported by SDF is installed.
+func checkJavaVersion() DoctorCheck {
+	check := DoctorCheck{Name: "Java", Fix: fmt.Sprintf("Install a Java %d or later JDK and make sure 'java' is in your PATH", minJavaVersion)}
+	output, err := runVersionCommand("java -version")
+	if err != nil {
+		check.Status = doctorFailed
+		check.Message = "not found"
+		return check
+	}
+
+	version := firstLine(output)
+	if m := doctorJavaVersionRe.FindStringSubmatch(output); m != nil {
+		version = m[1]
+	}
+	major, ok := parseMajorVersion(version)
+	switch {
+	case !ok:
+		check.Status = doctorWarning
+		check.Message = fmt.Sprintf("could not parse version '%s'", version)
+	case major < minJavaVersion:
+		check.Status = doctorFailed
+		check.Message = fmt.Sprintf("%s is too old", version)
+	default:
+		check.Status = doctorOK
+		check.Message = version
+	}
+	return check
+}
+
+// checkProject checks the project structure, configuration and authentication. Outside a
+// project only a warning is reported.
+func checkProject() []DoctorCheck {
+	root, err := FindProjectRoot()
+	if err != nil {
+		return []DoctorCheck{{
+			Name:    "Project",
+			Status:  doctorWarning,
+			Message: "not inside a netsuite-cli project, project checks skipped",
+			Fix:     "Run 'netsuite-cli create' or 'netsuite-cli adopt'",
+		}}
+	}
+	if err := os.Chdir(root); err != nil {
+		return []DoctorCheck{{Name: "Project", Status: doctorFailed, Message: err.Error()}}
+	}
+
+	checks := checkProjectStructure()
+
+	config, err := LoadConfig()
+	if err != nil {
+		return append(checks, DoctorCheck{
+			Name:    ".netsuite-cli",
+			Status:  doctorFailed,
+			Message: err.Error(),
+			Fix:     "Fix the syntax of the file or recreate the file with 'netsuite-cli adopt --force'",
+		})
+	}
+	checks = append(checks, checkProjectConfig(config))
+	return append(checks, checkAuthentication(config)...)
+}
+
+// checkProjectStructure checks that the SDF project files and directories exist.
+func checkProjectStructure() []DoctorCheck {
+	var checks []DoctorCheck
+
+	if path, ok := findManifestXML(); ok {
+		checks = append(checks, DoctorCheck{Name: "manifest.xml", Status: doctorOK, Message: path})
+	} else {
+		checks = append(checks, DoctorCheck{
+			Name:    "manifest.xml",
+			Status:  doctorFailed,
+			Message: "not found",
+			Fix:     "Restore src/manifest.xml or recreate the project with 'suitecloud project:create'",
+		})
+	}
+
+	if path, ok := findDeployXML(); ok {
+		checks = append(checks, DoctorCheck{Name: "deploy.xml", Status: doctorOK, Message: path})
+	} else {
+		checks = append(checks, DoctorCheck{
+			Name:    "deploy.xml",
+			Status:  doctorFailed,
+			Message: "not found",
+			Fix:     "Restore src/deploy.xml listing the files and objects to deploy",
+		})
+	}
+
+	for _, dir := range []struct {
+		name  string
+		paths []string
+	}{
+		{"SuiteScripts", []string{"src/FileCabinet/SuiteScripts", "src/SuiteScripts", "SuiteScripts"}},
+		{"Objects", []string{"src/Objects", "Objects"}},
+	} {
+		check := DoctorCheck{
+			Name:    dir.name,
+			Status:  doctorFailed,
+			Message: "directory not found",
+			Fix:     fmt.Sprintf("Create %s", dir.paths[0]),
+		}
+		for _, path := range dir.paths {
+			if info, err := os.Stat(path); err == nil && info.IsDir() {
+				check.Status = doctorOK
+				check.Message = path
+				break
+			}
+		}
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+// checkProjectConfig checks that the project configuration holds the required values and
+// produces valid script names.
+func checkProjectConfig(config *ProjectConfig) DoctorCheck {
+	check := DoctorCheck{Name: ".netsuite-cli", Status: doctorFailed}
+
+	var problems []string
+	if config.ProjectName == "" {
+		problems = append(problems, "projectName is empty")
+	}
+	if config.CompanyName == "" && config.CompanyPrefix == "" {
+		problems = append(problems, "companyName and companyPrefix are empty")
+	}
+	if config.CompanyPrefix != "" {
+		if err := ValidateCompanyPrefix(config.CompanyPrefix); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if config.DefaultEnvironment != "" {
+		if _, ok := config.Environments[config.DefaultEnvironment]; !ok {
+			problems = append(problems, fmt.Sprintf("default environment '%s' is not defined", config.DefaultEnvironment))
+		}
+	}
+	if _, err := config.ScriptNaming("example", "suitelet"); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if len(problems) > 0 {
+		check.Message = strings.Join(problems, "; ")
+		check.Fix = "Correct the values with 'netsuite-cli config set <key> <value>'"
+		return check
+	}
+	check.Status = doctorOK
+	check.Message = fmt.Sprintf("project %s, prefix %s", config.ProjectName, config.Prefix())
+	return check
+}
+
+// checkAuthentication checks that an SDF authentication ID is selected and known to the
+// SuiteCloud CLI, and that the default account profile has usable OAuth 2.0 credentials.
+func checkAuthentication(config *ProjectConfig) []DoctorCheck {
+	var checks []DoctorCheck
+
+	check := DoctorCheck{Name: "SDF authentication", Status: doctorFailed}
+	authID, err := resolveAuthID(config, "")
+	if err != nil {
+		check.Message = err.Error()
+		check.Fix = "Define the environment with 'netsuite-cli env add <name> <authid>'"
+		return append(checks, check)
+	}
+	if authID == "" {
+		if data, err := os.ReadFile("project.json"); err == nil {
+			var projectJSON struct {
+				DefaultAuthID string `json:"defaultAuthId"`
+			}
+			if json.Unmarshal(data, &projectJSON) == nil {
+				authID = projectJSON.DefaultAuthID
+			}
+		}
+	}
+
+	switch suiteCloudCmd := getSuiteCloudCommand(); {
+	case authID == "":
+		check.Message = "no authentication ID configured"
+		check.Fix = "Run 'suitecloud account:setup' in the project directory"
+	case suiteCloudCmd == "":
+		check.Status = doctorWarning
+		check.Message = fmt.Sprintf("authentication ID '%s' could not be verified without the SuiteCloud CLI", authID)
+	default:
+		output, err := runVersionCommand(suiteCloudCmd + " account:manageauth --list")
+		if err != nil {
+			check.Status = doctorWarning
+			check.Message = fmt.Sprintf("could not list authentication IDs: %v", err)
+			check.Fix = "Run 'suitecloud account:manageauth --list' to inspect the configured accounts"
+		} else if !regexp.MustCompile(`(?m)^\s*` + regexp.QuoteMeta(authID) + `\b`).MatchString(output) {
+			check.Message = fmt.Sprintf("authentication ID '%s' is not set up on this machine", authID)
+			check.Fix = "Run 'suitecloud account:setup' to authenticate it"
+		} else {
+			check.Status = doctorOK
+			check.Message = authID
+		}
+	}
+	checks = append(checks, check)
+
+	userConfig, err := LoadUserConfig()
+	if err != nil || userConfig == nil {
+		return checks
+	}
+	account := userConfig.DefaultAccount()
+	if account == nil || account.ClientID == "" {
+		return checks
+	}
+
+	oauth := DoctorCheck{Name: "OAuth 2.0", Status: doctorOK, Message: fmt.Sprintf("account profile '%s'", account.Label)}
+	if _, err := newAuthClient(account); err != nil {
+		oauth.Status = doctorFailed
+		oauth.Message = err.Error()
+	} else if storedPrivateKey(account) == nil {
+		if _, err := os.Stat(account.PrivateKeyPath); err != nil {
+			oauth.Status = doctorFailed
+			oauth.Message = fmt.Sprintf("private key %s is not readable", account.PrivateKeyPath)
+			oauth.Fix = fmt.Sprintf("Import the key with 'netsuite-cli auth login %s --private-key <path>'", account.Label)
+		}
+	}
+	return append(checks, oauth)
+}
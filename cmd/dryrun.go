@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+)
+
+// dryRunFlag makes add and create report the files and directories they would create without touching disk.
+var dryRunFlag bool
+
+// makeDir creates a directory and its parents. In dry-run mode it only reports directories that do not exist yet.
+func makeDir(path string) error {
+	if !dryRunFlag {
+		recordCreatedDirs(path)
+		return os.MkdirAll(path, 0755)
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		reportDryRunDir(path)
+	}
+	return nil
+}
+
+// reportDryRunDir prints the directory that would be created in dry-run mode.
+func reportDryRunDir(path string) {
+	if !addJSONFile(JSONFile{Path: path + "/", Action: "would create"}) {
+		fmt.Printf("Would create directory %s/\n", path)
+	}
+}
+
+// reportDryRunFile prints the file that would be written in dry-run mode, with its size.
+func reportDryRunFile(path string, size int) {
+	action := "create"
+	if _, err := os.Stat(path); err == nil {
+		action = "overwrite"
+	}
+	if !addJSONFile(JSONFile{Path: path, Action: "would " + action, Size: size}) {
+		fmt.Printf("Would %s %s (%d bytes)\n", action, path, size)
+	}
+}
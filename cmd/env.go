@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var envFlag string
+
+// envCmd represents the env command
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Manage project environments",
+	Long: `Manage the named environments of the project. Each environment maps to a
+SuiteCloud authentication ID created with 'suitecloud account:setup' and can be
+selected with --env on the deploy, validate and push commands.`,
+}
+
+// envListCmd represents the env list command
+var envListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the project environments",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runEnvList()
+	},
+}
+
+// envAddCmd represents the env add command
+var envAddCmd = &cobra.Command{
+	Use:   "add <name> <authid>",
+	Short: "Add or update a project environment",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runEnvAdd(args[0], args[1])
+	},
+}
+
+// envRemoveCmd represents the env remove command
+var envRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a project environment",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runEnvRemove(args[0])
+	},
+}
+
+// envDefaultCmd represents the env default command
+var envDefaultCmd = &cobra.Command{
+	Use:   "default <name>",
+	Short: "Set the environment used when --env is not given",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runEnvDefault(args[0])
+	},
+}
+
+func init() {
+	envCmd.AddCommand(envListCmd)
+	envCmd.AddCommand(envAddCmd)
+	envCmd.AddCommand(envRemoveCmd)
+	envCmd.AddCommand(envDefaultCmd)
+	rootCmd.AddCommand(envCmd)
+}
+
+// resolveAuthID returns the authentication ID of the named environment, or of the default
+// environment when name is empty. Names that are not project environments are looked up in
+// the user's account profiles, and the default account profile is used when the project
+// selects no environment and its project.json has no defaultAuthId. An empty result means the
+// suitecloud default is used.
+func resolveAuthID(config *ProjectConfig, name string) (string, error) {
+	if name == "" {
+		name = config.DefaultEnvironment
+	}
+
+	userConfig, err := LoadUserConfig()
+	if err != nil {
+		logWarn("Failed to load user configuration: %v", err)
+	}
+
+	if name == "" {
+		if projectJSONAuthID() != "" {
+			return "", nil
+		}
+		if userConfig != nil {
+			if account := userConfig.DefaultAccount(); account != nil {
+				return account.AuthID, nil
+			}
+		}
+		return "", nil
+	}
+
+	if authID, ok := config.Environments[name]; ok {
+		return authID, nil
+	}
+	if userConfig != nil {
+		if account := userConfig.FindAccount(name); account != nil {
+			return account.AuthID, nil
+		}
+	}
+	return "", fmt.Errorf("environment '%s' is not defined, use 'netsuite-cli env add %s <authid>'", name, name)
+}
+
+// activateEnvironment points project.json at the authentication ID of the selected environment.
+// The returned function restores the previous project.json and must be called once the
+// suitecloud command has finished; it also runs when the CLI is forced to exit before.
+func activateEnvironment(config *ProjectConfig, name string) (func(), error) {
+	authID, err := resolveAuthID(config, name)
+	if err != nil {
+		return nil, err
+	}
+	if authID == "" {
+		return func() {}, nil
+	}
+
+	const projectJSONPath = "project.json"
+	original, readErr := os.ReadFile(projectJSONPath)
+	if readErr != nil && !os.IsNotExist(readErr) {
+		return nil, fmt.Errorf("error reading %s: %v", projectJSONPath, readErr)
+	}
+
+	projectJSON := make(map[string]any)
+	if readErr == nil {
+		if err := json.Unmarshal(original, &projectJSON); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %v", projectJSONPath, err)
+		}
+	}
+	projectJSON["defaultAuthId"] = authID
+
+	data, err := json.MarshalIndent(projectJSON, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling %s: %v", projectJSONPath, err)
+	}
+	if err := os.WriteFile(projectJSONPath, data, 0644); err != nil {
+		return nil, fmt.Errorf("error writing %s: %v", projectJSONPath, err)
+	}
+
+	logDebug("using authentication ID '%s'", authID)
+
+	return cleanupOnExit(func() {
+		if readErr != nil {
+			os.Remove(projectJSONPath)
+			return
+		}
+		if err := os.WriteFile(projectJSONPath, original, 0644); err != nil {
+			logWarn("Failed to restore %s: %v", projectJSONPath, err)
+		}
+	}), nil
+}
+
+// loadProjectConfig loads the project configuration, returning a ConfigError when the current
+// directory is not in a project.
+func loadProjectConfig() (*ProjectConfig, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, configError(err)
+	}
+	return config, nil
+}
+
+// saveProjectConfig saves the project configuration in the current directory.
+func saveProjectConfig(config *ProjectConfig) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("error getting current directory: %v", err)
+	}
+	if err := SaveConfig(cwd, config); err != nil {
+		return configError(err)
+	}
+	return nil
+}
+
+// runEnvList prints the project environments.
+func runEnvList() error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+	if len(config.Environments) == 0 {
+		fmt.Println("No environments defined. Use 'netsuite-cli env add <name> <authid>' to add one.")
+		return nil
+	}
+
+	names := make([]string, 0, len(config.Environments))
+	for name := range config.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if jsonFlag {
+		type environment struct {
+			Name    string `json:"name"`
+			AuthID  string `json:"authId"`
+			Default bool   `json:"default"`
+		}
+		environments := make([]environment, 0, len(names))
+		for _, name := range names {
+			environments = append(environments, environment{Name: name, AuthID: config.Environments[name], Default: name == config.DefaultEnvironment})
+		}
+		setJSONResult(environments)
+		return nil
+	}
+
+	for _, name := range names {
+		marker := " "
+		if name == config.DefaultEnvironment {
+			marker = "*"
+		}
+		fmt.Printf("%s %s\t%s\n", marker, name, config.Environments[name])
+	}
+	return nil
+}
+
+// runEnvAdd adds or updates a project environment.
+func runEnvAdd(name, authID string) error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+	if config.Environments == nil {
+		config.Environments = make(map[string]string)
+	}
+	config.Environments[name] = authID
+	if config.DefaultEnvironment == "" {
+		config.DefaultEnvironment = name
+	}
+	if err := saveProjectConfig(config); err != nil {
+		return err
+	}
+	logInfo("Environment '%s' now uses authentication ID '%s'", name, authID)
+	return nil
+}
+
+// runEnvRemove removes a project environment.
+func runEnvRemove(name string) error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+	if _, ok := config.Environments[name]; !ok {
+		return fmt.Errorf("environment '%s' is not defined", name)
+	}
+	delete(config.Environments, name)
+	if config.DefaultEnvironment == name {
+		config.DefaultEnvironment = ""
+	}
+	if err := saveProjectConfig(config); err != nil {
+		return err
+	}
+	logInfo("Environment '%s' removed", name)
+	return nil
+}
+
+// runEnvDefault sets the default project environment.
+func runEnvDefault(name string) error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+	if _, ok := config.Environments[name]; !ok {
+		return fmt.Errorf("environment '%s' is not defined", name)
+	}
+	config.DefaultEnvironment = name
+	if err := saveProjectConfig(config); err != nil {
+		return err
+	}
+	logInfo("Default environment set to '%s'", name)
+	return nil
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// useDirs runs the test in a temporary project directory with a temporary home directory holding
+// a user configuration whose default account profile has the authentication ID user-default.
+func useDirs(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	user := `{"accounts": [{"label": "acme", "accountId": "1234567", "authId": "user-default", "default": true}]}`
+	if err := os.WriteFile(filepath.Join(home, ".netsuite-cli"), []byte(user), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	t.Chdir(dir)
+	return dir
+}
+
+func TestResolveAuthIDPrefersProjectJSON(t *testing.T) {
+	useDirs(t)
+	config := &ProjectConfig{Environments: map[string]string{"sb": "project-sb"}}
+
+	if authID, err := resolveAuthID(config, ""); err != nil || authID != "user-default" {
+		t.Errorf("without project.json = %q, %v, want the default profile", authID, err)
+	}
+
+	if err := os.WriteFile("project.json", []byte(`{"defaultAuthId": "project-own"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if authID, err := resolveAuthID(config, ""); err != nil || authID != "" {
+		t.Errorf("with a project.json defaultAuthId = %q, %v, want it kept", authID, err)
+	}
+	if authID, err := resolveAuthID(config, "sb"); err != nil || authID != "project-sb" {
+		t.Errorf("--env sb = %q, %v, want project-sb", authID, err)
+	}
+	config.DefaultEnvironment = "sb"
+	if authID, err := resolveAuthID(config, ""); err != nil || authID != "project-sb" {
+		t.Errorf("default environment = %q, %v, want project-sb", authID, err)
+	}
+	if _, err := resolveAuthID(config, "missing"); err == nil {
+		t.Error("an unknown environment did not fail")
+	}
+}
+
+func TestActivateEnvironmentRestoresOnExit(t *testing.T) {
+	useDirs(t)
+	original := `{"defaultAuthId": "project-own"}`
+	if err := os.WriteFile("project.json", []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config := &ProjectConfig{Environments: map[string]string{"sb": "project-sb"}}
+
+	restore, err := activateEnvironment(config, "sb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := projectJSONAuthID(); got != "project-sb" {
+		t.Errorf("activated defaultAuthId = %q, want project-sb", got)
+	}
+
+	// A forced exit restores project.json, and the command's own restore is then a no-op.
+	runExitCleanups()
+	if data, _ := os.ReadFile("project.json"); string(data) != original {
+		t.Errorf("project.json after exit = %s, want %s", data, original)
+	}
+	if err := os.WriteFile("project.json", []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	restore()
+	if data, _ := os.ReadFile("project.json"); string(data) != `{}` {
+		t.Errorf("restore ran twice, project.json = %s", data)
+	}
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"netsuite-cli/pkg/scaffold"
+)
+
+// Exit codes of the CLI. They are part of its interface, scripts and CI jobs can rely on them.
+const (
+	exitOK          = 0
+	exitFailure     = 1   // any other error
+	exitConfig      = 2   // missing or invalid project or user configuration
+	exitTool        = 3   // missing or failing external tool (SuiteCloud CLI, npm, git, ...)
+	exitValidation  = 4   // validate, lint, graph or doctor found problems
+	exitTimeout     = 124 // the command ran past --timeout, as with timeout(1)
+	exitInterrupted = 130 // the user pressed Ctrl+C, as with a shell killed by SIGINT
+)
+
+// ConfigError reports a missing or invalid project or user configuration.
+type ConfigError struct {
+	Err error
+}
+
+func (e *ConfigError) Error() string { return e.Err.Error() }
+func (e *ConfigError) Unwrap() error { return e.Err }
+
+// ToolError reports an external tool that is missing or failed.
+type ToolError struct {
+	Tool string
+	Err  error
+}
+
+func (e *ToolError) Error() string { return e.Err.Error() }
+func (e *ToolError) Unwrap() error { return e.Err }
+
+// TemplateError reports a template that cannot be read, parsed or rendered.
+type TemplateError = scaffold.TemplateError
+
+// ValidationError reports that checks found problems. The problems themselves have been printed
+// by the command.
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string { return e.Message }
+
+// errCancelled is returned when the user declines to continue. It ends the command without an
+// error message.
+var errCancelled = errors.New("cancelled")
+
+// configError wraps err in a ConfigError.
+func configError(err error) error {
+	return &ConfigError{Err: err}
+}
+
+// toolError wraps err in a ToolError for the named tool.
+func toolError(tool string, err error) error {
+	return &ToolError{Tool: tool, Err: err}
+}
+
+// validationError returns a ValidationError with a formatted message.
+func validationError(format string, args ...any) error {
+	return &ValidationError{Message: fmt.Sprintf(format, args...)}
+}
+
+// namingError returns the error of applying the naming patterns to a script name: a
+// ValidationError when the name gives an invalid ID or file name, a ConfigError when a pattern
+// of the project cannot be rendered.
+func namingError(err error) error {
+	var nameErr *InvalidScriptNameError
+	if errors.As(err, &nameErr) {
+		return validationError("%v", nameErr)
+	}
+	return configError(err)
+}
+
+// exitCode returns the process exit code for an error returned by a command.
+func exitCode(err error) int {
+	var (
+		configErr     *ConfigError
+		toolErr       *ToolError
+		validationErr *ValidationError
+	)
+	switch {
+	case err == nil, errors.Is(err, errCancelled):
+		return exitOK
+	case errors.Is(err, errInterrupted):
+		return exitInterrupted
+	case errors.Is(err, errTimedOut):
+		return exitTimeout
+	case errors.As(err, &configErr):
+		return exitConfig
+	case errors.As(err, &toolErr):
+		return exitTool
+	case errors.As(err, &validationErr):
+		return exitValidation
+	default:
+		return exitFailure
+	}
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	formObjectRe     = regexp.MustCompile(`<(entryform|transactionform)\s[^>]*scriptid="([^"]+)"`)
+	formRecordTypeRe = regexp.MustCompile(`<recordtype>\s*([^<]*?)\s*</recordtype>`)
+	formScriptFileRe = regexp.MustCompile(`(<customcode>\s*<scriptfile>)([^<]*)(</scriptfile>)`)
+	formCustomCodeRe = regexp.MustCompile(`<customcode>\s*</customcode>|<customcode\s*/>`)
+)
+
+// localForm is a custom entry or transaction form declared in the objects of the project.
+type localForm struct {
+	Id         string
+	Path       string
+	RecordType string
+}
+
+// localForms returns the custom entry and transaction forms declared in the objects of the
+// project, sorted by script ID.
+func localForms() []localForm {
+	var forms []localForm
+	objectsDir, err := findObjectsDir()
+	if err != nil {
+		return forms
+	}
+	filepath.WalkDir(objectsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".xml" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		m := formObjectRe.FindSubmatch(data)
+		if m == nil {
+			return nil
+		}
+		form := localForm{Id: string(m[2]), Path: path}
+		if rt := formRecordTypeRe.FindSubmatch(data); rt != nil {
+			form.RecordType = strings.Trim(string(rt[1]), "[]")
+		}
+		forms = append(forms, form)
+		return nil
+	})
+	sort.Slice(forms, func(i, j int) bool { return forms[i].Id < forms[j].Id })
+	return forms
+}
+
+// findTargetForms returns the local forms matching target: the form with that script ID, else
+// the forms of the record type target names, e.g. customrecord_invoice_batch or SALESORDER.
+func findTargetForms(target string) []localForm {
+	forms := localForms()
+	for _, form := range forms {
+		if strings.EqualFold(form.Id, target) {
+			return []localForm{form}
+		}
+	}
+	var matches []localForm
+	for _, form := range forms {
+		if strings.EqualFold(form.RecordType, target) || strings.EqualFold(form.RecordType, "scriptid="+target) {
+			matches = append(matches, form)
+		}
+	}
+	return matches
+}
+
+// bindFormClientScript attaches a formclient script to the custom form named by target, a form
+// script ID or the record type of a single local form, by setting the <customcode> script file
+// of the form object XML. When the form is not in the project, or a record has several forms,
+// the steps to attach the script by hand are printed instead.
+func bindFormClientScript(reader *bufio.Reader, target, scriptFile string) error {
+	forms := findTargetForms(target)
+	if len(forms) != 1 {
+		if len(forms) > 1 {
+			ids := make([]string, len(forms))
+			for i, form := range forms {
+				ids[i] = form.Id
+			}
+			logWarn("%s has %d forms in the project (%s), pass the script ID of the one to attach the script to with --form.", target, len(forms), strings.Join(ids, ", "))
+		}
+		printFormBindingInstructions(target, scriptFile)
+		return nil
+	}
+	form := forms[0]
+
+	data, err := os.ReadFile(form.Path)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", form.Path, err)
+	}
+	content := string(data)
+	reference := "[" + scriptFile + "]"
+
+	var updated string
+	if m := formScriptFileRe.FindStringSubmatch(content); m != nil {
+		current := strings.TrimSpace(m[2])
+		if current == reference {
+			logInfo("✓ %s already uses %s", form.Id, scriptFile)
+			return nil
+		}
+		if current != "" {
+			replace := false
+			if !yesFlag && !dryRunFlag {
+				if replace, err = promptConfirm(reader, fmt.Sprintf("%s already uses %s. Replace it with %s? (y/N): ", form.Id, current, scriptFile)); err != nil {
+					return err
+				}
+			}
+			if !replace {
+				logWarn("%s keeps %s, a form runs a single custom code script. Edit %s to use %s instead.", form.Id, current, form.Path, reference)
+				return nil
+			}
+		}
+		updated = formScriptFileRe.ReplaceAllString(content, "${1}"+reference+"${3}")
+	} else {
+		updated, err = insertFormCustomCode(content, reference)
+		if err != nil {
+			logWarn("Cannot attach the script to %s: %v", form.Path, err)
+			printFormBindingInstructions(form.Id, scriptFile)
+			return nil
+		}
+	}
+
+	if dryRunFlag {
+		fmt.Printf("Would attach %s to form %s in %s\n", scriptFile, form.Id, form.Path)
+		return nil
+	}
+	recordGeneratedFile(form.Path, data, true)
+	if err := os.WriteFile(form.Path, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", form.Path, err)
+	}
+	addJSONFile(JSONFile{Path: form.Path, Action: "updated"})
+	logInfo("Attached %s to form %s in %s", scriptFile, form.Id, form.Path)
+	registerInDeployXML(form.Path)
+	return nil
+}
+
+// insertFormCustomCode adds a <customcode> element referencing the script file to a form object
+// XML that has none, or fills an empty one. The element is placed before the closing tag of the
+// form, indented like its other children.
+func insertFormCustomCode(content, reference string) (string, error) {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if !formCustomCodeRe.MatchString(line) {
+			continue
+		}
+		indent := lineIndent(line)
+		block := []string{indent + "<customcode>", indent + "  <scriptfile>" + reference + "</scriptfile>", indent + "</customcode>"}
+		lines = append(lines[:i], append(block, lines[i+1:]...)...)
+		return strings.Join(lines, "\n"), nil
+	}
+
+	end := -1
+	for i := len(lines) - 1; i >= 0; i-- {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "</entryform>" || trimmed == "</transactionform>" {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return "", fmt.Errorf("no closing </entryform> or </transactionform> tag")
+	}
+	indent := lineIndent(lines[end]) + "  "
+	if end > 0 && strings.TrimSpace(lines[end-1]) != "" {
+		indent = lineIndent(lines[end-1])
+	}
+	block := []string{indent + "<customcode>", indent + "  <scriptfile>" + reference + "</scriptfile>", indent + "</customcode>"}
+	lines = append(lines[:end], append(block, lines[end:]...)...)
+	return strings.Join(lines, "\n"), nil
+}
+
+// printFormBindingInstructions prints how to attach a formclient script to a form that is not
+// in the project.
+func printFormBindingInstructions(target, scriptFile string) {
+	fmt.Printf(`
+To attach the script to %s:
+  - In NetSuite, open Customization > Forms > Entry Forms (or Transaction Forms), edit the form,
+    and select %s on the Custom Code subtab, or
+  - Import the form with 'suitecloud object:import' and add to its object XML:
+      <customcode>
+        <scriptfile>[%s]</scriptfile>
+      </customcode>
+`, target, filepath.Base(scriptFile), scriptFile)
+}
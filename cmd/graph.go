@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	graphFormatFlag  string
+	graphModulesFlag bool
+)
+
+// graphCmd represents the graph command
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Show the dependency graph between scripts and library modules",
+	Long: `Parse the import statements and AMD define([...]) dependencies of the scripts in the
+SuiteScripts folder and print the dependency graph between entry scripts and shared library
+modules, as text or as Graphviz DOT or Mermaid source. Imports pointing to files that do not
+exist are flagged and make the command exit with a non-zero status.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runGraph()
+	},
+}
+
+func init() {
+	graphCmd.Flags().StringVarP(&graphFormatFlag, "format", "f", "text", "Output format: text, dot or mermaid")
+	graphCmd.Flags().BoolVar(&graphModulesFlag, "modules", false, "Include the N/ modules used by each script")
+
+	rootCmd.AddCommand(graphCmd)
+}
+
+var (
+	graphImportRe     = regexp.MustCompile(`(?m)^\s*(?:import|export)\s+(?:[^'";]*?\s+from\s+)?['"]([^'"]+)['"]`)
+	graphRequireRe    = regexp.MustCompile(`\brequire\s*\(\s*['"]([^'"]+)['"]\s*\)`)
+	graphDefineRe     = regexp.MustCompile(`\bdefine\s*\(\s*\[([^\]]*)\]`)
+	graphStringRe     = regexp.MustCompile(`['"]([^'"]+)['"]`)
+	graphScriptTypeRe = regexp.MustCompile(`@NScriptType\s+(\w+)`)
+	graphExtensions   = []string{".ts", ".js"}
+	graphFormats      = []string{"text", "dot", "mermaid"}
+)
+
+// GraphNode is a script or library module of the dependency graph.
+type GraphNode struct {
+	Path       string
+	ScriptType string
+	Imports    []string
+	Modules    []string
+	Missing    []string
+}
+
+// runGraph builds the dependency graph of the project and prints it in the requested format.
+func runGraph() error {
+	if _, err := loadProjectConfig(); err != nil {
+		return err
+	}
+
+	if !containsString(graphFormats, graphFormatFlag) {
+		return fmt.Errorf("unsupported format '%s' (supported: %s)", graphFormatFlag, strings.Join(graphFormats, ", "))
+	}
+
+	suiteScriptsDir, err := findSuiteScriptsDir()
+	if err != nil {
+		return err
+	}
+
+	nodes, err := buildDependencyGraph(suiteScriptsDir)
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		fmt.Printf("No scripts found in %s\n", suiteScriptsDir)
+		return nil
+	}
+
+	switch graphFormatFlag {
+	case "dot":
+		printGraphDOT(nodes)
+	case "mermaid":
+		printGraphMermaid(nodes)
+	default:
+		printGraphText(nodes)
+	}
+
+	missing := 0
+	for _, node := range nodes {
+		missing += len(node.Missing)
+	}
+	if missing > 0 {
+		return validationError("%d import(s) point to files that do not exist", missing)
+	}
+	return nil
+}
+
+// buildDependencyGraph parses the scripts under suiteScriptsDir. When both a TypeScript source
+// and its compiled JavaScript exist, only the TypeScript source is used. Node paths are
+// relative to suiteScriptsDir.
+func buildDependencyGraph(suiteScriptsDir string) ([]*GraphNode, error) {
+	var files []string
+	err := filepath.WalkDir(suiteScriptsDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "node_modules" || d.Name() == testsDir || d.Name() == "__mocks__" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := filepath.Ext(path)
+		if !containsString(graphExtensions, ext) || strings.HasSuffix(path, ".d.ts") || strings.HasSuffix(path, ".test.ts") {
+			return nil
+		}
+		if ext == ".js" {
+			if _, err := os.Stat(strings.TrimSuffix(path, ext) + ".ts"); err == nil {
+				return nil
+			}
+		}
+		files = append(files, path)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", suiteScriptsDir, err)
+	}
+	sort.Strings(files)
+
+	fileCabinetDir := filepath.Dir(suiteScriptsDir)
+	var nodes []*GraphNode
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %v", file, err)
+		}
+
+		node := &GraphNode{Path: graphNodePath(suiteScriptsDir, file)}
+		if match := graphScriptTypeRe.FindSubmatch(content); match != nil {
+			node.ScriptType = strings.ToLower(string(match[1]))
+		}
+		for _, dependency := range parseScriptDependencies(string(content)) {
+			switch {
+			case strings.HasPrefix(dependency, "N/"):
+				if !containsString(node.Modules, dependency) {
+					node.Modules = append(node.Modules, dependency)
+				}
+			case strings.HasPrefix(dependency, "."), strings.HasPrefix(dependency, "/"):
+				base := filepath.Join(filepath.Dir(file), filepath.FromSlash(dependency))
+				if strings.HasPrefix(dependency, "/") {
+					base = filepath.Join(fileCabinetDir, filepath.FromSlash(dependency))
+				}
+				if resolved, ok := resolveScriptDependency(base); ok {
+					path := graphNodePath(suiteScriptsDir, resolved)
+					if !containsString(node.Imports, path) {
+						node.Imports = append(node.Imports, path)
+					}
+				} else {
+					node.Missing = append(node.Missing, dependency)
+				}
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// parseScriptDependencies returns the module paths imported by a script, in order of appearance.
+func parseScriptDependencies(content string) []string {
+	var dependencies []string
+	for _, match := range graphImportRe.FindAllStringSubmatch(content, -1) {
+		dependencies = append(dependencies, match[1])
+	}
+	for _, match := range graphRequireRe.FindAllStringSubmatch(content, -1) {
+		dependencies = append(dependencies, match[1])
+	}
+	for _, match := range graphDefineRe.FindAllStringSubmatch(content, -1) {
+		for _, name := range graphStringRe.FindAllStringSubmatch(match[1], -1) {
+			dependencies = append(dependencies, name[1])
+		}
+	}
+	return dependencies
+}
+
+// resolveScriptDependency returns the script file a module path without extension refers to.
+func resolveScriptDependency(base string) (string, bool) {
+	candidates := []string{base}
+	for _, ext := range graphExtensions {
+		candidates = append(candidates, strings.TrimSuffix(base, filepath.Ext(base))+ext, base+ext)
+	}
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			// Prefer the TypeScript source of a compiled file, as the graph does.
+			if filepath.Ext(candidate) == ".js" {
+				if ts := strings.TrimSuffix(candidate, ".js") + ".ts"; fileExists(ts) {
+					return ts, true
+				}
+			}
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// fileExists reports whether path exists and is a regular file.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// graphNodePath returns path relative to the SuiteScripts folder, with forward slashes.
+func graphNodePath(suiteScriptsDir, path string) string {
+	if rel, err := filepath.Rel(suiteScriptsDir, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(path)
+}
+
+// graphNodeKind describes a node as its script type or as a library module.
+func graphNodeKind(node *GraphNode) string {
+	if node.ScriptType != "" {
+		return node.ScriptType
+	}
+	return "library"
+}
+
+// printGraphText prints each script with its dependencies.
+func printGraphText(nodes []*GraphNode) {
+	for _, node := range nodes {
+		fmt.Printf("%s (%s)\n", node.Path, graphNodeKind(node))
+		for _, path := range node.Imports {
+			fmt.Printf("  → %s\n", path)
+		}
+		if graphModulesFlag {
+			for _, module := range node.Modules {
+				fmt.Printf("  → %s\n", module)
+			}
+		}
+		for _, path := range node.Missing {
+			fmt.Println(colorize(os.Stdout, colorRed, fmt.Sprintf("  ✗ %s (not found)", path)))
+		}
+	}
+}
+
+// printGraphDOT prints the graph as Graphviz DOT source. Entry scripts are drawn as boxes,
+// library modules as ellipses and missing imports in red.
+func printGraphDOT(nodes []*GraphNode) {
+	fmt.Println("digraph suitescripts {")
+	fmt.Println("  rankdir=LR;")
+	for _, node := range nodes {
+		shape := "ellipse"
+		if node.ScriptType != "" {
+			shape = "box"
+		}
+		fmt.Printf("  %q [shape=%s, label=%q];\n", node.Path, shape, node.Path+"\n"+graphNodeKind(node))
+	}
+	for _, node := range nodes {
+		for _, path := range node.Imports {
+			fmt.Printf("  %q -> %q;\n", node.Path, path)
+		}
+		if graphModulesFlag {
+			for _, module := range node.Modules {
+				fmt.Printf("  %q -> %q [style=dashed];\n", node.Path, module)
+			}
+		}
+		for _, path := range node.Missing {
+			missingID := node.Path + " → " + path
+			fmt.Printf("  %q [label=%q, color=red, fontcolor=red];\n", missingID, path+"\nnot found")
+			fmt.Printf("  %q -> %q [color=red];\n", node.Path, missingID)
+		}
+	}
+	fmt.Println("}")
+}
+
+// printGraphMermaid prints the graph as a Mermaid flowchart.
+func printGraphMermaid(nodes []*GraphNode) {
+	ids := make(map[string]string)
+	id := func(path string) string {
+		if _, ok := ids[path]; !ok {
+			ids[path] = fmt.Sprintf("n%d", len(ids))
+		}
+		return ids[path]
+	}
+
+	fmt.Println("flowchart LR")
+	for _, node := range nodes {
+		if node.ScriptType != "" {
+			fmt.Printf("  %s[\"%s<br/>%s\"]\n", id(node.Path), node.Path, node.ScriptType)
+		} else {
+			fmt.Printf("  %s([\"%s\"])\n", id(node.Path), node.Path)
+		}
+	}
+	var missing []string
+	for _, node := range nodes {
+		for _, path := range node.Imports {
+			fmt.Printf("  %s --> %s\n", id(node.Path), id(path))
+		}
+		if graphModulesFlag {
+			for _, module := range node.Modules {
+				if _, ok := ids[module]; !ok {
+					fmt.Printf("  %s{{\"%s\"}}\n", id(module), module)
+				}
+				fmt.Printf("  %s -.-> %s\n", id(node.Path), id(module))
+			}
+		}
+		for _, path := range node.Missing {
+			fmt.Printf("  %s[\"%s (not found)\"]\n", id(node.Path+"→"+path), path)
+			fmt.Printf("  %s --> %s\n", id(node.Path), id(node.Path+"→"+path))
+			missing = append(missing, id(node.Path+"→"+path))
+		}
+	}
+	if len(missing) > 0 {
+		fmt.Println("  classDef missing fill:#fdd,stroke:#c00")
+		fmt.Printf("  class %s missing\n", strings.Join(missing, ","))
+	}
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"netsuite-cli/pkg/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+var headersDryRunFlag bool
+
+// headersCmd represents the headers command
+var headersCmd = &cobra.Command{
+	Use:   "headers",
+	Short: "Manage the license headers of the project scripts",
+	Long: `Manage the license header configured with the license, copyrightHolder,
+copyrightYear and licenseHeader settings. The header is added to the TypeScript and
+JavaScript files generated by add, and 'headers apply' adds it to existing scripts.`,
+}
+
+// headersApplyCmd represents the headers apply command
+var headersApplyCmd = &cobra.Command{
+	Use:   "apply [path...]",
+	Short: "Add or update the license header of existing scripts",
+	Long: `Add the license header to the scripts under SuiteScripts, or to the given files and
+folders. The .ts files are updated in TypeScript projects and the .js files in JavaScript
+projects. A license header already at the top of a file is replaced, so the command also
+updates the year range and holder after the settings change.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runHeadersApply(args)
+	},
+}
+
+func init() {
+	headersApplyCmd.Flags().BoolVar(&headersDryRunFlag, "dry-run", false, "Print the files that would be updated without changing them")
+	headersCmd.AddCommand(headersApplyCmd)
+	rootCmd.AddCommand(headersCmd)
+}
+
+// runHeadersApply writes the license header to the scripts found in paths, or under SuiteScripts
+// when no path is given.
+func runHeadersApply(paths []string) error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+	header := scaffold.LicenseHeader(config, time.Now().Year())
+	if header == "" {
+		return configError(fmt.Errorf("no license header configured, set license or copyrightHolder with 'netsuite-cli config set'"))
+	}
+
+	ext := "." + config.ScriptLanguage()
+	if len(paths) == 0 {
+		dir, ok := locateSuiteScriptsDir()
+		if !ok {
+			return configError(fmt.Errorf("SuiteScripts directory not found"))
+		}
+		paths = []string{dir}
+	} else {
+		for i, path := range paths {
+			paths[i] = projectRelativePath(path)
+		}
+	}
+
+	var files []string
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				if entry.Name() == "node_modules" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if filepath.Ext(file) == ext && !strings.HasSuffix(file, ".d.ts") {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("error reading %s: %v", path, err)
+		}
+	}
+
+	if !headersDryRunFlag {
+		beginGeneration("netsuite-cli headers apply " + strings.Join(paths, " "))
+	}
+	updated := 0
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %v", file, err)
+		}
+		result := scaffold.ApplyHeader(content, header)
+		if string(result) == string(content) {
+			continue
+		}
+		updated++
+		if headersDryRunFlag {
+			fmt.Printf("Would update %s\n", file)
+			continue
+		}
+		recordGeneratedFile(file, content, true)
+		if err := os.WriteFile(file, result, 0644); err != nil {
+			return fmt.Errorf("error writing %s: %v", file, err)
+		}
+		reportFile("Updated", file, "")
+	}
+	if !headersDryRunFlag {
+		if err := commitGeneration("."); err != nil {
+			logWarn("Failed to update %s: %v", lockFileName, err)
+		}
+	}
+
+	if headersDryRunFlag {
+		fmt.Printf("%d of %d file(s) would be updated\n", updated, len(files))
+		return nil
+	}
+	logInfo("%d of %d file(s) updated", updated, len(files))
+	return nil
+}
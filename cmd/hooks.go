@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	hooksManagerFlag string
+	hooksForceFlag   bool
+)
+
+// setupHooksCmd represents the setup hooks command
+var setupHooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Install git hooks checking the project before commits and pushes",
+	Long: `Install a pre-commit hook that compiles the TypeScript sources and runs lint on the
+object XML files, and a pre-push hook that runs 'suitecloud project:validate'.
+
+The hooks are installed with husky (--manager husky), which adds the hook scripts to .husky
+and husky to package.json so every clone gets them on 'npm install', or as plain scripts in
+.git/hooks (--manager git). The default comes from the gitHooks project setting, otherwise
+husky is used when the project already depends on it.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := loadProjectConfig()
+		if err != nil {
+			return err
+		}
+		beginGeneration("netsuite-cli setup hooks")
+		err = setupHooks(config)
+		if commitErr := commitGeneration("."); commitErr != nil {
+			logWarn("Failed to update %s: %v", lockFileName, commitErr)
+		}
+		return err
+	},
+}
+
+// hookCmd represents the hook command run by the installed git hooks
+var hookCmd = &cobra.Command{
+	Use:       "hook <name>",
+	Short:     "Run the checks of a git hook installed by setup hooks",
+	Hidden:    true,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: gitHookNames,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runHook(args[0])
+	},
+}
+
+func init() {
+	setupHooksCmd.Flags().StringVar(&hooksManagerFlag, "manager", "", "How to install the hooks: "+strings.Join(gitHookManagers, " or ")+" (default: gitHooks setting or detected)")
+	setupHooksCmd.Flags().BoolVar(&hooksForceFlag, "force", false, "Replace existing hooks not installed by netsuite-cli")
+
+	setupCmd.AddCommand(setupHooksCmd)
+	rootCmd.AddCommand(hookCmd)
+}
+
+// gitHookManagers lists the supported ways of installing git hooks.
+var gitHookManagers = []string{"husky", "git"}
+
+// gitHookNames lists the git hooks installed by setup hooks.
+var gitHookNames = []string{"pre-commit", "pre-push"}
+
+// gitHookMarker identifies the hook scripts written by setup hooks.
+const gitHookMarker = "# Installed by netsuite-cli setup hooks"
+
+// huskyDevDependencies and huskyScripts register husky in package.json.
+var (
+	huskyDevDependencies = map[string]string{"husky": "^9.1.7"}
+	huskyScripts         = map[string]string{"prepare": "husky"}
+)
+
+// gitHookScript returns the content of the script running the named hook.
+func gitHookScript(name string) string {
+	return "#!/bin/sh\n" + gitHookMarker + "\nnetsuite-cli hook " + name + "\n"
+}
+
+// setupHooks installs the git hooks with the configured hook manager.
+func setupHooks(config *ProjectConfig) error {
+	manager := strings.TrimSpace(hooksManagerFlag)
+	if manager == "" {
+		manager = config.GitHooks
+	}
+	if manager == "" {
+		manager = "git"
+		if usesHusky() {
+			manager = "husky"
+		}
+	}
+	if !containsString(gitHookManagers, manager) {
+		return fmt.Errorf("unsupported hook manager '%s' (supported: %s)", manager, strings.Join(gitHookManagers, ", "))
+	}
+
+	if manager == "husky" {
+		if err := installHookScripts(".husky"); err != nil {
+			return err
+		}
+		updated, err := updatePackageJSON(".", huskyScripts, huskyDevDependencies)
+		if err != nil {
+			return err
+		}
+		if updated {
+			logInfo("Updated package.json with husky and the prepare script")
+		}
+		logInfo("Run 'npm install' to install husky and activate the hooks.")
+		return nil
+	}
+
+	out, err := externalCommand("git", "rev-parse", "--git-path", "hooks").Output()
+	if err != nil {
+		return errors.New("not a git repository, run 'git init' first or use --manager husky")
+	}
+	return installHookScripts(strings.TrimSpace(string(out)))
+}
+
+// usesHusky reports whether the project in the current directory already uses husky.
+func usesHusky() bool {
+	if info, err := os.Stat(".husky"); err == nil && info.IsDir() {
+		return true
+	}
+	data, err := os.ReadFile("package.json")
+	return err == nil && strings.Contains(string(data), `"husky"`)
+}
+
+// installHookScripts writes the hook scripts to dir. Hooks not written by netsuite-cli are
+// left in place unless --force is set.
+func installHookScripts(dir string) error {
+	recordCreatedDirs(dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("error creating directory %s: %v", dir, err)
+	}
+
+	for _, name := range gitHookNames {
+		path := filepath.Join(dir, name)
+		content := gitHookScript(name)
+		previous, err := os.ReadFile(path)
+		existed := err == nil
+		if existed && string(previous) == content {
+			reportFile("Unchanged", path, "")
+			continue
+		}
+		if existed && !strings.Contains(string(previous), gitHookMarker) && !hooksForceFlag {
+			reportFile("Skipped", path, "(existing hook, use --force to replace it)")
+			continue
+		}
+		recordGeneratedFile(path, previous, existed)
+		if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+			return fmt.Errorf("error creating %s: %v", path, err)
+		}
+		if err := os.Chmod(path, 0755); err != nil {
+			logWarn("Failed to make %s executable: %v", path, err)
+		}
+		reportFile("Created", path, "")
+	}
+	return nil
+}
+
+// runHook runs the checks of the named git hook and returns a ValidationError when they fail.
+func runHook(name string) error {
+	if _, err := loadProjectConfig(); err != nil {
+		return err
+	}
+
+	switch name {
+	case "pre-commit":
+		failed := false
+		if _, err := os.Stat("tsconfig.json"); err == nil {
+			logInfo("Compiling TypeScript...")
+			if !runTypeCheck() {
+				failed = true
+			}
+		}
+		logInfo("Linting object XML...")
+		issues, err := lintProject(nil)
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			fmt.Printf("%s: %s: %s\n", issue.File, issue.Severity, issue.Message)
+			if issue.Severity == "error" {
+				failed = true
+			}
+		}
+		if failed {
+			return validationError("pre-commit checks failed, fix the problems above or commit with --no-verify")
+		}
+	case "pre-push":
+		if err := runValidate(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown hook '%s' (available: %s)", name, strings.Join(gitHookNames, ", "))
+	}
+	return nil
+}
+
+// runTypeCheck compiles the project with tsc without emitting files and reports whether it
+// succeeded.
+func runTypeCheck() bool {
+	npxCmd := getNpxCommand()
+	if npxCmd == "" {
+		logWarn("npx not found, skipping the TypeScript compile.")
+		return true
+	}
+	tscCmd := externalCommand(npxCmd, "--no-install", "tsc", "--noEmit")
+	tscCmd.Stdout = os.Stdout
+	tscCmd.Stderr = os.Stderr
+	logCommand(tscCmd)
+	return tscCmd.Run() == nil
+}
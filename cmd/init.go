@@ -3,15 +3,19 @@ package cmd
 import (
 	"bufio"
 	"bytes"
-	"embed"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"os/user"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 
+	"netsuite-cli/pkg/scaffold"
+
 	"github.com/spf13/cobra"
 )
 
@@ -19,9 +23,41 @@ var (
 	projectNameFlag string
 	skipSetupFlag   bool
 	outputDirFlag   string
+	skipInstallFlag bool
+	packageMgrFlag  string
+	apiVersionFlag  string
+	typingsFlag     string
+	lintFlag        bool
+	gitFlag         bool
+	skipGitFlag     bool
+	gitRemoteFlag   string
+	aliasFlags      []string
 )
 
-var initTemplateFS embed.FS
+// projectConfigFiles maps the configuration files generated in a new project to their templates.
+var projectConfigFiles = map[string]string{
+	"package.json":         "package.json.tmpl",
+	"suitecloud.config.js": "suitecloud.config.js.tmpl",
+	"tsconfig.json":        "tsconfig.json.tmpl",
+	".gitignore":           ".gitignore.tmpl",
+}
+
+// projectConfigFilesFor returns the configuration files generated in a new project whose
+// scripts are written in language. JavaScript projects get a jsconfig.json, giving editors the
+// SuiteScript typings, instead of a tsconfig.json.
+func projectConfigFilesFor(language string) map[string]string {
+	if language != languageJavaScript {
+		return projectConfigFiles
+	}
+	files := make(map[string]string)
+	for name, templatePath := range projectConfigFiles {
+		if name != "tsconfig.json" {
+			files[name] = templatePath
+		}
+	}
+	files["jsconfig.json"] = "jsconfig.json.tmpl"
+	return files
+}
 
 // initCmd represents the create command
 var initCmd = &cobra.Command{
@@ -29,8 +65,8 @@ var initCmd = &cobra.Command{
 	Short: "Initialize a new NetSuite project",
 	Long: `Initialize a new NetSuite project by creating the project structure,
 generating configuration files, and setting up the account.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		runInit()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runInit()
 	},
 }
 
@@ -38,11 +74,23 @@ func init() {
 	initCmd.Flags().StringVarP(&projectNameFlag, "name", "n", "", "Project name (required)")
 	initCmd.Flags().BoolVarP(&skipSetupFlag, "skip-setup", "s", false, "Skip account setup step")
 	initCmd.Flags().StringVarP(&outputDirFlag, "output", "o", ".", "Output directory for the project (default: current directory)")
+	initCmd.Flags().StringVar(&apiVersionFlag, "api-version", "", "SuiteScript API version for generated scripts: 2.0, 2.1 or 2.x")
+	initCmd.Flags().StringVar(&langFlag, "lang", "", "Language of the generated scripts: ts or js (default: ts)")
+	initCmd.Flags().StringVar(&typingsFlag, "typings-version", "", "Version of the @hitc/netsuite-types package (default: "+defaultTypingsVersion+")")
+	initCmd.Flags().BoolVar(&lintFlag, "lint", false, "Add ESLint and Prettier configuration")
+	initCmd.Flags().BoolVar(&skipInstallFlag, "skip-install", false, "Skip installing the project dependencies")
+	initCmd.Flags().StringVar(&packageMgrFlag, "package-manager", "", "Package manager used to install dependencies: npm, pnpm or yarn (default: detected)")
+	initCmd.Flags().BoolVar(&gitFlag, "git", false, "Initialize a git repository with an initial commit without asking")
+	initCmd.Flags().BoolVar(&skipGitFlag, "skip-git", false, "Skip initializing a git repository")
+	initCmd.Flags().StringVar(&gitRemoteFlag, "remote", "", "URL of the git remote added as origin")
+	initCmd.Flags().StringArrayVar(&aliasFlags, "alias", nil, "Module alias for a folder under SuiteScripts, as alias=folder (repeatable, e.g. @lib=lib)")
+	initCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Print the files and directories that would be created without writing them")
 
 	rootCmd.AddCommand(initCmd)
 }
 
-// getSuiteCloudCommand checks for the availability of the suitecloud CLI command.
+// getSuiteCloudCommand checks for the availability of the suitecloud CLI command. With --npx
+// it falls back to running the CLI through npx.
 func getSuiteCloudCommand() string {
 	if _, err := exec.LookPath("suitecloud"); err == nil {
 		return "suitecloud"
@@ -50,40 +98,46 @@ func getSuiteCloudCommand() string {
 	if _, err := exec.LookPath("suitecloud.cmd"); err == nil {
 		return "suitecloud.cmd"
 	}
+	if npxFlag {
+		if npxCmd := getNpxCommand(); npxCmd != "" {
+			return npxCmd + " --yes " + suiteCloudPackage
+		}
+	}
 	return ""
 }
 
 // runInit executes the project initialization process.
-func runInit() {
+func runInit() error {
 	suiteCloudCmd := getSuiteCloudCommand()
+	if suiteCloudCmd == "" && dryRunFlag {
+		logWarn("suitecloud CLI is not available in the command line.")
+		suiteCloudCmd = "suitecloud"
+	}
 	if suiteCloudCmd == "" {
-		fmt.Println("Error: suitecloud CLI is not available in the command line.")
-		fmt.Println("Please install it using: npm install -g @oracle/suitecloud-cli")
-		os.Exit(1)
+		var err error
+		if suiteCloudCmd, err = ensureSuiteCloudCommand(); err != nil {
+			return err
+		}
 	}
 
 	userConfig, err := LoadUserConfig()
 	if err != nil {
-		fmt.Printf("Warning: Failed to load user configuration: %v\n", err)
+		logWarn("Failed to load user configuration: %v", err)
 	}
 
 	projectName := strings.TrimSpace(projectNameFlag)
 	if projectName == "" {
 		reader := bufio.NewReader(os.Stdin)
-		fmt.Print("Enter project name: ")
-		var err error
+		promptf("Enter project name: ")
 		projectName, err = reader.ReadString('\n')
 		if err != nil {
-			fmt.Printf("Error reading project name: %v\n", err)
-			os.Exit(1)
+			return fmt.Errorf("error reading project name: %v", err)
 		}
 		projectName = strings.TrimSpace(projectName)
 	}
 
 	if projectName == "" {
-		fmt.Println("Error: Project name cannot be empty.")
-		fmt.Println("Use --name or -n flag to specify project name, or provide it interactively.")
-		os.Exit(1)
+		return errors.New("project name cannot be empty, use the --name flag or provide it interactively")
 	}
 
 	reader := bufio.NewReader(os.Stdin)
@@ -91,23 +145,21 @@ func runInit() {
 	if userConfig != nil && userConfig.CompanyName != "" {
 		defaultCompanyName = userConfig.CompanyName
 	}
-	fmt.Print("Enter company name")
+	promptf("Enter company name")
 	if defaultCompanyName != "" {
-		fmt.Printf(" (default: %s)", defaultCompanyName)
+		promptf(" (default: %s)", defaultCompanyName)
 	}
-	fmt.Print(": ")
+	promptf(": ")
 	companyName, err := reader.ReadString('\n')
 	if err != nil {
-		fmt.Printf("Error reading company name: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("error reading company name: %v", err)
 	}
 	companyName = strings.TrimSpace(companyName)
 	if companyName == "" {
 		if defaultCompanyName != "" {
 			companyName = defaultCompanyName
 		} else {
-			fmt.Println("Error: Company name cannot be empty.")
-			os.Exit(1)
+			return errors.New("company name cannot be empty")
 		}
 	}
 
@@ -126,23 +178,21 @@ func runInit() {
 		}
 	}
 
-	fmt.Print("Enter user name")
+	promptf("Enter user name")
 	if defaultUserName != "" {
-		fmt.Printf(" (default: %s)", defaultUserName)
+		promptf(" (default: %s)", defaultUserName)
 	}
-	fmt.Print(": ")
+	promptf(": ")
 	userName, err := reader.ReadString('\n')
 	if err != nil {
-		fmt.Printf("Error reading user name: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("error reading user name: %v", err)
 	}
 	userName = strings.TrimSpace(userName)
 	if userName == "" {
 		if defaultUserName != "" {
 			userName = defaultUserName
 		} else {
-			fmt.Println("Error: User name cannot be empty.")
-			os.Exit(1)
+			return errors.New("user name cannot be empty")
 		}
 	}
 
@@ -150,35 +200,60 @@ func runInit() {
 	if userConfig != nil && userConfig.UserEmail != "" {
 		defaultUserEmail = userConfig.UserEmail
 	}
-	fmt.Print("Enter user email")
+	promptf("Enter user email")
 	if defaultUserEmail != "" {
-		fmt.Printf(" (default: %s)", defaultUserEmail)
+		promptf(" (default: %s)", defaultUserEmail)
 	}
-	fmt.Print(": ")
+	promptf(": ")
 	userEmail, err := reader.ReadString('\n')
 	if err != nil {
-		fmt.Printf("Error reading user email: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("error reading user email: %v", err)
 	}
 	userEmail = strings.TrimSpace(userEmail)
 	if userEmail == "" {
 		if defaultUserEmail != "" {
 			userEmail = defaultUserEmail
 		} else {
-			fmt.Println("Error: User email cannot be empty.")
-			os.Exit(1)
+			return errors.New("user email cannot be empty")
+		}
+	}
+
+	apiVersion := strings.TrimSpace(apiVersionFlag)
+	if apiVersion == "" {
+		if apiVersion, err = promptWithDefault(reader, fmt.Sprintf("SuiteScript API version [%s]", strings.Join(apiVersions, ", ")), defaultApiVersion); err != nil {
+			return err
+		}
+	}
+	if err := ValidateApiVersion(apiVersion); err != nil {
+		return err
+	}
+	language := languageTypeScript
+	if langFlag != "" {
+		language = strings.ToLower(strings.TrimSpace(langFlag))
+	}
+	if err := ValidateLanguage(language, apiVersion); err != nil {
+		return validationError("%v", err)
+	}
+
+	typingsVersion := strings.TrimSpace(typingsFlag)
+	if typingsVersion == "" {
+		if typingsVersion, err = promptWithDefault(reader, "@hitc/netsuite-types version", defaultTypingsVersion); err != nil {
+			return err
 		}
 	}
 
 	if strings.ContainsAny(projectName, `<>:"/\|?*`) {
-		fmt.Println("Error: Project name contains invalid characters.")
-		os.Exit(1)
+		return errors.New("project name contains invalid characters")
+	}
+
+	aliases, err := parseAliases(aliasFlags)
+	if err != nil {
+		return validationError("%v", err)
 	}
 
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Printf("Error getting current directory: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("error getting current directory: %v", err)
 	}
 
 	outputDir := outputDirFlag
@@ -191,143 +266,391 @@ func runInit() {
 	projectDir := filepath.Join(outputDir, projectName)
 
 	if _, err := os.Stat(projectDir); err == nil {
-		fmt.Printf("Error: Project directory '%s' already exists.\n", projectDir)
-		os.Exit(1)
+		return fmt.Errorf("project directory '%s' already exists", projectDir)
 	}
 
 	const projectType = "ACCOUNTCUSTOMIZATION"
-	fmt.Printf("Creating project '%s' (type: %s)...\n", projectName, projectType)
+
+	config := &ProjectConfig{
+		ProjectName:   projectName,
+		CompanyName:   companyName,
+		CompanyPrefix: GetCompanyPrefix(companyName),
+		UserName:      userName,
+		UserEmail:     userEmail,
+		ApiVersion:    apiVersion,
+	}
+	if len(aliases) > 0 {
+		config.Aliases = aliases
+	}
+	if language == languageJavaScript {
+		config.Language = language
+	}
+	templateData := map[string]string{
+		"ProjectName":    projectName,
+		"ApiVersion":     apiVersion,
+		"TypingsVersion": typingsVersion,
+		"Language":       language,
+	}
+
+	if dryRunFlag {
+		if err := printCreateDryRun(suiteCloudCmd, projectType, projectDir, config, templateData); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	steps := newStepTracker(5)
+	defer steps.printSummary()
+
+	steps.begin(fmt.Sprintf("Create project '%s' (type: %s)", projectName, projectType))
 
 	originalDir, err := os.Getwd()
 	if err != nil {
-		fmt.Printf("Error getting current directory: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("error getting current directory: %v", err)
 	}
 
 	if err := os.Chdir(outputDir); err != nil {
-		fmt.Printf("Error changing to output directory: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("error changing to output directory: %v", err)
 	}
 	defer os.Chdir(originalDir)
 
-	createCmd := exec.Command(suiteCloudCmd, "project:create", "--type", projectType, "--projectname", projectName)
-	createCmd.Stdout = os.Stdout
-	createCmd.Stderr = os.Stderr
-	createCmd.Stdin = os.Stdin
-
-	if err := createCmd.Run(); err != nil {
-		fmt.Printf("Error creating project: %v\n", err)
-		os.Exit(1)
+	createCmd := suiteCloudExec(suiteCloudCmd, "project:create", "--type", projectType, "--projectname", projectName)
+	if err := runWithSpinner(createCmd, "Running project:create"); err != nil {
+		return toolError("suitecloud", fmt.Errorf("error creating project: %v", err))
 	}
 
 	if _, err := os.Stat(projectDir); os.IsNotExist(err) {
-		fmt.Printf("Error: Project directory '%s' was not created.\n", projectDir)
-		os.Exit(1)
+		return fmt.Errorf("project directory '%s' was not created", projectDir)
 	}
 
 	suiteScriptsDir := filepath.Join(projectDir, "src", "FileCabinet", "SuiteScripts")
 	projectFolderPath := filepath.Join(suiteScriptsDir, projectName)
 	if err := os.MkdirAll(projectFolderPath, 0755); err != nil {
-		fmt.Printf("Warning: Failed to create project folder in SuiteScripts: %v\n", err)
+		logWarn("Failed to create project folder in SuiteScripts: %v", err)
 	} else {
-		fmt.Printf("Created project folder: %s\n", projectFolderPath)
+		logInfo("Created project folder: %s", projectFolderPath)
 	}
 
 	objectsDir := filepath.Join(projectDir, "src", "Objects")
 	objectsProjectFolderPath := filepath.Join(objectsDir, projectName)
 	if err := os.MkdirAll(objectsProjectFolderPath, 0755); err != nil {
-		fmt.Printf("Warning: Failed to create project folder in Objects: %v\n", err)
+		logWarn("Failed to create project folder in Objects: %v", err)
 	} else {
-		fmt.Printf("Created project folder: %s\n", objectsProjectFolderPath)
+		logInfo("Created project folder: %s", objectsProjectFolderPath)
 	}
+	steps.end(stepDone)
 
-	fmt.Println("Generating configuration files...")
+	steps.begin("Generate configuration files")
 
-	templateData := map[string]string{
-		"ProjectName": projectName,
+	beginGeneration("netsuite-cli create --name " + projectName)
+
+	for name, templatePath := range projectConfigFilesFor(language) {
+		if err := createFileFromTemplate(filepath.Join(projectDir, name), templatePath, templateData); err != nil {
+			return err
+		}
 	}
 
-	createFileFromTemplate(filepath.Join(projectDir, "package.json"), "templates/package.json.tmpl", templateData)
-	createFileFromTemplate(filepath.Join(projectDir, "suitecloud.config.js"), "templates/suitecloud.config.js.tmpl", templateData)
-	createFileFromTemplate(filepath.Join(projectDir, "tsconfig.json"), "templates/tsconfig.json.tmpl", templateData)
-	createFileFromTemplate(filepath.Join(projectDir, ".gitignore"), "templates/.gitignore.tmpl", templateData)
+	if len(config.Aliases) > 0 {
+		for _, folder := range config.Aliases {
+			if err := os.MkdirAll(filepath.Join(suiteScriptsDir, filepath.FromSlash(folder)), 0755); err != nil {
+				logWarn("Failed to create alias folder in SuiteScripts: %v", err)
+			}
+		}
+		if err := writeAliasFiles(projectDir, config); err != nil {
+			return err
+		}
+	}
 
-	if !skipSetupFlag {
-		fmt.Println("Setting up account...")
-		setupCmd := exec.Command(suiteCloudCmd, "account:setup")
+	withLint := lintFlag
+	if !withLint {
+		if withLint, err = promptConfirm(reader, "Add ESLint and Prettier configuration? (y/n, default: n): "); err != nil {
+			return err
+		}
+	}
+	if withLint {
+		if err := setupLint(projectDir); err != nil {
+			return err
+		}
+	}
+
+	if err := SaveConfig(projectDir, config); err != nil {
+		logWarn("Failed to save configuration: %v", err)
+	} else {
+		logInfo("Configuration saved to %s", filepath.Base(projectConfigFile(projectDir)))
+		recordGeneratedFile(filepath.Join(projectDir, ".netsuite-cli"), nil, false)
+	}
+	if err := commitGeneration(projectDir); err != nil {
+		logWarn("Failed to write %s: %v", lockFileName, err)
+	}
+	steps.end(stepDone)
+
+	if skipInstallFlag {
+		steps.skip("Install dependencies", "--skip-install")
+	} else {
+		steps.begin("Install dependencies")
+		status, err := installProjectDependencies(reader, projectDir)
+		if err != nil {
+			return err
+		}
+		steps.end(status)
+	}
+
+	if skipSetupFlag {
+		steps.skip("Set up account", "--skip-setup")
+	} else {
+		steps.begin("Set up account")
+		setupCmd := suiteCloudExec(suiteCloudCmd, "account:setup")
 		setupCmd.Dir = projectDir
 		setupCmd.Stdout = os.Stdout
 		setupCmd.Stderr = os.Stderr
-		setupCmd.Stdin = os.Stdin
+		attachTerminal(setupCmd)
 
+		logCommand(setupCmd)
 		if err := setupCmd.Run(); err != nil {
-			fmt.Printf("Warning: Account setup encountered an error: %v\n", err)
-			fmt.Printf("You can run 'suitecloud account:setup' manually in the project directory.\n")
+			logWarn("Account setup encountered an error: %v", err)
+			logInfo("You can run 'suitecloud account:setup' manually in the project directory.")
+			steps.end(stepFailed)
 		} else {
-			fmt.Println("Account setup completed successfully.")
+			steps.end(stepDone)
 		}
-	} else {
-		fmt.Println("Skipping account setup (--skip-setup flag used).")
 	}
 
-	config := &ProjectConfig{
-		ProjectName: projectName,
-		CompanyName: companyName,
-		UserName:    userName,
-		UserEmail:   userEmail,
-	}
-	if err := SaveConfig(projectDir, config); err != nil {
-		fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+	if skipGitFlag {
+		steps.skip("Initialize git repository", "--skip-git")
 	} else {
-		fmt.Println("Configuration saved to .netsuite-cli file")
+		steps.begin("Initialize git repository")
+		status, err := initGitRepository(reader, projectDir, userName, userEmail)
+		if err != nil {
+			return err
+		}
+		steps.end(status)
 	}
 
-	userConfigToSave := &UserConfig{
-		CompanyName: companyName,
-		UserName:    userName,
-		UserEmail:   userEmail,
+	userConfigToSave := &UserConfig{}
+	if userConfig != nil {
+		*userConfigToSave = *userConfig
 	}
+	userConfigToSave.CompanyName = companyName
+	userConfigToSave.UserName = userName
+	userConfigToSave.UserEmail = userEmail
 	if err := SaveUserConfig(userConfigToSave); err != nil {
-		fmt.Printf("Warning: Failed to save user configuration: %v\n", err)
+		logWarn("Failed to save user configuration: %v", err)
 	} else {
-		fmt.Println("User configuration saved to .netsuite-cli file")
+		logInfo("User configuration saved to .netsuite-cli file")
+	}
+
+	steps.printSummary()
+	logInfo("\n✓ Initialization complete!")
+	logInfo("Project created at: %s", projectDir)
+	logInfo("To get started, run: cd %s", projectDir)
+	return nil
+}
+
+// installProjectDependencies installs the dependencies listed in the generated package.json,
+// including the SuiteScript typings and TypeScript, so the project compiles right away. It
+// returns whether the installation was done, failed or skipped.
+func installProjectDependencies(reader *bufio.Reader, projectDir string) (stepStatus, error) {
+	packageManager, err := detectPackageManager(packageMgrFlag)
+	if err != nil {
+		logWarn("Skipping dependency installation: %v", err)
+		return stepSkipped, nil
+	}
+	if packageMgrFlag == "" {
+		install, err := promptYesDefault(reader, fmt.Sprintf("Install dependencies with %s now? (Y/n): ", packageManager))
+		if err != nil {
+			return stepFailed, err
+		}
+		if !install {
+			logInfo("Skipping dependency installation. Run '%s install' in the project directory later.", packageManager)
+			return stepSkipped, nil
+		}
+	}
+
+	installCmd := externalCommand(packageManager, "install")
+	installCmd.Dir = projectDir
+	if err := runWithSpinner(installCmd, fmt.Sprintf("Running %s install", packageManager)); err != nil {
+		logWarn("Dependency installation failed: %v", err)
+		logInfo("You can run '%s install' manually in the project directory.", packageManager)
+		return stepFailed, nil
+	}
+	logInfo("Dependencies installed with %s.", packageManager)
+	return stepDone, nil
+}
+
+// initGitRepository initializes a git repository in projectDir, commits the generated scaffold
+// and optionally adds a remote. Projects created inside an existing repository are left alone.
+// It returns whether the repository was initialized, failed to or was skipped.
+func initGitRepository(reader *bufio.Reader, projectDir, userName, userEmail string) (stepStatus, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		logWarn("git not found in PATH, skipping repository initialization.")
+		return stepSkipped, nil
+	}
+	if out, err := externalCommand("git", "-C", projectDir, "rev-parse", "--is-inside-work-tree").Output(); err == nil && strings.TrimSpace(string(out)) == "true" {
+		logInfo("Project is inside an existing git repository, skipping repository initialization.")
+		return stepSkipped, nil
+	}
+	if !gitFlag && gitRemoteFlag == "" {
+		initialize, err := promptYesDefault(reader, "Initialize a git repository with an initial commit? (Y/n): ")
+		if err != nil {
+			return stepFailed, err
+		}
+		if !initialize {
+			return stepSkipped, nil
+		}
+	}
+
+	if err := runGit(projectDir, "init"); err != nil {
+		logWarn("git init failed: %v", err)
+		return stepFailed, nil
+	}
+	if err := runGit(projectDir, "add", "-A"); err != nil {
+		logWarn("git add failed: %v", err)
+		return stepFailed, nil
+	}
+
+	// Fall back to the project author when git has no identity configured, so the
+	// initial commit does not fail on a fresh machine.
+	commitArgs := []string{}
+	if out, _ := externalCommand("git", "-C", projectDir, "config", "user.name").Output(); strings.TrimSpace(string(out)) == "" && userName != "" {
+		commitArgs = append(commitArgs, "-c", "user.name="+userName)
+	}
+	if out, _ := externalCommand("git", "-C", projectDir, "config", "user.email").Output(); strings.TrimSpace(string(out)) == "" && userEmail != "" {
+		commitArgs = append(commitArgs, "-c", "user.email="+userEmail)
+	}
+	commitArgs = append(commitArgs, "commit", "--quiet", "-m", "Initial commit")
+	if err := runGit(projectDir, commitArgs...); err != nil {
+		logWarn("Initial commit failed: %v", err)
+		logInfo("You can commit the project manually with 'git commit'.")
+		return stepFailed, nil
+	}
+	logInfo("Initialized git repository with an initial commit.")
+
+	remote := strings.TrimSpace(gitRemoteFlag)
+	if remote == "" && !gitFlag {
+		var err error
+		if remote, err = promptLine(reader, "Git remote URL (leave empty to skip): "); err != nil {
+			return stepDone, err
+		}
+	}
+	if remote == "" {
+		return stepDone, nil
 	}
+	if err := runGit(projectDir, "remote", "add", "origin", remote); err != nil {
+		logWarn("Failed to add remote: %v", err)
+		return stepDone, nil
+	}
+	logInfo("Added remote origin: %s", remote)
+	return stepDone, nil
+}
 
-	fmt.Printf("\n✓ Initialization complete!\n")
-	fmt.Printf("Project created at: %s\n", projectDir)
-	fmt.Printf("To get started, run: cd %s\n", projectDir)
+// runGit runs a git command in dir, including its output in the returned error.
+func runGit(dir string, args ...string) error {
+	gitCmd := externalCommand("git", append([]string{"-C", dir}, args...)...)
+	logCommand(gitCmd)
+	out, err := gitCmd.CombinedOutput()
+	if err != nil && len(bytes.TrimSpace(out)) > 0 {
+		return fmt.Errorf("%v: %s", err, bytes.TrimSpace(out))
+	}
+	return err
 }
 
 // createFile creates a file with the specified content.
-func createFile(path, content string) {
+func createFile(path, content string) error {
 	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
-		fmt.Printf("Error creating %s: %v\n", path, err)
-		os.Exit(1)
+		return fmt.Errorf("error creating %s: %v", path, err)
 	}
+	return nil
 }
 
 // createFileFromTemplate creates a file by executing a template with the provided data.
-func createFileFromTemplate(path, templatePath string, data map[string]string) {
-	tmplContent, err := initTemplateFS.ReadFile(templatePath)
+func createFileFromTemplate(path, templatePath string, data map[string]string) error {
+	content, err := renderInitTemplate(templatePath, data)
+	if err != nil {
+		return err
+	}
+	recordGeneratedFile(path, nil, false)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("error creating %s: %v", path, err)
+	}
+	return nil
+}
+
+// renderInitTemplate executes an embedded project template with the provided data.
+func renderInitTemplate(templatePath string, data map[string]string) ([]byte, error) {
+	tmplContent, err := scaffold.Embedded(templatePath)
 	if err != nil {
-		fmt.Printf("Error reading template %s: %v\n", templatePath, err)
-		os.Exit(1)
+		return nil, &TemplateError{Template: templatePath, Err: err}
 	}
 
-	tmpl, err := template.New("config").Parse(string(tmplContent))
+	tmpl, err := template.New("config").Funcs(scaffold.Funcs(DateFormat{})).Parse(string(tmplContent))
 	if err != nil {
-		fmt.Printf("Error parsing template %s: %v\n", templatePath, err)
-		os.Exit(1)
+		return nil, &TemplateError{Template: templatePath, Err: err}
 	}
 
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, data); err != nil {
-		fmt.Printf("Error executing template %s: %v\n", templatePath, err)
-		os.Exit(1)
+		return nil, &TemplateError{Template: templatePath, Err: err}
 	}
 
-	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
-		fmt.Printf("Error creating %s: %v\n", path, err)
-		os.Exit(1)
+	return buf.Bytes(), nil
+}
+
+// printCreateDryRun prints the commands create would run and the directories and files it would write.
+func printCreateDryRun(suiteCloudCmd, projectType, projectDir string, config *ProjectConfig, templateData map[string]string) error {
+	fmt.Printf("Would run: %s project:create --type %s --projectname %s\n", suiteCloudCmd, projectType, config.ProjectName)
+	reportDryRunDir(projectDir)
+	reportDryRunDir(filepath.Join(projectDir, "src", "FileCabinet", "SuiteScripts", config.ProjectName))
+	reportDryRunDir(filepath.Join(projectDir, "src", "Objects", config.ProjectName))
+
+	configFiles := projectConfigFilesFor(templateData["Language"])
+	names := make([]string, 0, len(configFiles))
+	for name := range configFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		content, err := renderInitTemplate(configFiles[name], templateData)
+		if err != nil {
+			return err
+		}
+		reportDryRunFile(filepath.Join(projectDir, name), len(content))
+	}
+
+	if len(config.Aliases) > 0 {
+		fmt.Printf("Would create %s with the paths of %s\n", amdConfigFile(projectDir, config.ProjectName), strings.Join(sortedAliases(config.Aliases), ", "))
+	}
+
+	if lintFlag {
+		for _, name := range []string{".eslintrc.json", ".prettierrc"} {
+			content, err := renderInitTemplate(""+strings.TrimPrefix(name, ".")+".tmpl", nil)
+			if err != nil {
+				return err
+			}
+			reportDryRunFile(filepath.Join(projectDir, name), len(content))
+		}
+	}
+	if !skipInstallFlag {
+		if packageManager, err := detectPackageManager(packageMgrFlag); err == nil {
+			fmt.Printf("Would run: %s install\n", packageManager)
+		}
+	}
+	if !skipSetupFlag {
+		fmt.Printf("Would run: %s account:setup\n", suiteCloudCmd)
+	}
+	if !skipGitFlag {
+		fmt.Println("Would run: git init")
+		fmt.Println("Would run: git commit -m \"Initial commit\"")
+		if gitRemoteFlag != "" {
+			fmt.Printf("Would run: git remote add origin %s\n", gitRemoteFlag)
+		}
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling config: %v", err)
 	}
+	reportDryRunFile(filepath.Join(projectDir, ".netsuite-cli"), len(data))
+	fmt.Printf("Would update the user configuration in your home directory\n")
+	fmt.Printf("Project prefix: %s\n", config.Prefix())
+	return nil
 }
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"netsuite-cli/pkg/config"
+)
+
+// libMarkerFile marks a SuiteScripts folder as holding the copies of the shared library, which
+// the sync may overwrite and delete.
+const libMarkerFile = ".netsuite-cli-lib"
+
+// sharedLibrary is the shared library of the workspace holding the current project.
+type sharedLibrary struct {
+	Dir    string // library directory, relative to the project root
+	Folder string // folder under SuiteScripts holding the copies, using '/' separators
+}
+
+// findSharedLib returns the shared library of the workspace holding the project in the current
+// directory, or nil when the project is not listed in a workspace with a library.
+func findSharedLib() (*sharedLibrary, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("error getting current directory: %v", err)
+	}
+	root, err := config.FindWorkspace(cwd)
+	if errors.Is(err, config.ErrNoWorkspace) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	workspace, err := config.LoadWorkspace(root)
+	if err != nil {
+		return nil, configError(err)
+	}
+	if workspace.Lib == nil {
+		return nil, nil
+	}
+	rel, err := filepath.Rel(root, cwd)
+	if err != nil || workspace.Project(rel) == nil {
+		return nil, nil
+	}
+
+	dir, err := filepath.Rel(cwd, filepath.Join(root, workspace.Lib.Path))
+	if err != nil {
+		return nil, err
+	}
+	return &sharedLibrary{Dir: dir, Folder: workspace.Lib.Folder}, nil
+}
+
+// syncSharedLib copies the shared library of the workspace into the SuiteScripts folder of the
+// project, so it is compiled and deployed with the project scripts. Copies of files removed
+// from the library are deleted. Tests are not copied.
+func syncSharedLib() error {
+	lib, err := findSharedLib()
+	if err != nil || lib == nil {
+		return err
+	}
+	if info, err := os.Stat(lib.Dir); err != nil || !info.IsDir() {
+		return configError(fmt.Errorf("shared library %s not found", lib.Dir))
+	}
+
+	suiteScriptsDir, err := findSuiteScriptsDir()
+	if err != nil {
+		return err
+	}
+	target := filepath.Join(suiteScriptsDir, filepath.FromSlash(lib.Folder))
+	if entries, err := os.ReadDir(target); err == nil && len(entries) > 0 {
+		if _, err := os.Stat(filepath.Join(target, libMarkerFile)); err != nil {
+			return configError(fmt.Errorf("%s already exists and does not hold a copy of the shared library, remove it or set another lib folder", target))
+		}
+	}
+
+	var synced []string
+	updated := 0
+	err = filepath.WalkDir(lib.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != lib.Dir && (d.Name() == "node_modules" || d.Name() == testsDir || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") || strings.HasSuffix(d.Name(), ".test.ts") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(lib.Dir, path)
+		if err != nil {
+			return err
+		}
+		synced = append(synced, rel)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(target, rel)
+		if existing, err := os.ReadFile(dest); err == nil && bytes.Equal(existing, content) {
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		updated++
+		return os.WriteFile(dest, content, 0644)
+	})
+	if err != nil {
+		return fmt.Errorf("error syncing shared library: %v", err)
+	}
+
+	removed := 0
+	err = filepath.WalkDir(target, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || d.Name() == libMarkerFile {
+			return err
+		}
+		rel, err := filepath.Rel(target, path)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(synced, rel) {
+			removed++
+			return os.Remove(path)
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error syncing shared library: %v", err)
+	}
+
+	if err := os.MkdirAll(target, 0755); err != nil {
+		return fmt.Errorf("error syncing shared library: %v", err)
+	}
+	marker := "Copied from " + filepath.ToSlash(lib.Dir) + " by netsuite-cli, do not edit. Changes are overwritten by the next build.\n"
+	if err := os.WriteFile(filepath.Join(target, libMarkerFile), []byte(marker), 0644); err != nil {
+		return fmt.Errorf("error syncing shared library: %v", err)
+	}
+
+	if updated > 0 || removed > 0 {
+		logInfo("Synced shared library %s into %s (%d updated, %d removed)", filepath.ToSlash(lib.Dir), target, updated, removed)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"text/template"
+)
+
+// HookData holds the data available to the lifecycle hook commands of the project configuration.
+type HookData struct {
+	Project string    // project name
+	Command string    // command running the hook, e.g. add suitelet
+	Env     string    // environment of deploy hooks
+	Files   hookFiles // files written by add, for postAdd
+}
+
+// hookFiles renders as a space separated list of shell quoted paths, so {{.Files}} can be
+// appended to a command line. {{range .Files}} iterates over the paths.
+type hookFiles []string
+
+func (f hookFiles) String() string {
+	quoted := make([]string, len(f))
+	for i, path := range f {
+		quoted[i] = shellQuote(path)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// shellQuote quotes s for the shell running the hooks, if it holds characters the shell would
+// interpret.
+func shellQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\n'\"\\$`!*?[](){}<>|&;#~%^") {
+		return s
+	}
+	if runtime.GOOS == "windows" {
+		return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// runLifecycleHook runs the named hook of the project configuration from the project root, if it
+// is set. The hook is a text/template rendered with data and run by the shell. A failing hook
+// is returned as a ToolError; for pre hooks this stops the command before it changes anything.
+func runLifecycleHook(config *ProjectConfig, name string, data HookData) error {
+	hook := strings.TrimSpace(config.Hooks[name])
+	if hook == "" {
+		return nil
+	}
+	data.Project = config.ProjectName
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(hook)
+	if err != nil {
+		return configError(fmt.Errorf("invalid %s hook: %v", name, err))
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return configError(fmt.Errorf("invalid %s hook: %v", name, err))
+	}
+	command := buf.String()
+
+	if dryRunFlag {
+		fmt.Printf("Would run %s hook: %s\n", name, command)
+		return nil
+	}
+	logInfo("Running %s hook: %s", name, command)
+
+	var hookCmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		hookCmd = externalCommand("cmd", "/C", command)
+	} else {
+		hookCmd = externalCommand("sh", "-c", command)
+	}
+	attachTerminal(hookCmd)
+	hookCmd.Stdout = os.Stdout
+	hookCmd.Stderr = os.Stderr
+	logCommand(hookCmd)
+	if err := hookCmd.Run(); err != nil {
+		return toolError(name+" hook", fmt.Errorf("%s hook failed: %v", name, err))
+	}
+	return nil
+}
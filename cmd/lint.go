@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// lintCmd represents the lint command
+var lintCmd = &cobra.Command{
+	Use:   "lint [files...]",
+	Short: "Check the SDF object XML files for common mistakes",
+	Long: `Check the object XML files in the Objects folder, or only the given files, before a slow
+deploy cycle. The command reports XML that is not well formed, objects missing their scriptid
+or required elements, file references that do not exist in the FileCabinet folder and
+invalid script deployment status values. The
+manifest.xml and deploy.xml files are checked for well-formedness. The command exits with a
+non-zero status when errors are found.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runLint(args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+}
+
+// LintIssue is a problem found in an object XML file.
+type LintIssue struct {
+	File     string `json:"file"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// lintElement is a generic XML element of an object file.
+type lintElement struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr    `xml:",any,attr"`
+	Text     string        `xml:",chardata"`
+	Children []lintElement `xml:",any"`
+}
+
+// attr returns the value of the named attribute of the element.
+func (e *lintElement) attr(name string) string {
+	for _, attr := range e.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// child returns the first child element with the given name.
+func (e *lintElement) child(name string) *lintElement {
+	for i := range e.Children {
+		if e.Children[i].XMLName.Local == name {
+			return &e.Children[i]
+		}
+	}
+	return nil
+}
+
+// scriptObjectTypes lists the object types of scripts, which require a scriptfile.
+var scriptObjectTypes = []string{
+	"bundleinstallationscript",
+	"clientscript",
+	"mapreducescript",
+	"massupdatescript",
+	"portlet",
+	"restlet",
+	"scheduledscript",
+	"sdfinstallationscript",
+	"suitelet",
+	"usereventscript",
+	"workflowactionscript",
+}
+
+// lintRequiredElements lists the child elements required by object types other than scripts.
+var lintRequiredElements = map[string][]string{
+	"customrecordtype":             {"recordname"},
+	"savedsearch":                  {"definition"},
+	"workflow":                     {"name", "recordtypes"},
+	"entitycustomfield":            {"label", "fieldtype"},
+	"crmcustomfield":               {"label", "fieldtype"},
+	"itemcustomfield":              {"label", "fieldtype"},
+	"othercustomfield":             {"label", "fieldtype", "rectype"},
+	"transactionbodycustomfield":   {"label", "fieldtype"},
+	"transactioncolumncustomfield": {"label", "fieldtype"},
+}
+
+// deploymentStatuses lists the valid script deployment status values. Scheduled and map/reduce
+// scripts are scheduled rather than released.
+var (
+	deploymentStatuses          = []string{"RELEASED", "TESTING"}
+	scheduledDeploymentStatuses = []string{"NOTSCHEDULED", "SCHEDULED", "TESTING"}
+)
+
+// fileReferenceRe matches a FileCabinet file reference such as [SuiteScripts/x.ts] or [/SuiteScripts/x.js].
+var fileReferenceRe = regexp.MustCompile(`^\[((?:/|~/FileCabinet/)?SuiteScripts/[^\]]+)\]$`)
+
+// runLint checks the object XML files and prints the issues found.
+func runLint(files []string) error {
+	if _, err := loadProjectConfig(); err != nil {
+		return err
+	}
+
+	if len(files) > 0 {
+		for i, file := range files {
+			files[i] = projectRelativePath(file)
+		}
+	}
+	issues, err := lintProject(files)
+	if err != nil {
+		return err
+	}
+	if jsonFlag {
+		setJSONResult(append([]LintIssue{}, issues...))
+	}
+
+	errors := 0
+	for _, issue := range issues {
+		if issue.Severity == "error" {
+			errors++
+		}
+		if !jsonFlag {
+			fmt.Printf("%s: %s: %s\n", issue.File, issue.Severity, issue.Message)
+		}
+	}
+	if errors > 0 {
+		fmt.Println()
+		return validationError("%d error(s), %d warning(s)", errors, len(issues)-errors)
+	}
+	logInfo("✓ No errors found (%d warning(s))", len(issues))
+	return nil
+}
+
+// lintProject checks the given object files, or all object files of the project together with
+// manifest.xml and deploy.xml when files is empty.
+func lintProject(files []string) ([]LintIssue, error) {
+	var issues []LintIssue
+	if len(files) == 0 {
+		if path, ok := findManifestXML(); ok {
+			issues = append(issues, lintWellFormed(path)...)
+		}
+		if path, ok := findDeployXML(); ok {
+			issues = append(issues, lintWellFormed(path)...)
+		}
+
+		objectsDir, err := findObjectsDir()
+		if err != nil {
+			return nil, err
+		}
+		filepath.WalkDir(objectsDir, func(path string, d os.DirEntry, err error) error {
+			if err == nil && !d.IsDir() && strings.EqualFold(filepath.Ext(path), ".xml") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		sort.Strings(files)
+	}
+
+	fileCabinetDir := filepath.Join("src", "FileCabinet")
+	if _, err := os.Stat(fileCabinetDir); err != nil {
+		fileCabinetDir = "FileCabinet"
+	}
+	for _, file := range files {
+		issues = append(issues, lintObjectFile(file, fileCabinetDir)...)
+	}
+	return issues, nil
+}
+
+// lintWellFormed reports an error if the XML file at path is not well formed.
+func lintWellFormed(path string) []LintIssue {
+	if err := checkXMLWellFormed(path); err != nil {
+		return []LintIssue{{File: path, Severity: "error", Message: err.Error()}}
+	}
+	return nil
+}
+
+// lintObjectFile checks a single object XML file.
+func lintObjectFile(path, fileCabinetDir string) []LintIssue {
+	if issues := lintWellFormed(path); issues != nil {
+		return issues
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []LintIssue{{File: path, Severity: "error", Message: err.Error()}}
+	}
+	var root lintElement
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return []LintIssue{{File: path, Severity: "error", Message: err.Error()}}
+	}
+
+	var issues []LintIssue
+	report := func(severity, format string, args ...interface{}) {
+		issues = append(issues, LintIssue{File: path, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	objectType := root.XMLName.Local
+	scriptID := root.attr("scriptid")
+	if scriptID == "" {
+		report("error", "<%s> is missing the scriptid attribute", objectType)
+	}
+
+	required := lintRequiredElements[objectType]
+	isScript := containsString(scriptObjectTypes, objectType)
+	if isScript {
+		required = []string{"name", "scriptfile"}
+	}
+	for _, name := range required {
+		if child := root.child(name); child == nil || strings.TrimSpace(child.Text) == "" {
+			report("error", "<%s> is missing the required <%s> element", objectType, name)
+		}
+	}
+
+	if isScript {
+		statuses := deploymentStatuses
+		if objectType == "scheduledscript" || objectType == "mapreducescript" {
+			statuses = scheduledDeploymentStatuses
+		}
+		if deployments := root.child("scriptdeployments"); deployments != nil {
+			for _, deployment := range deployments.Children {
+				if deployment.XMLName.Local != "scriptdeployment" {
+					continue
+				}
+				id := deployment.attr("scriptid")
+				if id == "" {
+					report("error", "<scriptdeployment> is missing the scriptid attribute")
+					id = "scriptdeployment"
+				}
+				status := deployment.child("status")
+				if status == nil {
+					report("warning", "%s has no <status>", id)
+				} else if value := strings.TrimSpace(status.Text); !containsString(statuses, value) {
+					report("error", "%s has invalid status '%s' (valid for %s: %s)", id, value, objectType, strings.Join(statuses, ", "))
+				}
+			}
+		} else {
+			report("warning", "%s has no script deployments", scriptID)
+		}
+	}
+
+	lintFileReferences(&root, fileCabinetDir, report)
+	return issues
+}
+
+// lintFileReferences reports the FileCabinet file references of element and its children that
+// point to files that do not exist.
+func lintFileReferences(element *lintElement, fileCabinetDir string, report func(severity, format string, args ...interface{})) {
+	if match := fileReferenceRe.FindStringSubmatch(strings.TrimSpace(element.Text)); match != nil {
+		reference := strings.TrimPrefix(strings.TrimPrefix(match[1], "~/FileCabinet"), "/")
+		path := filepath.Join(fileCabinetDir, filepath.FromSlash(reference))
+		switch {
+		case fileExists(path):
+		case strings.HasSuffix(path, ".js") && fileExists(strings.TrimSuffix(path, ".js")+".ts"):
+			report("warning", "<%s> references %s, which has not been compiled yet (run 'netsuite-cli build')", element.XMLName.Local, match[1])
+		default:
+			report("error", "<%s> references %s, which does not exist in %s", element.XMLName.Local, match[1], fileCabinetDir)
+		}
+	}
+	for i := range element.Children {
+		lintFileReferences(&element.Children[i], fileCabinetDir, report)
+	}
+}
+
+// checkXMLWellFormed reports the first syntax error of the XML file at path.
+func checkXMLWellFormed(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	decoder := xml.NewDecoder(f)
+	for {
+		if _, err := decoder.Token(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// lockFileName is the manifest recording the files generated by add and create.
+const lockFileName = ".netsuite-cli.lock"
+
+// maxGenerations is the number of generations kept in the manifest. Older ones are dropped with
+// the previous file contents they hold, so the manifest does not grow with every add.
+const maxGenerations = 20
+
+// GeneratedFile is a file written by a generation. Previous holds the former content of a file
+// that was overwritten; it is nil for files that did not exist before. SHA256 is the checksum of
+// the content written, so undo can tell whether the file was edited since.
+type GeneratedFile struct {
+	Path     string  `json:"path"`
+	Previous *string `json:"previous,omitempty"`
+	SHA256   string  `json:"sha256,omitempty"`
+}
+
+// Generation records the files and directories written by a single add or create run.
+type Generation struct {
+	Command     string          `json:"command"`
+	Time        time.Time       `json:"time"`
+	Files       []GeneratedFile `json:"files"`
+	Directories []string        `json:"directories,omitempty"`
+}
+
+// LockFile is the generation manifest stored in the project root.
+type LockFile struct {
+	Generations []Generation `json:"generations"`
+}
+
+// currentGeneration collects the files written by the running command, if it records a generation.
+var currentGeneration *Generation
+
+// beginGeneration starts recording the files and directories written by a command.
+func beginGeneration(command string) {
+	currentGeneration = &Generation{Command: command, Time: time.Now()}
+}
+
+// recordGeneratedFile adds a file about to be written to the current generation. previous is the
+// content of the file if it already exists.
+func recordGeneratedFile(path string, previous []byte, existed bool) {
+	if currentGeneration == nil {
+		return
+	}
+	for _, file := range currentGeneration.Files {
+		if file.Path == path {
+			return
+		}
+	}
+	file := GeneratedFile{Path: path}
+	if existed {
+		content := string(previous)
+		file.Previous = &content
+	}
+	currentGeneration.Files = append(currentGeneration.Files, file)
+}
+
+// recordCreatedDirs adds the directories of path that do not exist yet to the current generation,
+// outermost first.
+func recordCreatedDirs(path string) {
+	if currentGeneration == nil {
+		return
+	}
+	var missing []string
+	for dir := filepath.Clean(path); ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			break
+		}
+		missing = append([]string{dir}, missing...)
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+	currentGeneration.Directories = append(currentGeneration.Directories, missing...)
+}
+
+// commitGeneration appends the current generation to the lock file in root, dropping the oldest
+// ones past maxGenerations. Absolute paths are stored relative to root. Nothing is written if the
+// generation is empty.
+func commitGeneration(root string) error {
+	generation := currentGeneration
+	currentGeneration = nil
+	if generation == nil || (len(generation.Files) == 0 && len(generation.Directories) == 0) {
+		return nil
+	}
+
+	relative := func(path string) string {
+		if filepath.IsAbs(path) {
+			if rel, err := filepath.Rel(root, path); err == nil {
+				return filepath.ToSlash(rel)
+			}
+		}
+		return filepath.ToSlash(path)
+	}
+	for i := range generation.Files {
+		generation.Files[i].SHA256, _ = fileChecksum(generation.Files[i].Path)
+		generation.Files[i].Path = relative(generation.Files[i].Path)
+	}
+	for i := range generation.Directories {
+		generation.Directories[i] = relative(generation.Directories[i])
+	}
+
+	lock, err := LoadLockFile(root)
+	if err != nil {
+		return err
+	}
+	lock.Generations = append(lock.Generations, *generation)
+	if excess := len(lock.Generations) - maxGenerations; excess > 0 {
+		lock.Generations = lock.Generations[excess:]
+	}
+	return SaveLockFile(root, lock)
+}
+
+// fileChecksum returns the hex SHA-256 checksum of the content of the file at path.
+func fileChecksum(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// modifiedSince reports whether the file was changed after the generation wrote it. Files
+// recorded without a checksum, by earlier releases, and files that no longer exist are not
+// considered modified.
+func (f GeneratedFile) modifiedSince() bool {
+	if f.SHA256 == "" {
+		return false
+	}
+	sum, err := fileChecksum(filepath.FromSlash(f.Path))
+	return err == nil && sum != f.SHA256
+}
+
+// LoadLockFile reads the generation manifest in root. A missing manifest yields an empty one.
+func LoadLockFile(root string) (*LockFile, error) {
+	data, err := os.ReadFile(filepath.Join(root, lockFileName))
+	if os.IsNotExist(err) {
+		return &LockFile{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", lockFileName, err)
+	}
+
+	var lock LockFile
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", lockFileName, err)
+	}
+	return &lock, nil
+}
+
+// SaveLockFile writes the generation manifest to root.
+func SaveLockFile(root string, lock *LockFile) error {
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling %s: %v", lockFileName, err)
+	}
+	if err := os.WriteFile(filepath.Join(root, lockFileName), data, 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", lockFileName, err)
+	}
+	return nil
+}
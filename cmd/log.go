@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"netsuite-cli/internal/logfile"
+)
+
+// logLevel orders the messages of the CLI by importance.
+type logLevel int
+
+const (
+	// levelDebug messages explain what a command does, e.g. the external commands it runs, the
+	// templates it reads and why files are written or skipped. Shown with --verbose.
+	levelDebug logLevel = iota
+	// levelInfo messages report progress and the files changed. Hidden with --quiet.
+	levelInfo
+	// levelWarn messages report problems a command recovered from. Hidden with --quiet.
+	levelWarn
+	// levelError messages report failures. Always shown.
+	levelError
+)
+
+// String returns the name of the level as written to the log file.
+func (level logLevel) String() string {
+	switch level {
+	case levelDebug:
+		return "DEBUG"
+	case levelInfo:
+		return "INFO"
+	case levelWarn:
+		return "WARN"
+	}
+	return "ERROR"
+}
+
+// logFile is the log file of the invocation, nil when it could not be opened.
+var logFile *logfile.Log
+
+// logStarted is when the invocation started.
+var logStarted time.Time
+
+// logDir returns the directory the log files are kept in.
+func logDir() (string, error) {
+	userDir, err := UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(userDir, "logs"), nil
+}
+
+// openLogFile opens the log file and records the command line of the invocation. Every message
+// is written to it whatever --verbose and --quiet select, so failures can be diagnosed after
+// the fact. The log is skipped silently when it cannot be opened.
+func openLogFile(args []string) {
+	logStarted = time.Now()
+	dir, err := logDir()
+	if err != nil {
+		return
+	}
+	logFile, err = logfile.Open(dir)
+	if err != nil {
+		return
+	}
+	wd, _ := os.Getwd()
+	logFile.Printf("START netsuite-cli %s (in %s)", strings.Join(args, " "), wd)
+}
+
+// closeLogFile records the exit code of the invocation and closes the log file.
+func closeLogFile(code int) {
+	logFile.Printf("EXIT %d after %s", code, time.Since(logStarted).Round(time.Millisecond))
+	logFile.Close()
+}
+
+// writeLog writes a message to the log file.
+func writeLog(level logLevel, format string, args ...any) {
+	if logFile != nil {
+		logFile.Printf(level.String()+" "+format, args...)
+	}
+}
+
+// logThreshold returns the lowest level printed: debug with --verbose, error with --quiet and
+// info otherwise.
+func logThreshold() logLevel {
+	switch {
+	case quietFlag:
+		return levelError
+	case verboseFlag:
+		return levelDebug
+	}
+	return levelInfo
+}
+
+// logEnabled reports whether messages of level are printed.
+func logEnabled(level logLevel) bool {
+	return level >= logThreshold()
+}
+
+// logDebug prints a debug message to stderr, so it never mixes with the output of a command.
+func logDebug(format string, args ...any) {
+	writeLog(levelDebug, format, args...)
+	if logEnabled(levelDebug) {
+		fmt.Fprintln(os.Stderr, colorize(os.Stderr, colorGray, "debug: "+fmt.Sprintf(format, args...)))
+	}
+}
+
+// logInfo prints a progress message to stdout.
+func logInfo(format string, args ...any) {
+	writeLog(levelInfo, format, args...)
+	if logEnabled(levelInfo) {
+		message := fmt.Sprintf(format, args...)
+		fmt.Println(colorize(os.Stdout, infoColor(message), message))
+	}
+}
+
+// logWarn prints a warning to stdout, or adds it to the warnings of the document with --json.
+func logWarn(format string, args ...any) {
+	writeLog(levelWarn, format, args...)
+	if !logEnabled(levelWarn) {
+		return
+	}
+	if jsonFlag {
+		addJSONWarning(fmt.Sprintf(format, args...))
+		return
+	}
+	fmt.Println(colorize(os.Stdout, colorYellow, "Warning: "+fmt.Sprintf(format, args...)))
+}
+
+// logError prints an error a command carries on after to stderr, or adds it to the errors of
+// the document with --json.
+func logError(format string, args ...any) {
+	writeLog(levelError, format, args...)
+	if jsonFlag {
+		addJSONError(fmt.Sprintf(format, args...))
+		return
+	}
+	fmt.Fprintln(os.Stderr, colorize(os.Stderr, colorRed, "Error: "+fmt.Sprintf(format, args...)))
+}
+
+// logCommand prints the command line of an external command about to run, and the directory it
+// runs in when it is not the current one.
+func logCommand(cmd *exec.Cmd) {
+	if cmd.Dir != "" && cmd.Dir != "." {
+		logDebug("running %s (in %s)", strings.Join(cmd.Args, " "), cmd.Dir)
+		return
+	}
+	logDebug("running %s", strings.Join(cmd.Args, " "))
+}
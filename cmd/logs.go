@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"netsuite-cli/internal/logfile"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	logsScriptFlag   string
+	logsFollowFlag   bool
+	logsLinesFlag    int
+	logsLevelFlag    string
+	logsIntervalFlag time.Duration
+	logsAccountFlag  string
+
+	logsShowLinesFlag int
+	logsShowPathFlag  bool
+)
+
+// logsCmd represents the logs command
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Show the execution log of a script",
+	Long: `Show the latest execution log entries of a script by querying the script
+notes with SuiteQL, and optionally keep polling for new entries.
+
+The log of netsuite-cli itself is shown with 'netsuite-cli logs show'.`,
+	Example: `  netsuite-cli logs --script customscript_acm_sync_mapreduce --follow
+  netsuite-cli logs --script customscript_acm_sync_mapreduce --level error --lines 50`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runLogs()
+	},
+}
+
+// logsShowCmd represents the logs show command
+var logsShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the log of netsuite-cli itself",
+	Long: fmt.Sprintf(`Show the latest lines of the log netsuite-cli writes on every invocation: the command
+line, the external commands run, the messages printed at every level and the errors and
+exit code. The log is kept in %s
+and rotated once it grows past 1 MB, keeping the 5 previous files. Attach it when reporting
+a failure.`, userDirHelp("logs")),
+	Example: `  netsuite-cli logs show
+  netsuite-cli logs show --lines 0 > netsuite-cli.log`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runLogsShow()
+	},
+}
+
+func init() {
+	logsShowCmd.Flags().IntVarP(&logsShowLinesFlag, "lines", "n", 100, "Number of recent lines to show, 0 for the whole history")
+	logsShowCmd.Flags().BoolVar(&logsShowPathFlag, "path", false, "Print the directory of the log files instead")
+	logsCmd.AddCommand(logsShowCmd)
+
+	logsCmd.Flags().StringVarP(&logsScriptFlag, "script", "s", "", "Script ID of the script (required)")
+	logsCmd.Flags().BoolVarP(&logsFollowFlag, "follow", "f", false, "Keep polling for new log entries")
+	logsCmd.Flags().IntVarP(&logsLinesFlag, "lines", "n", 20, "Number of recent entries to show")
+	logsCmd.Flags().StringVarP(&logsLevelFlag, "level", "l", "debug", "Minimum log level: debug, audit, error or emergency")
+	logsCmd.Flags().DurationVarP(&logsIntervalFlag, "interval", "i", 5*time.Second, "Polling interval when following")
+	logsCmd.Flags().StringVarP(&logsAccountFlag, "account", "a", "", "Account profile to use (default: the default profile)")
+	logsCmd.MarkFlagRequired("script")
+
+	rootCmd.AddCommand(logsCmd)
+}
+
+// logLevels lists the script log levels in increasing severity, as stored in the script notes.
+var logLevels = []string{"DEBUG", "AUDIT", "ERROR", "EMERGENCY"}
+
+// logLevelColors maps each log level to its ANSI color.
+var logLevelColors = map[string]string{
+	"DEBUG":     colorGray,
+	"AUDIT":     colorCyan,
+	"ERROR":     colorRed,
+	"EMERGENCY": colorBoldRed,
+}
+
+var logsScriptIdRe = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// LogEntry is a single script execution log entry.
+type LogEntry struct {
+	Id     int
+	Date   string
+	Level  string
+	Title  string
+	Detail string
+}
+
+// runLogs prints the script execution log.
+func runLogs() error {
+	if !logsScriptIdRe.MatchString(logsScriptFlag) {
+		return fmt.Errorf("invalid script ID '%s'", logsScriptFlag)
+	}
+
+	minLevel := -1
+	for i, level := range logLevels {
+		if strings.EqualFold(level, logsLevelFlag) {
+			minLevel = i
+		}
+	}
+	if minLevel < 0 {
+		return fmt.Errorf("invalid --level '%s' (supported: debug, audit, error, emergency)", logsLevelFlag)
+	}
+
+	account, err := resolveAccount(logsAccountFlag)
+	if err != nil {
+		return err
+	}
+	client, err := newAuthClient(account)
+	if err != nil {
+		return err
+	}
+
+	levelFilter := "'" + strings.Join(logLevels[minLevel:], "', '") + "'"
+	query := func(afterId int, order string) string {
+		return fmt.Sprintf(`SELECT n.internalid AS id, TO_CHAR(n.date, 'YYYY-MM-DD HH24:MI:SS') AS logdate, n.type AS loglevel, n.title, n.detail
+FROM scriptnote n INNER JOIN script s ON s.id = n.scripttype
+WHERE UPPER(s.scriptid) = UPPER('%s') AND UPPER(n.type) IN (%s) AND n.internalid > %d
+ORDER BY n.internalid %s`, logsScriptFlag, levelFilter, afterId, order)
+	}
+
+	fetch := func(afterId int, order string, limit int) ([]LogEntry, error) {
+		result, err := runSuiteQL(runContext, client, account.AccountID, query(afterId, order), limit)
+		if err != nil {
+			return nil, err
+		}
+		return logEntriesFromRows(result.Rows), nil
+	}
+
+	entries, err := fetch(0, "DESC", logsLinesFlag)
+	if err != nil {
+		return err
+	}
+	lastId := 0
+	for i := len(entries) - 1; i >= 0; i-- {
+		printLogEntry(entries[i])
+		lastId = max(lastId, entries[i].Id)
+	}
+
+	if !logsFollowFlag {
+		if len(entries) == 0 {
+			fmt.Println("No log entries found.")
+		}
+		return nil
+	}
+
+	if logEnabled(levelInfo) {
+		fmt.Fprintf(os.Stderr, "Following %s, press Ctrl+C to stop...\n", logsScriptFlag)
+	}
+	for {
+		if err := sleepContext(logsIntervalFlag); err != nil {
+			return err
+		}
+		entries, err := fetch(lastId, "ASC", 0)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			printLogEntry(entry)
+			lastId = max(lastId, entry.Id)
+		}
+	}
+}
+
+// logEntriesFromRows converts SuiteQL rows into log entries.
+func logEntriesFromRows(rows []map[string]any) []LogEntry {
+	entries := make([]LogEntry, 0, len(rows))
+	for _, row := range rows {
+		values := queryRowValues([]string{"id", "logdate", "loglevel", "title", "detail"}, row)
+		id, _ := strconv.Atoi(values[0])
+		entries = append(entries, LogEntry{
+			Id:     id,
+			Date:   values[1],
+			Level:  strings.ToUpper(values[2]),
+			Title:  values[3],
+			Detail: values[4],
+		})
+	}
+	return entries
+}
+
+// printLogEntry prints a log entry, colored by level when colors are enabled.
+func printLogEntry(entry LogEntry) {
+	level := colorize(os.Stdout, logLevelColors[entry.Level], fmt.Sprintf("%-9s", entry.Level))
+
+	line := fmt.Sprintf("%s %s %s", entry.Date, level, entry.Title)
+	if entry.Detail != "" {
+		line += ": " + entry.Detail
+	}
+	fmt.Println(line)
+}
+
+// runLogsShow prints the latest lines of the log files of netsuite-cli, oldest first.
+func runLogsShow() error {
+	dir, err := logDir()
+	if err != nil {
+		return configError(err)
+	}
+	if logsShowPathFlag {
+		fmt.Println(dir)
+		return nil
+	}
+
+	files, err := logfile.Files(dir)
+	if err != nil {
+		return err
+	}
+	var lines []string
+	for _, file := range files {
+		if logsShowLinesFlag > 0 && len(lines) >= logsShowLinesFlag {
+			break
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("error reading log file: %v", err)
+		}
+		lines = append(strings.Split(strings.TrimRight(string(content), "\n"), "\n"), lines...)
+	}
+	if logsShowLinesFlag > 0 && len(lines) > logsShowLinesFlag {
+		lines = lines[len(lines)-logsShowLinesFlag:]
+	}
+	if len(lines) == 0 {
+		fmt.Println("The log is empty.")
+		return nil
+	}
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	return nil
+}
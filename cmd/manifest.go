@@ -0,0 +1,323 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var manifestOptionalFlag bool
+
+// manifestCmd represents the manifest command
+var manifestCmd = &cobra.Command{
+	Use:   "manifest",
+	Short: "Manage the feature dependencies declared in manifest.xml",
+	Long: `Manage the account features the project depends on, declared in the <dependencies>
+section of manifest.xml. Deploying a project that uses a feature not declared in the manifest
+fails, so add also declares the features required by the scripts and objects it generates.`,
+}
+
+// manifestAddFeatureCmd represents the manifest add-feature command
+var manifestAddFeatureCmd = &cobra.Command{
+	Use:   "add-feature <FEATURE>...",
+	Short: "Declare account features the project depends on",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runManifestAddFeature(args)
+	},
+}
+
+// manifestRemoveFeatureCmd represents the manifest remove-feature command
+var manifestRemoveFeatureCmd = &cobra.Command{
+	Use:   "remove-feature <FEATURE>...",
+	Short: "Remove feature dependencies from manifest.xml",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runManifestRemoveFeature(args)
+	},
+}
+
+// manifestListCmd represents the manifest list command
+var manifestListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the feature dependencies declared in manifest.xml",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runManifestList()
+	},
+}
+
+func init() {
+	manifestAddFeatureCmd.Flags().BoolVar(&manifestOptionalFlag, "optional", false, "Declare the features as optional instead of required")
+
+	manifestCmd.AddCommand(manifestAddFeatureCmd)
+	manifestCmd.AddCommand(manifestRemoveFeatureCmd)
+	manifestCmd.AddCommand(manifestListCmd)
+	rootCmd.AddCommand(manifestCmd)
+}
+
+// ManifestFeature is a feature dependency declared in manifest.xml.
+type ManifestFeature struct {
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+}
+
+var (
+	manifestFeatureRe     = regexp.MustCompile(`<feature(?:\s+required="(true|false)")?\s*>\s*([A-Za-z0-9_]+)\s*</feature>`)
+	manifestFeatureNameRe = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+)
+
+// scriptTypeFeatures lists the account features required by the objects generated by add.
+var scriptTypeFeatures = map[string][]string{
+	"client":          {"CUSTOMCODE"},
+	"formclient":      {"CUSTOMCODE"},
+	"userevent":       {"SERVERSIDESCRIPTING"},
+	"suitelet":        {"SERVERSIDESCRIPTING"},
+	"restlet":         {"SERVERSIDESCRIPTING"},
+	"scheduled":       {"SERVERSIDESCRIPTING"},
+	"mapreduce":       {"SERVERSIDESCRIPTING"},
+	"portlet":         {"SERVERSIDESCRIPTING"},
+	"massupdate":      {"SERVERSIDESCRIPTING"},
+	"workflowaction":  {"SERVERSIDESCRIPTING", "WORKFLOW"},
+	"bundle":          {"SERVERSIDESCRIPTING"},
+	"sdfinstallation": {"SERVERSIDESCRIPTING"},
+	"customrecord":    {"CUSTOMRECORDS"},
+	"workflow":        {"WORKFLOW"},
+}
+
+// requireManifestXML returns the path of the project manifest, or an error if there is none.
+func requireManifestXML() (string, error) {
+	if _, err := loadProjectConfig(); err != nil {
+		return "", err
+	}
+	path, ok := findManifestXML()
+	if !ok {
+		return "", configError(errors.New("manifest.xml not found, expected src/manifest.xml in an SDF project"))
+	}
+	return path, nil
+}
+
+// normalizeFeatureNames upper-cases and validates feature names given on the command line.
+func normalizeFeatureNames(names []string) ([]string, error) {
+	features := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if !manifestFeatureNameRe.MatchString(name) {
+			return nil, fmt.Errorf("invalid feature name '%s'", name)
+		}
+		features = append(features, name)
+	}
+	return features, nil
+}
+
+// runManifestAddFeature declares the given features in manifest.xml.
+func runManifestAddFeature(names []string) error {
+	manifestPath, err := requireManifestXML()
+	if err != nil {
+		return err
+	}
+	features, err := normalizeFeatureNames(names)
+	if err != nil {
+		return err
+	}
+
+	added, err := addManifestFeatures(manifestPath, features, !manifestOptionalFlag, true)
+	if err != nil {
+		return err
+	}
+	for _, feature := range features {
+		if containsString(added, feature) {
+			logInfo("Added %s to %s", feature, manifestPath)
+		} else {
+			logInfo("%s is already declared in %s", feature, manifestPath)
+		}
+	}
+	return nil
+}
+
+// runManifestRemoveFeature removes the given features from manifest.xml.
+func runManifestRemoveFeature(names []string) error {
+	manifestPath, err := requireManifestXML()
+	if err != nil {
+		return err
+	}
+	features, err := normalizeFeatureNames(names)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", manifestPath, err)
+	}
+	lines := strings.Split(string(data), "\n")
+	result := make([]string, 0, len(lines))
+	var removed []string
+	for _, line := range lines {
+		if m := manifestFeatureRe.FindStringSubmatch(line); m != nil && containsString(features, m[2]) {
+			removed = append(removed, m[2])
+			continue
+		}
+		result = append(result, line)
+	}
+	if len(removed) > 0 {
+		if err := os.WriteFile(manifestPath, []byte(strings.Join(result, "\n")), 0644); err != nil {
+			return fmt.Errorf("error writing %s: %v", manifestPath, err)
+		}
+	}
+	for _, feature := range features {
+		if containsString(removed, feature) {
+			logInfo("Removed %s from %s", feature, manifestPath)
+		} else {
+			logInfo("%s is not declared in %s", feature, manifestPath)
+		}
+	}
+	return nil
+}
+
+// runManifestList prints the features declared in manifest.xml.
+func runManifestList() error {
+	manifestPath, err := requireManifestXML()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", manifestPath, err)
+	}
+	features := parseManifestFeatures(string(data))
+	if jsonFlag {
+		setJSONResult(append([]ManifestFeature{}, features...))
+		return nil
+	}
+	if len(features) == 0 {
+		fmt.Printf("No features declared in %s\n", manifestPath)
+		return nil
+	}
+	for _, feature := range features {
+		requirement := "required"
+		if !feature.Required {
+			requirement = "optional"
+		}
+		fmt.Printf("%-30s %s\n", feature.Name, requirement)
+	}
+	return nil
+}
+
+// parseManifestFeatures returns the features declared in the manifest content. Features without
+// a required attribute are required, as in SDF.
+func parseManifestFeatures(content string) []ManifestFeature {
+	var features []ManifestFeature
+	for _, m := range manifestFeatureRe.FindAllStringSubmatch(content, -1) {
+		features = append(features, ManifestFeature{Name: m[2], Required: m[1] != "false"})
+	}
+	return features
+}
+
+// addManifestFeatures declares the given features in manifest.xml, creating the <dependencies>
+// and <features> sections if needed. Features already declared are left as they are. When write
+// is false the manifest is left unchanged. It returns the features that were, or would be, added.
+func addManifestFeatures(manifestPath string, features []string, required, write bool) ([]string, error) {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", manifestPath, err)
+	}
+
+	declared := make(map[string]bool)
+	for _, feature := range parseManifestFeatures(string(data)) {
+		declared[feature.Name] = true
+	}
+	var added []string
+	for _, feature := range features {
+		if !declared[feature] && !containsString(added, feature) {
+			added = append(added, feature)
+		}
+	}
+	if len(added) == 0 || !write {
+		return added, nil
+	}
+
+	lines := strings.Split(string(data), "\n")
+	featuresEnd, dependenciesEnd, manifestEnd := -1, -1, -1
+	for i, line := range lines {
+		switch strings.TrimSpace(line) {
+		case "</features>":
+			if featuresEnd < 0 {
+				featuresEnd = i
+			}
+		case "</dependencies>":
+			dependenciesEnd = i
+		case "</manifest>":
+			manifestEnd = i
+		}
+	}
+
+	featureLine := func(indent, feature string) string {
+		return fmt.Sprintf(`%s<feature required="%t">%s</feature>`, indent, required, feature)
+	}
+	var insert []string
+	at := featuresEnd
+	switch {
+	case featuresEnd >= 0:
+		indent := lineIndent(lines[featuresEnd]) + "  "
+		if featuresEnd > 0 && manifestFeatureRe.MatchString(lines[featuresEnd-1]) {
+			indent = lineIndent(lines[featuresEnd-1])
+		}
+		for _, feature := range added {
+			insert = append(insert, featureLine(indent, feature))
+		}
+	case dependenciesEnd >= 0:
+		indent := lineIndent(lines[dependenciesEnd]) + "  "
+		insert = append(insert, indent+"<features>")
+		for _, feature := range added {
+			insert = append(insert, featureLine(indent+"  ", feature))
+		}
+		insert = append(insert, indent+"</features>")
+		at = dependenciesEnd
+	case manifestEnd >= 0:
+		indent := lineIndent(lines[manifestEnd]) + "  "
+		insert = append(insert, indent+"<dependencies>", indent+"  <features>")
+		for _, feature := range added {
+			insert = append(insert, featureLine(indent+"    ", feature))
+		}
+		insert = append(insert, indent+"  </features>", indent+"</dependencies>")
+		at = manifestEnd
+	default:
+		return nil, fmt.Errorf("%s has no closing </manifest> tag", manifestPath)
+	}
+	lines = append(lines[:at], append(insert, lines[at:]...)...)
+
+	if err := os.WriteFile(manifestPath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return nil, fmt.Errorf("error writing %s: %v", manifestPath, err)
+	}
+	return added, nil
+}
+
+// ensureManifestFeatures declares the features required by an object generated by add in
+// manifest.xml, if the project has one.
+func ensureManifestFeatures(objectType string) {
+	features := scriptTypeFeatures[objectType]
+	if len(features) == 0 {
+		return
+	}
+	manifestPath, ok := findManifestXML()
+	if !ok {
+		return
+	}
+	added, err := addManifestFeatures(manifestPath, features, true, !dryRunFlag)
+	if err != nil {
+		logWarn("Failed to update %s: %v", manifestPath, err)
+		return
+	}
+	for _, feature := range added {
+		if dryRunFlag {
+			fmt.Printf("Would add feature %s to %s\n", feature, manifestPath)
+		} else {
+			logInfo("Added feature %s to %s", feature, manifestPath)
+		}
+	}
+}
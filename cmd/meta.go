@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"netsuite-cli/internal/auth"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	metaRecordFlags   []string
+	metaAccountFlag   string
+	metaWorkersFlag   int
+	metaTypesOnlyFlag bool
+)
+
+// metadataCacheDir is the project folder holding cached account data. The project
+// configuration already uses the .netsuite-cli file name, so the cache lives next to it.
+const metadataCacheDir = ".netsuite-cli-cache"
+
+// metaCmd represents the meta command
+var metaCmd = &cobra.Command{
+	Use:   "meta",
+	Short: "Manage the local cache of account record metadata",
+}
+
+// metaSyncCmd represents the meta sync command
+var metaSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Download the record metadata catalog of the account",
+	Long: `Download the record types, fields and enumerated values of the account from
+the SuiteTalk REST metadata catalog into ` + metadataCacheDir + `/metadata.json. The cache
+is used to validate and suggest record types and fields in other commands.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMetaSync()
+	},
+}
+
+// metaListCmd represents the meta list command
+var metaListCmd = &cobra.Command{
+	Use:   "list [record-type]",
+	Short: "List the cached record types, or the fields of a record type",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMetaList(args)
+	},
+}
+
+func init() {
+	metaSyncCmd.Flags().StringSliceVarP(&metaRecordFlags, "record", "r", nil, "Record types to fetch fields for (default: all)")
+	metaSyncCmd.Flags().StringVarP(&metaAccountFlag, "account", "a", "", "Account profile to use (default: the default profile)")
+	metaSyncCmd.Flags().IntVar(&metaWorkersFlag, "workers", 4, "Number of record types fetched in parallel")
+	metaSyncCmd.Flags().BoolVar(&metaTypesOnlyFlag, "types-only", false, "Only fetch the list of record types, without fields")
+
+	metaCmd.AddCommand(metaSyncCmd)
+	metaCmd.AddCommand(metaListCmd)
+	rootCmd.AddCommand(metaCmd)
+}
+
+// MetadataCache holds the record metadata of an account.
+type MetadataCache struct {
+	AccountID string           `json:"accountId"`
+	SyncedAt  time.Time        `json:"syncedAt"`
+	Records   []RecordMetadata `json:"records"`
+}
+
+// RecordMetadata describes a record type and its fields.
+type RecordMetadata struct {
+	Name   string          `json:"name"`
+	Label  string          `json:"label,omitempty"`
+	Fields []FieldMetadata `json:"fields,omitempty"`
+}
+
+// FieldMetadata describes a field of a record type.
+type FieldMetadata struct {
+	Id     string   `json:"id"`
+	Label  string   `json:"label,omitempty"`
+	Type   string   `json:"type,omitempty"`
+	Custom bool     `json:"custom,omitempty"`
+	Values []string `json:"values,omitempty"`
+}
+
+// FindRecord returns the metadata of a record type, matched case-insensitively, or nil.
+func (c *MetadataCache) FindRecord(name string) *RecordMetadata {
+	for i := range c.Records {
+		if strings.EqualFold(c.Records[i].Name, name) {
+			return &c.Records[i]
+		}
+	}
+	return nil
+}
+
+// RecordNames returns the names of the cached record types.
+func (c *MetadataCache) RecordNames() []string {
+	names := make([]string, len(c.Records))
+	for i, record := range c.Records {
+		names[i] = record.Name
+	}
+	return names
+}
+
+// metadataCachePath returns the path of the metadata cache in the current project.
+func metadataCachePath() string {
+	return filepath.Join(metadataCacheDir, "metadata.json")
+}
+
+// LoadMetadataCache reads the metadata cache of the current project. It returns nil without an
+// error when the cache has not been synced yet.
+func LoadMetadataCache() (*MetadataCache, error) {
+	data, err := os.ReadFile(metadataCachePath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading metadata cache: %v", err)
+	}
+
+	var cache MetadataCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("error parsing metadata cache: %v", err)
+	}
+	return &cache, nil
+}
+
+// SaveMetadataCache writes the metadata cache of the current project.
+func SaveMetadataCache(cache *MetadataCache) error {
+	if err := os.MkdirAll(metadataCacheDir, 0755); err != nil {
+		return fmt.Errorf("error creating %s: %v", metadataCacheDir, err)
+	}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling metadata cache: %v", err)
+	}
+	if err := os.WriteFile(metadataCachePath(), data, 0644); err != nil {
+		return fmt.Errorf("error writing metadata cache: %v", err)
+	}
+	return nil
+}
+
+// runMetaSync downloads the metadata catalog into the project cache.
+func runMetaSync() error {
+	if _, err := loadProjectConfig(); err != nil {
+		return err
+	}
+
+	account, err := resolveAccount(metaAccountFlag)
+	if err != nil {
+		return err
+	}
+	client, err := newAuthClient(account)
+	if err != nil {
+		return err
+	}
+
+	logInfo("Fetching record types...")
+	names, err := fetchMetadataCatalog(client, account.AccountID)
+	if err != nil {
+		return err
+	}
+
+	cache := &MetadataCache{AccountID: account.AccountID, SyncedAt: time.Now().UTC()}
+	for _, name := range names {
+		cache.Records = append(cache.Records, RecordMetadata{Name: name})
+	}
+
+	if !metaTypesOnlyFlag {
+		var selected []*RecordMetadata
+		for _, name := range metaRecordFlags {
+			record := cache.FindRecord(strings.TrimSpace(name))
+			if record == nil {
+				logWarn("Record type '%s' is not in the metadata catalog", name)
+				continue
+			}
+			selected = append(selected, record)
+		}
+		if len(metaRecordFlags) == 0 {
+			for i := range cache.Records {
+				selected = append(selected, &cache.Records[i])
+			}
+		}
+		fetchRecordSchemas(client, account.AccountID, selected)
+	}
+
+	if err := SaveMetadataCache(cache); err != nil {
+		return err
+	}
+	logInfo("\n✓ Cached %d record types in %s", len(cache.Records), metadataCachePath())
+	return nil
+}
+
+// fetchMetadataCatalog returns the sorted names of the record types in the metadata catalog.
+func fetchMetadataCatalog(client *auth.Client, accountID string) ([]string, error) {
+	resp, err := doRESTRequest(client, accountID, http.MethodGet, "/record/v1/metadata-catalog", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching metadata catalog: %v", err)
+	}
+
+	var catalog struct {
+		Items []struct {
+			Name string `json:"name"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(resp.Body, &catalog); err != nil {
+		return nil, fmt.Errorf("error parsing metadata catalog: %v", err)
+	}
+
+	names := make([]string, 0, len(catalog.Items))
+	for _, item := range catalog.Items {
+		names = append(names, item.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// fetchRecordSchemas fills in the label and fields of the records from their JSON schemas,
+// fetching up to --workers records in parallel. Failures are reported as warnings.
+func fetchRecordSchemas(client *auth.Client, accountID string, records []*RecordMetadata) {
+	jobs := make(chan *RecordMetadata)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	done := 0
+
+	for range max(metaWorkersFlag, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for record := range jobs {
+				err := fetchRecordSchema(client, accountID, record)
+
+				mu.Lock()
+				done++
+				if err != nil && logEnabled(levelWarn) {
+					fmt.Println()
+					logWarn("Failed to fetch fields of %s: %v", record.Name, err)
+				}
+				if logEnabled(levelInfo) {
+					fmt.Printf("\rFetching fields... %d/%d", done, len(records))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, record := range records {
+		jobs <- record
+	}
+	close(jobs)
+	wg.Wait()
+	if logEnabled(levelInfo) {
+		fmt.Println()
+	}
+}
+
+// fetchRecordSchema fills in the label and fields of a record from its JSON schema.
+func fetchRecordSchema(client *auth.Client, accountID string, record *RecordMetadata) error {
+	resource := "/record/v1/metadata-catalog/" + url.PathEscape(record.Name)
+	resp, err := sendRESTRequest(client, http.MethodGet, auth.RESTBaseURL(accountID)+resource, nil, "application/schema+json")
+	if err != nil {
+		return err
+	}
+
+	var schema struct {
+		Title      string `json:"title"`
+		Properties map[string]struct {
+			Title  string `json:"title"`
+			Type   string `json:"type"`
+			Format string `json:"format"`
+			Ref    string `json:"$ref"`
+			Enum   []any  `json:"enum"`
+			Custom bool   `json:"x-ns-custom-field"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(resp.Body, &schema); err != nil {
+		return fmt.Errorf("error parsing schema: %v", err)
+	}
+
+	record.Label = schema.Title
+	record.Fields = nil
+	for id, property := range schema.Properties {
+		if id == "links" {
+			continue
+		}
+
+		fieldType := property.Type
+		if property.Format != "" {
+			fieldType = property.Format
+		}
+		if property.Ref != "" {
+			fieldType = "select"
+			if strings.HasSuffix(property.Ref, "Collection") {
+				fieldType = "sublist"
+			}
+		}
+
+		field := FieldMetadata{Id: id, Label: property.Title, Type: fieldType, Custom: property.Custom}
+		for _, value := range property.Enum {
+			field.Values = append(field.Values, fmt.Sprint(value))
+		}
+		record.Fields = append(record.Fields, field)
+	}
+	sort.Slice(record.Fields, func(i, j int) bool {
+		return record.Fields[i].Id < record.Fields[j].Id
+	})
+	return nil
+}
+
+// runMetaList prints the cached record types, or the fields of a record type.
+func runMetaList(args []string) error {
+	cache, err := LoadMetadataCache()
+	if err != nil {
+		return err
+	}
+	if cache == nil {
+		return errors.New("no metadata cached, run 'netsuite-cli meta sync' first")
+	}
+
+	if len(args) == 0 {
+		for _, record := range cache.Records {
+			fmt.Println(record.Name)
+		}
+		return nil
+	}
+
+	record := cache.FindRecord(args[0])
+	if record == nil {
+		return fmt.Errorf("record type '%s' is not in the metadata cache", args[0])
+	}
+	if len(record.Fields) == 0 {
+		fmt.Printf("No fields cached for %s. Run 'netsuite-cli meta sync --record %s'.\n", record.Name, record.Name)
+		return nil
+	}
+
+	for _, field := range record.Fields {
+		line := fmt.Sprintf("%-40s %-10s %s", field.Id, field.Type, field.Label)
+		if len(field.Values) > 0 {
+			line += " [" + strings.Join(field.Values, ", ") + "]"
+		}
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var mocksOutputFlag string
+
+// mocksCmd represents the mocks command
+var mocksCmd = &cobra.Command{
+	Use:   "mocks",
+	Short: "Generate Jest mocks of the SuiteScript modules",
+}
+
+// mocksGenerateCmd represents the mocks generate command
+var mocksGenerateCmd = &cobra.Command{
+	Use:   "generate <module>...",
+	Short: "Generate typed Jest mock modules for SuiteScript N/ modules",
+	Long: `Generate a typed Jest mock for each SuiteScript module in the __mocks__ folder, so
+tests of generated scripts run without the NetSuite runtime. Functions are jest.fn()
+mocks typed after @hitc/netsuite-types; enums and constants come from the SuiteCloud
+Unit Testing stubs set up by 'netsuite-cli setup tests'.`,
+	Example: `  netsuite-cli mocks generate N/record N/search N/log
+  jest.mock("N/record");`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMocksGenerate(args)
+	},
+}
+
+func init() {
+	mocksGenerateCmd.Flags().StringVarP(&mocksOutputFlag, "output", "o", "__mocks__", "Output folder for the generated mocks")
+	mocksGenerateCmd.Flags().BoolVar(&forceFlag, "force", false, "Overwrite existing mocks without asking")
+
+	mocksCmd.AddCommand(mocksGenerateCmd)
+	rootCmd.AddCommand(mocksCmd)
+}
+
+// suiteScriptModule lists the functions and the enums or constants exported by a SuiteScript module.
+type suiteScriptModule struct {
+	functions []string
+	constants []string
+}
+
+// suiteScriptModules describes the SuiteScript modules that can be mocked.
+var suiteScriptModules = map[string]suiteScriptModule{
+	"N/cache":           {[]string{"getCache"}, []string{"Scope"}},
+	"N/config":          {[]string{"load"}, []string{"Type"}},
+	"N/crypto":          {[]string{"createCipher", "createDecipher", "createHash", "createHmac", "createSecretKey"}, []string{"EncryptionAlg", "HashAlg", "Padding"}},
+	"N/currentRecord":   {[]string{"get"}, nil},
+	"N/email":           {[]string{"send", "sendBulk", "sendCampaignEvent"}, nil},
+	"N/encode":          {[]string{"convert"}, []string{"Encoding"}},
+	"N/error":           {[]string{"create"}, []string{"Type"}},
+	"N/file":            {[]string{"create", "delete", "load"}, []string{"Encoding", "Type"}},
+	"N/format":          {[]string{"format", "parse"}, []string{"Timezone", "Type"}},
+	"N/http":            {[]string{"delete", "get", "post", "put", "request"}, []string{"CacheDuration", "Method"}},
+	"N/https":           {[]string{"createSecretKey", "createSecureString", "delete", "get", "post", "put", "request", "requestRestlet", "requestSuitelet"}, []string{"CacheDuration", "Method"}},
+	"N/log":             {[]string{"audit", "debug", "emergency", "error"}, nil},
+	"N/query":           {[]string{"create", "delete", "load", "runSuiteQL", "runSuiteQLPaged"}, []string{"Operator", "Type"}},
+	"N/record":          {[]string{"attach", "copy", "create", "delete", "detach", "load", "submitFields", "transform"}, []string{"Type"}},
+	"N/redirect":        {[]string{"redirect", "toRecord", "toRecordTransform", "toSavedSearch", "toSavedSearchResult", "toSearch", "toSearchResult", "toSuitelet", "toTaskLink"}, nil},
+	"N/render":          {[]string{"bom", "create", "mergeEmail", "packingSlip", "pickingTicket", "statement", "transaction", "xmlToPdf"}, []string{"DataSource", "PrintMode"}},
+	"N/runtime":         {[]string{"getCurrentScript", "getCurrentSession", "getCurrentUser", "isFeatureInEffect"}, []string{"ContextType", "EnvType", "Permission", "accountId", "envType", "executionContext", "version"}},
+	"N/search":          {[]string{"create", "createColumn", "createFilter", "createSetting", "delete", "duplicates", "global", "load", "lookupFields"}, []string{"Operator", "Sort", "Summary", "Type"}},
+	"N/task":            {[]string{"checkStatus", "create"}, []string{"MapReduceStage", "TaskStatus", "TaskType"}},
+	"N/ui/serverWidget": {[]string{"createAssistant", "createForm", "createList"}, []string{"AssistantSubmitAction", "FieldBreakType", "FieldDisplayType", "FieldLayoutType", "FieldType", "FormPageLinkType", "LayoutJustification", "ListStyle", "SublistDisplayType", "SublistType"}},
+	"N/url":             {[]string{"format", "resolveDomain", "resolveRecord", "resolveScript", "resolveTaskLink"}, []string{"HostType"}},
+	"N/workflow":        {[]string{"initiate", "trigger"}, nil},
+}
+
+// suiteScriptModuleNames returns the sorted names of the modules that can be mocked.
+func suiteScriptModuleNames() []string {
+	names := make([]string, 0, len(suiteScriptModules))
+	for name := range suiteScriptModules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// reservedExportNames are module functions whose name is a reserved word in TypeScript and
+// needs a local alias.
+var reservedExportNames = []string{"delete"}
+
+// MockFunction is a mocked module function. Local differs from Name when Name is a reserved word.
+type MockFunction struct {
+	Name  string
+	Local string
+}
+
+// MockData holds the data used to render a module mock.
+type MockData struct {
+	Module    string
+	Functions []MockFunction
+	Constants []string
+}
+
+// runMocksGenerate writes a mock for each requested module.
+func runMocksGenerate(modules []string) error {
+	if _, err := loadProjectConfig(); err != nil {
+		return err
+	}
+
+	tmplContent, err := readTemplate("mock.ts.tmpl")
+	if err != nil {
+		return err
+	}
+
+	var selected []string
+	for _, module := range modules {
+		name := strings.TrimSuffix(strings.TrimSpace(module), ".js")
+		if !strings.HasPrefix(name, "N/") {
+			name = "N/" + name
+		}
+		if _, ok := suiteScriptModules[name]; !ok {
+			return fmt.Errorf("unsupported module '%s' (supported: %s)", module, strings.Join(suiteScriptModuleNames(), ", "))
+		}
+		selected = append(selected, name)
+	}
+
+	if !testsConfigured() {
+		logWarn("jest.config.js not found. Run 'netsuite-cli setup tests' so the mocks can load the SuiteCloud stubs.")
+	}
+
+	beginGeneration("netsuite-cli mocks generate " + strings.Join(selected, " "))
+	reader := bufio.NewReader(os.Stdin)
+	for _, name := range selected {
+		module := suiteScriptModules[name]
+		data := MockData{Module: name, Constants: module.constants}
+		for _, function := range module.functions {
+			local := function
+			if containsString(reservedExportNames, function) {
+				local = function + "_"
+			}
+			data.Functions = append(data.Functions, MockFunction{Name: function, Local: local})
+		}
+
+		path := filepath.Join(mocksOutputFlag, filepath.FromSlash(name)+".ts")
+		if err := makeDir(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("error creating directory %s: %v", filepath.Dir(path), err)
+		}
+		written, err := renderAndWrite(reader, path, string(tmplContent), data)
+		if err != nil {
+			return err
+		}
+		if written != "" {
+			reportFile("Created", written, "")
+		}
+	}
+	if err := commitGeneration("."); err != nil {
+		logWarn("Failed to update %s: %v", lockFileName, err)
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffTypeFlag []string
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff [scriptid...]",
+	Short: "Compare local objects with their versions in the account",
+	Long: `Import the account versions of the given objects, or of every object in the Objects
+folder, into a temporary project with 'suitecloud object:import' and show a unified diff against
+the local XML files. Use it to see whether someone changed an object in the NetSuite UI before
+overwriting it with a deploy.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runObjectDiff(args)
+	},
+}
+
+func init() {
+	diffCmd.Flags().StringSliceVarP(&diffTypeFlag, "type", "t", nil, "Comma separated object types to compare (e.g., usereventscript,customrecordtype)")
+	diffCmd.Flags().StringVarP(&envFlag, "env", "e", "", "Project environment to compare against")
+
+	rootCmd.AddCommand(diffCmd)
+}
+
+// LocalObject is an object XML file of the project.
+type LocalObject struct {
+	AccountObject
+	Path string
+}
+
+// diffColors maps the first character of unified diff lines to their color.
+var diffColors = map[byte]string{
+	'+': colorGreen,
+	'-': colorRed,
+	'@': colorCyan,
+}
+
+// findLocalObjects returns the objects declared by the XML files in the Objects folder, sorted by
+// type and script ID.
+func findLocalObjects() ([]LocalObject, error) {
+	objectsDir, err := findObjectsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []LocalObject
+	err = filepath.WalkDir(objectsDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".xml") {
+			return err
+		}
+		if object, ok := readObjectHeader(path); ok {
+			objects = append(objects, LocalObject{AccountObject: object, Path: path})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", objectsDir, err)
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].Type != objects[j].Type {
+			return objects[i].Type < objects[j].Type
+		}
+		return objects[i].ScriptId < objects[j].ScriptId
+	})
+	return objects, nil
+}
+
+// readObjectHeader returns the type and script ID declared by the root element of an object file.
+func readObjectHeader(path string) (AccountObject, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return AccountObject{}, false
+	}
+	defer f.Close()
+
+	decoder := xml.NewDecoder(f)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return AccountObject{}, false
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			for _, attr := range start.Attr {
+				if attr.Name.Local == "scriptid" && attr.Value != "" {
+					return AccountObject{Type: start.Name.Local, ScriptId: attr.Value}, true
+				}
+			}
+			return AccountObject{}, false
+		}
+	}
+}
+
+// newTempProject creates a temporary SDF project sharing the manifest and authentication of the
+// current project, so objects can be imported without touching the local files.
+func newTempProject() (string, error) {
+	dir, err := os.MkdirTemp("", "netsuite-cli-diff-")
+	if err != nil {
+		return "", fmt.Errorf("error creating temporary project: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "src", "Objects"), 0755); err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("error creating temporary project: %v", err)
+	}
+
+	files := map[string][]byte{
+		"suitecloud.config.js": []byte("module.exports = {\n  defaultProjectFolder: \"src\",\n  commands: {}\n};\n"),
+	}
+	if manifestPath, ok := findManifestXML(); ok {
+		if data, err := os.ReadFile(manifestPath); err == nil {
+			files[filepath.Join("src", "manifest.xml")] = data
+		}
+	}
+	if data, err := os.ReadFile("project.json"); err == nil {
+		files["project.json"] = data
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			os.RemoveAll(dir)
+			return "", fmt.Errorf("error creating temporary project: %v", err)
+		}
+	}
+	return dir, nil
+}
+
+// runObjectDiff compares local objects with the account versions.
+func runObjectDiff(scriptIDs []string) error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	objects, err := findLocalObjects()
+	if err != nil {
+		return err
+	}
+
+	var selected []LocalObject
+	for _, object := range objects {
+		if len(scriptIDs) > 0 && !containsString(scriptIDs, object.ScriptId) {
+			continue
+		}
+		if len(diffTypeFlag) > 0 && !containsString(diffTypeFlag, object.Type) {
+			continue
+		}
+		selected = append(selected, object)
+	}
+	for _, id := range scriptIDs {
+		found := false
+		for _, object := range selected {
+			found = found || object.ScriptId == id
+		}
+		if !found {
+			logWarn("No local object with script ID '%s'", id)
+		}
+	}
+	if len(selected) == 0 {
+		fmt.Println("No objects to compare.")
+		return nil
+	}
+
+	suiteCloudCmd, err := ensureSuiteCloudCommand()
+	if err != nil {
+		return err
+	}
+
+	restore, err := activateEnvironment(config, envFlag)
+	if err != nil {
+		return err
+	}
+	tempDir, err := newTempProject()
+	restore()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	byType := make(map[string][]string)
+	var types []string
+	for _, object := range selected {
+		if _, ok := byType[object.Type]; !ok {
+			types = append(types, object.Type)
+		}
+		byType[object.Type] = append(byType[object.Type], object.ScriptId)
+	}
+
+	logInfo("Importing %d object(s) from the account...", len(selected))
+	for _, objectType := range types {
+		args := []string{"object:import", "--type", objectType, "--destinationfolder", "/Objects", "--excludefiles", "--scriptid"}
+		args = append(args, byType[objectType]...)
+
+		var output bytes.Buffer
+		importCmd := suiteCloudExec(suiteCloudCmd, args...)
+		importCmd.Dir = tempDir
+		importCmd.Stdout = &output
+		importCmd.Stderr = &output
+		logCommand(importCmd)
+		if err := importCmd.Run(); err != nil {
+			fmt.Print(output.String())
+			return toolError("suitecloud", fmt.Errorf("error importing %s objects: %v", objectType, err))
+		}
+	}
+
+	changed, missing := 0, 0
+	for _, object := range selected {
+		local, err := os.ReadFile(object.Path)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %v", object.Path, err)
+		}
+		remote, err := os.ReadFile(filepath.Join(tempDir, "src", "Objects", object.ScriptId+".xml"))
+		if err != nil {
+			fmt.Printf("%s (%s): not in the account\n", object.ScriptId, object.Type)
+			missing++
+			continue
+		}
+
+		diff := unifiedDiff("account/"+object.ScriptId+".xml", filepath.ToSlash(object.Path), normalizeNewlines(string(remote)), normalizeNewlines(string(local)))
+		if diff == "" {
+			continue
+		}
+		changed++
+		printColoredDiff(os.Stdout, diff)
+	}
+
+	fmt.Printf("\n%d object(s) compared: %d differ, %d not in the account, %d identical.\n", len(selected), changed, missing, len(selected)-changed-missing)
+	return nil
+}
+
+// normalizeNewlines converts Windows line endings so they do not show up as changes.
+func normalizeNewlines(content string) string {
+	return strings.ReplaceAll(content, "\r\n", "\n")
+}
+
+// printColoredDiff prints a unified diff to file, coloring added, removed and hunk header lines when
+// colors are enabled.
+func printColoredDiff(file *os.File, diff string) {
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		color := ""
+		if line != "" && !strings.HasPrefix(line, "+++") && !strings.HasPrefix(line, "---") {
+			color = diffColors[line[0]]
+		}
+		fmt.Fprintln(file, colorize(file, color, line))
+	}
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// jsonFlag makes commands emit a single JSON document describing their outcome instead of
+// free-form output.
+var jsonFlag bool
+
+// JSONOutput is the document printed by a command run with --json.
+type JSONOutput struct {
+	Command  string     `json:"command"`
+	Success  bool       `json:"success"`
+	ExitCode int        `json:"exitCode"`
+	Files    []JSONFile `json:"files,omitempty"`
+	Warnings []string   `json:"warnings,omitempty"`
+	Errors   []string   `json:"errors,omitempty"`
+	Messages []string   `json:"messages,omitempty"`
+	Result   any        `json:"result,omitempty"`
+}
+
+// JSONFile is a file created, changed or skipped by a command.
+type JSONFile struct {
+	Path   string `json:"path"`
+	Action string `json:"action"`
+	From   string `json:"from,omitempty"`
+	Size   int    `json:"size,omitempty"`
+}
+
+// jsonCapture collects the output of a command run with --json.
+var jsonCapture struct {
+	stdout *os.File
+	writer *os.File
+	done   chan struct{}
+	output JSONOutput
+}
+
+// beginJSONOutput redirects the standard output of the command to a collector when --json is
+// set. Commands add their files, warnings and errors to the document directly, the other lines
+// they print become its messages.
+func beginJSONOutput() {
+	if !jsonFlag || jsonCapture.stdout != nil {
+		return
+	}
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	jsonCapture.stdout, jsonCapture.writer = os.Stdout, writer
+	jsonCapture.done = make(chan struct{})
+	os.Stdout = writer
+
+	go func() {
+		defer close(jsonCapture.done)
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if i := strings.LastIndex(line, "\r"); i >= 0 {
+				line = line[i+1:]
+			}
+			collectOutputLine(strings.TrimSpace(line))
+		}
+	}()
+}
+
+// collectOutputLine adds a line printed by a command to the messages of the JSON output.
+func collectOutputLine(line string) {
+	line = strings.TrimSpace(strings.TrimPrefix(line, "✓"))
+	if line == "" {
+		return
+	}
+	jsonCapture.output.Messages = append(jsonCapture.output.Messages, line)
+}
+
+// addJSONFile adds a file to the JSON output. It reports whether --json is set, in which case
+// the caller does not print the file.
+func addJSONFile(file JSONFile) bool {
+	if !jsonFlag {
+		return false
+	}
+	jsonCapture.output.Files = append(jsonCapture.output.Files, file)
+	return true
+}
+
+// addJSONWarning adds a warning to the JSON output.
+func addJSONWarning(message string) {
+	jsonCapture.output.Warnings = append(jsonCapture.output.Warnings, message)
+}
+
+// addJSONError adds an error to the JSON output.
+func addJSONError(message string) {
+	jsonCapture.output.Errors = append(jsonCapture.output.Errors, message)
+}
+
+// reportFile prints that a file was created, changed or skipped, e.g. "Created x.ts", followed
+// by detail when not empty. With --json the file is added to the document instead.
+func reportFile(action, path, detail string) {
+	message := action + " " + path
+	if detail != "" {
+		message += " " + detail
+	}
+	if addJSONFile(JSONFile{Path: path, Action: strings.ToLower(action)}) {
+		writeLog(levelInfo, "%s", message)
+		return
+	}
+	logInfo("%s", message)
+}
+
+// setJSONResult sets the structured result of the command printed with --json.
+func setJSONResult(result any) {
+	jsonCapture.output.Result = result
+}
+
+// finishJSONOutput restores the standard output and prints the JSON output of the command. It
+// does nothing without --json.
+func finishJSONOutput(code int) {
+	if jsonCapture.stdout == nil {
+		return
+	}
+	jsonCapture.writer.Close()
+	<-jsonCapture.done
+	os.Stdout = jsonCapture.stdout
+	jsonCapture.stdout = nil
+
+	output := &jsonCapture.output
+	output.Command = rootCmd.Name()
+	if cmd, _, err := rootCmd.Find(os.Args[1:]); err == nil {
+		output.Command = cmd.CommandPath()
+	}
+	output.ExitCode = code
+	output.Success = code == 0
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshaling output: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// jsonField is a member of a jsonObject.
+type jsonField struct {
+	Key   string
+	Value json.RawMessage
+}
+
+// jsonObject is a JSON object that keeps the order of its members, so that files such as
+// package.json can be edited without reordering them.
+type jsonObject []jsonField
+
+// UnmarshalJSON decodes a JSON object, keeping the order of its members.
+func (o *jsonObject) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected a JSON object")
+	}
+
+	*o = nil
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+		*o = append(*o, jsonField{Key: token.(string), Value: value})
+	}
+	return nil
+}
+
+// MarshalJSON encodes the object with its members in order.
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(field.Value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Get returns the value of a member.
+func (o jsonObject) Get(key string) (json.RawMessage, bool) {
+	for _, field := range o {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
+
+// marshalJSONUnescaped encodes value like json.MarshalIndent without escaping &, < and >,
+// which are common in package.json scripts.
+func marshalJSONUnescaped(value any, indent string) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", indent)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Set replaces the value of a member, or appends it if missing.
+func (o *jsonObject) Set(key string, value any) error {
+	data, err := marshalJSONUnescaped(value, "")
+	if err != nil {
+		return err
+	}
+	for i, field := range *o {
+		if field.Key == key {
+			(*o)[i].Value = data
+			return nil
+		}
+	}
+	*o = append(*o, jsonField{Key: key, Value: data})
+	return nil
+}
+
+// addMissingMembers adds the entries missing from the object member named key, in sorted
+// order. Existing entries are kept. It returns the number of entries added.
+func (o *jsonObject) addMissingMembers(key string, entries map[string]string) (int, error) {
+	var section jsonObject
+	if raw, ok := o.Get(key); ok {
+		if err := json.Unmarshal(raw, &section); err != nil {
+			return 0, fmt.Errorf("'%s' is not an object: %v", key, err)
+		}
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	added := 0
+	for _, name := range names {
+		if _, ok := section.Get(name); ok {
+			continue
+		}
+		if err := section.Set(name, entries[name]); err != nil {
+			return 0, err
+		}
+		added++
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	return added, o.Set(key, section)
+}
+
+// updatePackageJSON adds the scripts and devDependencies missing from the package.json in
+// projectDir. Existing entries are left unchanged. It reports whether the file was changed.
+func updatePackageJSON(projectDir string, scripts, devDependencies map[string]string) (bool, error) {
+	path := filepath.Join(projectDir, "package.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("error reading %s: %v", path, err)
+	}
+
+	var pkg jsonObject
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return false, fmt.Errorf("error parsing %s: %v", path, err)
+	}
+
+	addedScripts, err := pkg.addMissingMembers("scripts", scripts)
+	if err != nil {
+		return false, fmt.Errorf("error updating %s: %v", path, err)
+	}
+	addedDependencies, err := pkg.addMissingMembers("devDependencies", devDependencies)
+	if err != nil {
+		return false, fmt.Errorf("error updating %s: %v", path, err)
+	}
+	if addedScripts+addedDependencies == 0 {
+		return false, nil
+	}
+
+	updated, err := marshalJSONUnescaped(pkg, "  ")
+	if err != nil {
+		return false, fmt.Errorf("error marshaling %s: %v", path, err)
+	}
+	recordGeneratedFile(path, data, true)
+	if err := os.WriteFile(path, append(updated, '\n'), 0644); err != nil {
+		return false, fmt.Errorf("error writing %s: %v", path, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"netsuite-cli/pkg/scaffold"
+)
+
+// promptScriptParams interactively collects script parameters until an empty name is entered.
+func promptScriptParams(reader *bufio.Reader) ([]scaffold.ScriptParam, error) {
+	promptf("Add script parameters? (y/n, default: n): ")
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %v", err)
+	}
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response != "y" && response != "yes" {
+		return nil, nil
+	}
+
+	var params []scaffold.ScriptParam
+	for {
+		promptf("Parameter id (leave empty to finish): ")
+		name, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("error reading parameter id: %v", err)
+		}
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return params, nil
+		}
+
+		promptf("Parameter label (default: %s): ", name)
+		label, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("error reading parameter label: %v", err)
+		}
+
+		promptf("Parameter type [%s] (default: text): ", strings.Join(scaffold.ParamTypeNames(), ", "))
+		paramType, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("error reading parameter type: %v", err)
+		}
+
+		param, err := scaffold.NewScriptParam(name, paramType, label)
+		if err != nil {
+			promptf("Invalid parameter: %v\n", err)
+			continue
+		}
+		params = append(params, param)
+	}
+}
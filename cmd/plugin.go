@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"netsuite-cli/pkg/config"
+
+	"github.com/spf13/cobra"
+)
+
+// pluginPrefix is the name prefix of the executables run as external subcommands: an executable
+// netsuite-cli-foo on the PATH is run by 'netsuite-cli foo'.
+const pluginPrefix = "netsuite-cli-"
+
+// pluginsCmd represents the plugins command
+var pluginsCmd = &cobra.Command{
+	Use:   "plugins",
+	Short: "List the plugins found on the PATH",
+	Long: `List the netsuite-cli-<name> executables found on the PATH. Each of them can be run
+as 'netsuite-cli <name>', with the remaining arguments passed through. Built-in commands
+take precedence over plugins of the same name.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPlugins()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pluginsCmd)
+}
+
+// runPlugins prints the plugins found on the PATH.
+func runPlugins() error {
+	plugins := findPlugins()
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if jsonFlag {
+		type plugin struct {
+			Name string `json:"name"`
+			Path string `json:"path"`
+		}
+		result := make([]plugin, 0, len(names))
+		for _, name := range names {
+			result = append(result, plugin{Name: name, Path: plugins[name]})
+		}
+		setJSONResult(result)
+		return nil
+	}
+
+	if len(names) == 0 {
+		fmt.Printf("No plugins found. Plugins are executables named %s<name> on the PATH.\n", pluginPrefix)
+		return nil
+	}
+	for _, name := range names {
+		fmt.Printf("%s\t%s\n", name, plugins[name])
+	}
+	return nil
+}
+
+// findPlugins returns the plugin executables on the PATH by plugin name. When several
+// directories hold the same plugin, the first one on the PATH wins, as it does when running it.
+func findPlugins() map[string]string {
+	plugins := make(map[string]string)
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasPrefix(entry.Name(), pluginPrefix) {
+				continue
+			}
+			path, err := exec.LookPath(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				continue
+			}
+			name := strings.TrimPrefix(entry.Name(), pluginPrefix)
+			name = strings.TrimSuffix(name, filepath.Ext(name))
+			if _, ok := plugins[name]; !ok && name != "" {
+				plugins[name] = path
+			}
+		}
+	}
+	return plugins
+}
+
+// lookupPlugin returns the plugin executable run by the command line args, if the first argument
+// names a plugin rather than a built-in command.
+func lookupPlugin(args []string) (string, bool) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return "", false
+	}
+	rootCmd.InitDefaultHelpCmd()
+	rootCmd.InitDefaultCompletionCmd()
+	if cmd, _, err := rootCmd.Find(args[:1]); err == nil && cmd != rootCmd {
+		return "", false
+	}
+	path, err := exec.LookPath(pluginPrefix + args[0])
+	if err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// runPlugin runs a plugin executable with the given arguments, connected to the terminal. The
+// project configuration is passed in NETSUITE_CLI_* environment variables when the command is
+// run inside a project. It returns the exit code of the plugin.
+func runPlugin(path string, args []string) (int, error) {
+	env, err := pluginEnv()
+	if err != nil {
+		return exitConfig, err
+	}
+
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), env...)
+	logCommand(cmd)
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), nil
+		}
+		return exitTool, toolError(filepath.Base(path), fmt.Errorf("error running %s: %v", path, err))
+	}
+	return exitOK, nil
+}
+
+// pluginEnv returns the environment variables describing the CLI and the current project to a
+// plugin. Outside a project only NETSUITE_CLI is set.
+func pluginEnv() ([]string, error) {
+	var env []string
+	if executable, err := os.Executable(); err == nil {
+		env = append(env, "NETSUITE_CLI="+executable)
+	}
+
+	root, err := FindProjectRoot()
+	if errors.Is(err, config.ErrNoProject) {
+		return env, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	project, _, err := config.Load(root)
+	if err != nil {
+		return nil, configError(err)
+	}
+	data, err := json.Marshal(project)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(env,
+		"NETSUITE_CLI_PROJECT_ROOT="+root,
+		"NETSUITE_CLI_PROJECT_NAME="+project.ProjectName,
+		"NETSUITE_CLI_COMPANY_NAME="+project.CompanyName,
+		"NETSUITE_CLI_COMPANY_PREFIX="+project.Prefix(),
+		"NETSUITE_CLI_USER_NAME="+project.UserName,
+		"NETSUITE_CLI_USER_EMAIL="+project.UserEmail,
+		"NETSUITE_CLI_API_VERSION="+project.SuiteScriptVersion(),
+		"NETSUITE_CLI_DEFAULT_ENVIRONMENT="+project.DefaultEnvironment,
+		"NETSUITE_CLI_AUTH_ID="+project.Environments[project.DefaultEnvironment],
+		"NETSUITE_CLI_CONFIG="+string(data),
+	), nil
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// timeoutFlag bounds the run time of a command, including the external commands and requests it
+// runs. Zero means no limit.
+var timeoutFlag time.Duration
+
+// runContext is done when the user presses Ctrl+C or the command runs past --timeout. External
+// commands and REST requests are tied to it.
+var runContext = context.Background()
+
+// stopGracePeriod is how long stopped external commands get to exit before they are killed.
+const stopGracePeriod = 5 * time.Second
+
+// errInterrupted is returned when the user pressed Ctrl+C. It ends the command without an error
+// message.
+var errInterrupted = errors.New("interrupted")
+
+// errTimedOut is returned when the command ran past --timeout.
+var errTimedOut = errors.New("timed out")
+
+// stoppedProcesses are the external commands asked to stop, by process or process group ID.
+var stoppedProcesses struct {
+	sync.Mutex
+	ids []int
+}
+
+// exitCleanups undo temporary changes of the running command, such as the project.json of
+// activateEnvironment, by registration number. They run when the CLI exits without waiting for
+// the command to return.
+var exitCleanups struct {
+	sync.Mutex
+	next int
+	fns  map[int]func()
+}
+
+// cleanupOnExit registers fn to run if the CLI is forced to exit, and returns the function the
+// command calls instead once it is done. fn runs at most once.
+func cleanupOnExit(fn func()) func() {
+	var once sync.Once
+	run := func() { once.Do(fn) }
+
+	exitCleanups.Lock()
+	defer exitCleanups.Unlock()
+	if exitCleanups.fns == nil {
+		exitCleanups.fns = make(map[int]func())
+	}
+	id := exitCleanups.next
+	exitCleanups.next++
+	exitCleanups.fns[id] = run
+
+	return func() {
+		exitCleanups.Lock()
+		delete(exitCleanups.fns, id)
+		exitCleanups.Unlock()
+		run()
+	}
+}
+
+// runExitCleanups runs the cleanups the command has not run itself.
+func runExitCleanups() {
+	exitCleanups.Lock()
+	fns := exitCleanups.fns
+	exitCleanups.fns = nil
+	exitCleanups.Unlock()
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// handleInterrupts makes Ctrl+C stop the running external commands instead of ending the CLI at
+// once. When the command does not return within stopGracePeriod, e.g. because it waits at a
+// prompt, the CLI exits anyway. A second Ctrl+C exits immediately.
+func handleInterrupts(done <-chan struct{}) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	runContext = ctx
+	go func() {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+		}
+		stop()
+		writeLog(levelError, "interrupted")
+
+		wait := stopGracePeriod
+		// Stopped commands register themselves right after the context is cancelled; without
+		// any, there is nothing to wait for.
+		time.Sleep(100 * time.Millisecond)
+		stoppedProcesses.Lock()
+		if len(stoppedProcesses.ids) == 0 {
+			wait = 0
+		}
+		stoppedProcesses.Unlock()
+		select {
+		case <-done:
+		case <-time.After(wait):
+			killStoppedProcesses()
+			runExitCleanups()
+			closeLogFile(exitInterrupted)
+			os.Exit(exitInterrupted)
+		}
+	}()
+}
+
+// stopTimeout releases the timer of --timeout.
+var stopTimeout context.CancelFunc = func() {}
+
+// startTimeout applies --timeout to runContext.
+func startTimeout() {
+	if timeoutFlag > 0 {
+		runContext, stopTimeout = context.WithTimeout(runContext, timeoutFlag)
+	}
+}
+
+// contextError replaces the error of a command stopped by Ctrl+C or --timeout: its external
+// commands fail with whatever signal ended them.
+func contextError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(runContext.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("%w after %s, raise --timeout if the command needs longer", errTimedOut, timeoutFlag)
+	case runContext.Err() != nil:
+		return errInterrupted
+	}
+	return err
+}
+
+// sleepContext pauses for d, returning early with the error of the command when runContext is
+// done.
+func sleepContext(d time.Duration) error {
+	select {
+	case <-runContext.Done():
+		return contextError(runContext.Err())
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// externalCommand returns a command tied to runContext. It runs in a process group of its own,
+// so stopping it also stops the processes it starts, e.g. node under npx.
+func externalCommand(name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(runContext, name, args...)
+	cmd.WaitDelay = stopGracePeriod
+	setProcessGroup(cmd)
+	return cmd
+}
+
+// attachTerminal connects the standard input of the command to the terminal, so it can prompt.
+// The command then stays in the process group of the CLI, which the terminal sends Ctrl+C to.
+func attachTerminal(cmd *exec.Cmd) {
+	cmd.Stdin = os.Stdin
+	setTerminalProcess(cmd)
+}
+
+// stoppedProcess records a process or process group asked to stop.
+func stoppedProcess(id int) {
+	stoppedProcesses.Lock()
+	defer stoppedProcesses.Unlock()
+	stoppedProcesses.ids = append(stoppedProcesses.ids, id)
+}
+
+// killStoppedProcesses kills what is left of the external commands asked to stop.
+func killStoppedProcesses() {
+	stoppedProcesses.Lock()
+	defer stoppedProcesses.Unlock()
+	for _, id := range stoppedProcesses.ids {
+		killProcess(id)
+	}
+	stoppedProcesses.ids = nil
+}
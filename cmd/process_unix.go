@@ -0,0 +1,40 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup starts the command as the leader of a new process group, which is sent
+// SIGTERM when runContext is done.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		stoppedProcess(-cmd.Process.Pid)
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+	}
+}
+
+// setTerminalProcess keeps the command in the foreground process group, where it can read from
+// the terminal. It is sent SIGINT when runContext is done, as Ctrl+C does.
+func setTerminalProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = nil
+	cmd.Cancel = func() error {
+		stoppedProcess(cmd.Process.Pid)
+		return cmd.Process.Signal(os.Interrupt)
+	}
+}
+
+// killProcess kills a process, or a process group given as a negative ID.
+func killProcess(id int) {
+	syscall.Kill(id, syscall.SIGKILL)
+}
+
+// detachProcess starts the command in a session of its own, so it keeps running after the CLI
+// exits and does not receive the signals of the terminal.
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
@@ -0,0 +1,44 @@
+//go:build windows
+
+package cmd
+
+import (
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// setProcessGroup starts the command in a new process group, so it does not receive the Ctrl+C
+// of the console. Its process tree is killed when runContext is done.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+	cmd.Cancel = func() error {
+		return killProcessTree(cmd.Process.Pid)
+	}
+}
+
+// setTerminalProcess keeps the command in the process group of the console, which receives
+// Ctrl+C. Its process tree is killed when runContext is done.
+func setTerminalProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = nil
+	cmd.Cancel = func() error {
+		return killProcessTree(cmd.Process.Pid)
+	}
+}
+
+// killProcessTree kills a process and the processes it started.
+func killProcessTree(pid int) error {
+	return exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(pid)).Run()
+}
+
+// killProcess does nothing on Windows, where stopping a command kills it right away.
+func killProcess(id int) {}
+
+// detachedProcess is the DETACHED_PROCESS process creation flag, missing from package syscall.
+const detachedProcess = 0x00000008
+
+// detachProcess starts the command without a console and in a new process group, so it keeps
+// running after the CLI exits and does not receive the Ctrl+C of the console.
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP | detachedProcess}
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"text/tabwriter"
+	"time"
+)
+
+// stepStatus is the outcome of a step of a long operation.
+type stepStatus string
+
+const (
+	stepDone    stepStatus = "done"
+	stepFailed  stepStatus = "failed"
+	stepSkipped stepStatus = "skipped"
+)
+
+// StepResult is the outcome of a step, listed in the summary.
+type StepResult struct {
+	Name    string        `json:"name"`
+	Status  stepStatus    `json:"status"`
+	Elapsed time.Duration `json:"elapsedMs"`
+}
+
+// MarshalJSON writes the elapsed time in milliseconds.
+func (r StepResult) MarshalJSON() ([]byte, error) {
+	type plain StepResult
+	result := plain(r)
+	result.Elapsed = r.Elapsed / time.Millisecond
+	return json.Marshal(result)
+}
+
+// stepTracker numbers the steps of a long operation, prints a header before each of them and a
+// summary of their outcome at the end.
+type stepTracker struct {
+	total   int
+	name    string
+	started time.Time
+	results []StepResult
+	printed bool
+}
+
+// newStepTracker returns a tracker for an operation of total steps.
+func newStepTracker(total int) *stepTracker {
+	return &stepTracker{total: total}
+}
+
+// begin prints the header of the next step and starts its clock. A step left unfinished is
+// recorded as failed.
+func (t *stepTracker) begin(name string) {
+	if t.name != "" {
+		t.end(stepFailed)
+	}
+	t.name, t.started = name, time.Now()
+	t.header(name)
+}
+
+// end records the outcome of the current step.
+func (t *stepTracker) end(status stepStatus) {
+	if t.name == "" {
+		return
+	}
+	t.results = append(t.results, StepResult{Name: t.name, Status: status, Elapsed: time.Since(t.started)})
+	t.name = ""
+}
+
+// skip records a step that is not run.
+func (t *stepTracker) skip(name, reason string) {
+	t.header(fmt.Sprintf("%s (skipped: %s)", name, reason))
+	t.results = append(t.results, StepResult{Name: name, Status: stepSkipped})
+}
+
+// header prints the numbered header of a step.
+func (t *stepTracker) header(title string) {
+	header := fmt.Sprintf("[%d/%d] %s", len(t.results)+1, t.total, title)
+	writeLog(levelInfo, "%s", header)
+	if logEnabled(levelInfo) {
+		fmt.Printf("\n%s\n", colorize(os.Stdout, colorCyan, header))
+	}
+}
+
+// printSummary records the step still running as failed and prints the outcome and duration of
+// every step, once. With --json the steps are the result of the command.
+func (t *stepTracker) printSummary() {
+	if t.printed {
+		return
+	}
+	t.printed = true
+	t.end(stepFailed)
+	if jsonFlag {
+		setJSONResult(t.results)
+		return
+	}
+	if !logEnabled(levelInfo) || len(t.results) == 0 {
+		return
+	}
+
+	fmt.Println("\nSummary:")
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, result := range t.results {
+		symbol, color, elapsed := "✓", colorGreen, formatElapsed(result.Elapsed)
+		switch result.Status {
+		case stepFailed:
+			symbol, color = "✗", colorRed
+		case stepSkipped:
+			symbol, color, elapsed = "-", colorYellow, ""
+		}
+		fmt.Fprintf(writer, "  %s %s\t%s\t%s\n", colorize(os.Stdout, color, symbol), result.Name, result.Status, elapsed)
+	}
+	writer.Flush()
+}
+
+// formatElapsed formats a duration for the progress output, e.g. 850ms or 12.3s.
+func formatElapsed(elapsed time.Duration) string {
+	if elapsed < time.Second {
+		return elapsed.Round(time.Millisecond).String()
+	}
+	return elapsed.Round(100 * time.Millisecond).String()
+}
+
+// spinnerFrames are drawn in turn while a command runs.
+var spinnerFrames = []rune("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏")
+
+// runWithSpinner runs a non-interactive command with its output captured, drawing a spinner and
+// the elapsed time while it runs when the output is a terminal. The captured output is printed
+// when the command fails. With --verbose the output is streamed instead.
+func runWithSpinner(cmd *exec.Cmd, label string) error {
+	logCommand(cmd)
+	if verboseFlag {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		return cmd.Run()
+	}
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	var err error
+	if logEnabled(levelInfo) && isTerminal(os.Stdout) {
+		started := time.Now()
+		ticker := time.NewTicker(100 * time.Millisecond)
+	spin:
+		for frame := 0; ; frame++ {
+			fmt.Printf("\r%c %s (%s)", spinnerFrames[frame%len(spinnerFrames)], label, time.Since(started).Round(time.Second))
+			select {
+			case err = <-done:
+				break spin
+			case <-ticker.C:
+			}
+		}
+		ticker.Stop()
+		fmt.Print("\r\033[K")
+	} else {
+		err = <-done
+	}
+
+	if err != nil {
+		os.Stderr.Write(output.Bytes())
+	}
+	return err
+}
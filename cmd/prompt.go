@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// promptOutput returns the file prompts are written to: stdout, or stderr with --json, where
+// stdout is collected into the JSON document and a prompt there would never be seen.
+func promptOutput() *os.File {
+	if jsonFlag {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// promptf prints a prompt, or the choices and hints around it, to promptOutput.
+func promptf(format string, args ...any) {
+	fmt.Fprintf(promptOutput(), format, args...)
+}
+
+// promptLine prints a prompt and returns the trimmed line entered by the user.
+func promptLine(reader *bufio.Reader, prompt string) (string, error) {
+	promptf("%s", prompt)
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("error reading input: %v", err)
+	}
+	return strings.TrimSpace(input), nil
+}
+
+// promptConfirm asks a yes/no question and reports whether the user answered yes.
+func promptConfirm(reader *bufio.Reader, prompt string) (bool, error) {
+	response, err := promptLine(reader, prompt)
+	if err != nil {
+		return false, err
+	}
+	response = strings.ToLower(response)
+	return response == "y" || response == "yes", nil
+}
+
+// promptYesDefault asks a yes/no question that defaults to yes and reports whether the user agreed.
+func promptYesDefault(reader *bufio.Reader, prompt string) (bool, error) {
+	response, err := promptLine(reader, prompt)
+	if err != nil {
+		return false, err
+	}
+	response = strings.ToLower(response)
+	return response == "" || response == "y" || response == "yes", nil
+}
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	pullTypeFlag         []string
+	pullPrefixFlag       string
+	pullAllFlag          bool
+	pullExcludeFilesFlag bool
+)
+
+// pullCmd represents the pull command
+var pullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Import content from the account",
+}
+
+// pullObjectsCmd represents the pull objects command
+var pullObjectsCmd = &cobra.Command{
+	Use:   "objects",
+	Short: "Import objects from the account with an interactive picker",
+	Long: `List the importable objects in the account with 'suitecloud object:list',
+select the ones to import, and import them with 'suitecloud object:import'
+into the project's Objects/<project>/<type> folders.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPullObjects()
+	},
+}
+
+func init() {
+	pullObjectsCmd.Flags().StringSliceVarP(&pullTypeFlag, "type", "t", nil, "Comma separated object types to list (e.g., usereventscript,customrecordtype)")
+	pullObjectsCmd.Flags().StringVar(&pullPrefixFlag, "prefix", "", "Only list objects whose script ID starts with this prefix")
+	pullObjectsCmd.Flags().BoolVarP(&pullAllFlag, "all", "a", false, "Import every listed object without prompting")
+	pullObjectsCmd.Flags().BoolVar(&pullExcludeFilesFlag, "exclude-files", false, "Do not import the files referenced by the objects")
+
+	pullCmd.AddCommand(pullObjectsCmd)
+	rootCmd.AddCommand(pullCmd)
+}
+
+// AccountObject identifies a customization object in the account.
+type AccountObject struct {
+	Type     string
+	ScriptId string
+}
+
+var accountObjectRe = regexp.MustCompile(`^\s*([a-z]+)\s*:\s*([a-z0-9_]+)\s*$`)
+
+// parseObjectList extracts the objects listed in the 'suitecloud object:list' output.
+func parseObjectList(output string) []AccountObject {
+	var objects []AccountObject
+	for _, line := range strings.Split(output, "\n") {
+		if m := accountObjectRe.FindStringSubmatch(line); m != nil {
+			objects = append(objects, AccountObject{Type: m[1], ScriptId: m[2]})
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].Type != objects[j].Type {
+			return objects[i].Type < objects[j].Type
+		}
+		return objects[i].ScriptId < objects[j].ScriptId
+	})
+	return objects
+}
+
+// listAccountObjects lists the objects in the account, optionally filtered by type and script ID prefix.
+func listAccountObjects(suiteCloudCmd string, types []string, prefix string) ([]AccountObject, error) {
+	args := []string{"object:list"}
+	if len(types) > 0 {
+		args = append(args, "--type")
+		args = append(args, types...)
+	}
+	if prefix != "" {
+		args = append(args, "--scriptid", prefix)
+	}
+
+	var output bytes.Buffer
+	listCmd := suiteCloudExec(suiteCloudCmd, args...)
+	listCmd.Stdout = &output
+	listCmd.Stderr = &output
+	logCommand(listCmd)
+	if err := listCmd.Run(); err != nil {
+		return nil, toolError("suitecloud", fmt.Errorf("object:list failed: %v\n%s", err, output.String()))
+	}
+
+	var objects []AccountObject
+	for _, object := range parseObjectList(output.String()) {
+		if prefix == "" || strings.HasPrefix(object.ScriptId, prefix) {
+			objects = append(objects, object)
+		}
+	}
+	return objects, nil
+}
+
+// parseSelection parses a selection such as "1,3-5" or "all" into zero-based indexes.
+func parseSelection(input string, count int) ([]int, error) {
+	input = strings.TrimSpace(strings.ToLower(input))
+	if input == "all" || input == "*" {
+		indexes := make([]int, count)
+		for i := range indexes {
+			indexes[i] = i
+		}
+		return indexes, nil
+	}
+
+	seen := make(map[int]bool)
+	var indexes []int
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		start, end := part, part
+		if before, after, ok := strings.Cut(part, "-"); ok {
+			start, end = before, after
+		}
+		from, err := strconv.Atoi(strings.TrimSpace(start))
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection '%s'", part)
+		}
+		to, err := strconv.Atoi(strings.TrimSpace(end))
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection '%s'", part)
+		}
+		if from < 1 || to > count || from > to {
+			return nil, fmt.Errorf("selection '%s' is out of range 1-%d", part, count)
+		}
+		for i := from; i <= to; i++ {
+			if !seen[i-1] {
+				seen[i-1] = true
+				indexes = append(indexes, i-1)
+			}
+		}
+	}
+	return indexes, nil
+}
+
+// runPullObjects executes the object import process.
+func runPullObjects() error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	suiteCloudCmd, err := ensureSuiteCloudCommand()
+	if err != nil {
+		return err
+	}
+
+	logInfo("Listing objects in the account...")
+	objects, err := listAccountObjects(suiteCloudCmd, pullTypeFlag, pullPrefixFlag)
+	if err != nil {
+		return err
+	}
+	if len(objects) == 0 {
+		logInfo("No importable objects found.")
+		return nil
+	}
+
+	selected := objects
+	if !pullAllFlag {
+		for i, object := range objects {
+			promptf("  %d. %s (%s)\n", i+1, object.ScriptId, object.Type)
+		}
+
+		reader := bufio.NewReader(os.Stdin)
+		for {
+			input, err := promptLine(reader, "\nSelect objects to import (e.g., 1,3-5 or 'all', empty to cancel): ")
+			if err != nil {
+				return err
+			}
+			if input == "" {
+				fmt.Println("Cancelled. No objects imported.")
+				return nil
+			}
+			indexes, err := parseSelection(input, len(objects))
+			if err != nil {
+				promptf("Invalid selection: %v\n", err)
+				continue
+			}
+			selected = nil
+			for _, i := range indexes {
+				selected = append(selected, objects[i])
+			}
+			break
+		}
+	}
+
+	byType := make(map[string][]string)
+	var types []string
+	for _, object := range selected {
+		if _, ok := byType[object.Type]; !ok {
+			types = append(types, object.Type)
+		}
+		byType[object.Type] = append(byType[object.Type], object.ScriptId)
+	}
+
+	for _, objectType := range types {
+		destination := "/Objects/" + config.ProjectName + "/" + objectType
+		args := []string{"object:import", "--type", objectType, "--destinationfolder", destination, "--scriptid"}
+		args = append(args, byType[objectType]...)
+		if pullExcludeFilesFlag {
+			args = append(args, "--excludefiles")
+		}
+
+		logInfo("\nImporting %d %s object(s) into %s...", len(byType[objectType]), objectType, destination)
+		importCmd := suiteCloudExec(suiteCloudCmd, args...)
+		attachTerminal(importCmd)
+		if _, err := runSuiteCloud(importCmd); err != nil {
+			return toolError("suitecloud", fmt.Errorf("error importing %s objects: %v", objectType, err))
+		}
+	}
+
+	logInfo("\n✓ Imported %d object(s).", len(selected))
+	return nil
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// pushCmd represents the push command
+var pushCmd = &cobra.Command{
+	Use:   "push <path>...",
+	Short: "Upload individual files to the File Cabinet",
+	Long: `Resolve local files under the FileCabinet folder to their File Cabinet paths
+and upload them with 'suitecloud file:upload'. TypeScript files are resolved to
+their compiled JavaScript output.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPush(args)
+	},
+}
+
+func init() {
+	pushCmd.Flags().StringVarP(&envFlag, "env", "e", "", "Project environment to upload to")
+
+	rootCmd.AddCommand(pushCmd)
+}
+
+var pushURLRe = regexp.MustCompile(`https?://\S+`)
+
+// resolvePushPath validates a local file and returns the path of the file to upload.
+func resolvePushPath(path string) (string, error) {
+	if filepath.Ext(path) == ".ts" {
+		path = strings.TrimSuffix(path, ".ts") + ".js"
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("compiled file %s not found, run 'netsuite-cli build' first", path)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("file %s not found", path)
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("%s is a directory", path)
+	}
+
+	if !strings.Contains("/"+filepath.ToSlash(path), "/FileCabinet/") && !strings.Contains("/"+filepath.ToSlash(path), "/SuiteScripts/") {
+		return "", fmt.Errorf("%s is not inside the FileCabinet folder", path)
+	}
+	return path, nil
+}
+
+// runPush uploads the given files to the File Cabinet.
+func runPush(paths []string) error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	suiteCloudCmd, err := ensureSuiteCloudCommand()
+	if err != nil {
+		return err
+	}
+
+	var cabinetPaths []string
+	for _, path := range paths {
+		resolved, err := resolvePushPath(projectRelativePath(path))
+		if err != nil {
+			return err
+		}
+		cabinetPath := toFileCabinetPath(resolved)
+		cabinetPaths = append(cabinetPaths, cabinetPath)
+		logInfo("Uploading %s -> %s", resolved, cabinetPath)
+	}
+
+	restore, err := activateEnvironment(config, envFlag)
+	if err != nil {
+		return err
+	}
+
+	uploadCmd := suiteCloudExec(suiteCloudCmd, append([]string{"file:upload", "--paths"}, cabinetPaths...)...)
+	attachTerminal(uploadCmd)
+	result, runErr := runSuiteCloud(uploadCmd)
+	restore()
+	if jsonFlag {
+		setJSONResult(result)
+	}
+	if runErr != nil {
+		return toolError("suitecloud", fmt.Errorf("error uploading files: %v", runErr))
+	}
+
+	logInfo("\n✓ Upload complete.")
+	for _, cabinetPath := range cabinetPaths {
+		logInfo("  File Cabinet: %s", cabinetPath)
+	}
+	for _, url := range pushURLRe.FindAllString(result.Output, -1) {
+		logInfo("  URL: %s", url)
+	}
+	return nil
+}
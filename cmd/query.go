@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"netsuite-cli/internal/auth"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	queryFormatFlag  string
+	queryAccountFlag string
+	queryLimitFlag   int
+)
+
+// suiteQLPageSize is the maximum number of rows NetSuite returns per SuiteQL page.
+const suiteQLPageSize = 1000
+
+// queryCmd represents the query command
+var queryCmd = &cobra.Command{
+	Use:   "query <suiteql>",
+	Short: "Run a SuiteQL query against the account",
+	Long: `Execute a SuiteQL query through the SuiteTalk REST query endpoint using the
+OAuth 2.0 credentials of an account profile, following pagination until all rows
+(or --limit rows) are retrieved.`,
+	Example: `  netsuite-cli query "SELECT id, companyname FROM customer WHERE isinactive = 'F'"
+  netsuite-cli query --format csv "SELECT id, tranid FROM transaction" > transactions.csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runQuery(args[0])
+	},
+}
+
+func init() {
+	queryCmd.Flags().StringVarP(&queryFormatFlag, "format", "f", "table", "Output format: table, csv or json")
+	queryCmd.Flags().StringVarP(&queryAccountFlag, "account", "a", "", "Account profile to query (default: the default profile)")
+	queryCmd.Flags().IntVarP(&queryLimitFlag, "limit", "l", 0, "Maximum number of rows to return (default: all)")
+
+	rootCmd.AddCommand(queryCmd)
+}
+
+// QueryResult holds the columns and rows returned by a SuiteQL query.
+type QueryResult struct {
+	Columns []string
+	Rows    []map[string]any
+}
+
+// runQuery executes a SuiteQL query and prints the results.
+func runQuery(query string) error {
+	format := strings.ToLower(queryFormatFlag)
+	if format != "table" && format != "csv" && format != "json" {
+		return fmt.Errorf("invalid --format '%s' (supported: table, csv, json)", queryFormatFlag)
+	}
+
+	account, err := resolveAccount(queryAccountFlag)
+	if err != nil {
+		return err
+	}
+	client, err := newAuthClient(account)
+	if err != nil {
+		return err
+	}
+
+	result, err := runSuiteQL(runContext, client, account.AccountID, query, queryLimitFlag)
+	if err != nil {
+		return err
+	}
+
+	if jsonFlag {
+		setJSONResult(result.Rows)
+		return nil
+	}
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(result.Rows, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling results: %v", err)
+		}
+		fmt.Println(string(data))
+	case "csv":
+		writer := csv.NewWriter(os.Stdout)
+		writer.Write(result.Columns)
+		for _, row := range result.Rows {
+			writer.Write(queryRowValues(result.Columns, row))
+		}
+		writer.Flush()
+	default:
+		printQueryTable(result)
+	}
+	return nil
+}
+
+// runSuiteQL executes a SuiteQL query, following pagination until limit rows (or all rows when
+// limit is 0) have been retrieved. NetSuite requires the offset to be a multiple of the page
+// size, so every page has the same size and the rows past limit are dropped.
+func runSuiteQL(ctx context.Context, client *auth.Client, accountID, query string, limit int) (*QueryResult, error) {
+	body, err := json.Marshal(map[string]string{"q": query})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &QueryResult{Rows: []map[string]any{}}
+	seen := make(map[string]bool)
+
+	pageSize := suiteQLPageSize
+	if limit > 0 && limit < pageSize {
+		pageSize = limit
+	}
+	for offset := 0; ; offset += pageSize {
+		url := fmt.Sprintf("%s/query/v1/suiteql?limit=%d&offset=%d", auth.RESTBaseURL(accountID), pageSize, offset)
+		page, err := fetchSuiteQLPage(ctx, client, url, body)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.Items {
+			for _, column := range item.columns {
+				if !seen[column] {
+					seen[column] = true
+					result.Columns = append(result.Columns, column)
+				}
+			}
+			result.Rows = append(result.Rows, item.values)
+		}
+
+		logDebug("fetched %d of %d rows", len(result.Rows), page.TotalResults)
+
+		if !page.HasMore || len(page.Items) == 0 || (limit > 0 && len(result.Rows) >= limit) {
+			break
+		}
+	}
+
+	if limit > 0 && len(result.Rows) > limit {
+		result.Rows = result.Rows[:limit]
+	}
+	return result, nil
+}
+
+// suiteQLPage is a single page of SuiteQL results.
+type suiteQLPage struct {
+	Items        []suiteQLItem `json:"items"`
+	HasMore      bool          `json:"hasMore"`
+	TotalResults int           `json:"totalResults"`
+}
+
+// suiteQLItem is a result row that remembers the order of its columns.
+type suiteQLItem struct {
+	columns []string
+	values  map[string]any
+}
+
+// UnmarshalJSON decodes a result row, preserving column order and dropping the links property.
+func (i *suiteQLItem) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+
+	i.values = make(map[string]any)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+		if key == "links" {
+			continue
+		}
+		i.columns = append(i.columns, key)
+		i.values[key] = value
+	}
+	return nil
+}
+
+// fetchSuiteQLPage requests a single page of SuiteQL results.
+func fetchSuiteQLPage(ctx context.Context, client *auth.Client, url string, body []byte) (*suiteQLPage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Prefer", "transient")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error running query: %v", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading query response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("query failed (%s): %s", resp.Status, restErrorMessage(data))
+	}
+
+	var page suiteQLPage
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, fmt.Errorf("error parsing query response: %v", err)
+	}
+	return &page, nil
+}
+
+// restErrorMessage extracts the error details from a SuiteTalk REST error response.
+func restErrorMessage(data []byte) string {
+	var payload struct {
+		Title        string `json:"title"`
+		ErrorDetails []struct {
+			Detail string `json:"detail"`
+		} `json:"o:errorDetails"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return strings.TrimSpace(string(data))
+	}
+
+	var details []string
+	for _, detail := range payload.ErrorDetails {
+		details = append(details, detail.Detail)
+	}
+	if len(details) > 0 {
+		return strings.Join(details, "; ")
+	}
+	if payload.Title != "" {
+		return payload.Title
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// queryRowValues returns the values of a row in column order.
+func queryRowValues(columns []string, row map[string]any) []string {
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		if value, ok := row[column]; ok && value != nil {
+			values[i] = fmt.Sprint(value)
+		}
+	}
+	return values
+}
+
+// printQueryTable prints the query results as an aligned table.
+func printQueryTable(result *QueryResult) {
+	if len(result.Rows) == 0 {
+		fmt.Println("No rows returned.")
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(result.Columns, "\t"))
+	underline := make([]string, len(result.Columns))
+	for i, column := range result.Columns {
+		underline[i] = strings.Repeat("-", len(column))
+	}
+	fmt.Fprintln(writer, strings.Join(underline, "\t"))
+	for _, row := range result.Rows {
+		fmt.Fprintln(writer, strings.Join(queryRowValues(result.Columns, row), "\t"))
+	}
+	writer.Flush()
+
+	fmt.Printf("\n%d row(s)\n", len(result.Rows))
+}
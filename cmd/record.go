@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"netsuite-cli/internal/auth"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	recordFieldsFlag  string
+	recordBodyFlag    string
+	recordExpandFlag  bool
+	recordAccountFlag string
+	recordYesFlag     bool
+)
+
+// recordCmd represents the record command
+var recordCmd = &cobra.Command{
+	Use:   "record",
+	Short: "Read and modify records through the SuiteTalk REST record service",
+	Long: `Read, create, update and delete records through the SuiteTalk REST record
+service using the OAuth 2.0 credentials of an account profile. Record bodies are
+exchanged as JSON.`,
+}
+
+// recordGetCmd represents the record get command
+var recordGetCmd = &cobra.Command{
+	Use:     "get <type> <id>",
+	Short:   "Print a record as JSON",
+	Example: `  netsuite-cli record get customer 42 --fields companyname,email`,
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRecordGet(args[0], args[1])
+	},
+}
+
+// recordCreateCmd represents the record create command
+var recordCreateCmd = &cobra.Command{
+	Use:     "create <type>",
+	Short:   "Create a record from JSON",
+	Example: `  netsuite-cli record create customrecord_acm_rate --body '{"name": "Standard"}'`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRecordCreate(args[0])
+	},
+}
+
+// recordUpdateCmd represents the record update command
+var recordUpdateCmd = &cobra.Command{
+	Use:     "update <type> <id>",
+	Short:   "Update the given fields of a record from JSON",
+	Example: `  netsuite-cli record update customer 42 --body @changes.json`,
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRecordUpdate(args[0], args[1])
+	},
+}
+
+// recordDeleteCmd represents the record delete command
+var recordDeleteCmd = &cobra.Command{
+	Use:   "delete <type> <id>",
+	Short: "Delete a record",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRecordDelete(args[0], args[1])
+	},
+}
+
+func init() {
+	recordCmd.PersistentFlags().StringVarP(&recordAccountFlag, "account", "a", "", "Account profile to use (default: the default profile)")
+
+	recordGetCmd.Flags().StringVar(&recordFieldsFlag, "fields", "", "Comma separated list of fields to return")
+	recordGetCmd.Flags().BoolVar(&recordExpandFlag, "expand", false, "Expand sublists and subrecords")
+	recordCreateCmd.Flags().StringVarP(&recordBodyFlag, "body", "b", "@-", "Record JSON, or @file to read it from a file (default: stdin)")
+	recordUpdateCmd.Flags().StringVarP(&recordBodyFlag, "body", "b", "@-", "Record JSON, or @file to read it from a file (default: stdin)")
+	recordDeleteCmd.Flags().BoolVarP(&recordYesFlag, "yes", "y", false, "Skip the confirmation prompt")
+
+	recordCmd.AddCommand(recordGetCmd)
+	recordCmd.AddCommand(recordCreateCmd)
+	recordCmd.AddCommand(recordUpdateCmd)
+	recordCmd.AddCommand(recordDeleteCmd)
+	rootCmd.AddCommand(recordCmd)
+}
+
+// RESTResponse holds the status, headers and body of a SuiteTalk REST response.
+type RESTResponse struct {
+	StatusCode int
+	Status     string
+	Header     http.Header
+	Body       []byte
+}
+
+// doRESTRequest sends a JSON request to the SuiteTalk REST services of the account and returns
+// the response, or an error describing the failure when the status is not successful.
+func doRESTRequest(client *auth.Client, accountID, method, resource string, body []byte) (*RESTResponse, error) {
+	return doJSONRequest(client, method, auth.RESTBaseURL(accountID)+resource, body)
+}
+
+// doJSONRequest sends an authenticated JSON request and returns the response, or an error
+// describing the failure when the status is not successful.
+func doJSONRequest(client *auth.Client, method, requestURL string, body []byte) (*RESTResponse, error) {
+	return sendRESTRequest(client, method, requestURL, body, "application/json")
+}
+
+// sendRESTRequest sends an authenticated request and returns the response, or an error
+// describing the failure when the status is not successful.
+func sendRESTRequest(client *auth.Client, method, requestURL string, body []byte, accept string) (*RESTResponse, error) {
+	ctx, cancel := context.WithTimeout(runContext, 2*time.Minute)
+	defer cancel()
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", accept)
+
+	logDebug("%s %s", method, req.URL)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %v", err)
+	}
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s: %s", resp.Status, restErrorMessage(data))
+	}
+
+	return &RESTResponse{StatusCode: resp.StatusCode, Status: resp.Status, Header: resp.Header, Body: data}, nil
+}
+
+// recordResource returns the REST record service path of a record type and optional id.
+func recordResource(recordType, id string) string {
+	resource := "/record/v1/" + url.PathEscape(strings.ToLower(recordType))
+	if id != "" {
+		resource += "/" + url.PathEscape(id)
+	}
+	return resource
+}
+
+// newRecordClient returns the account ID and OAuth 2.0 client of the selected account profile.
+func newRecordClient() (string, *auth.Client, error) {
+	account, err := resolveAccount(recordAccountFlag)
+	if err != nil {
+		return "", nil, err
+	}
+	client, err := newAuthClient(account)
+	if err != nil {
+		return "", nil, err
+	}
+	return account.AccountID, client, nil
+}
+
+// readRecordBody reads and validates the JSON record body.
+func readRecordBody() ([]byte, error) {
+	body, err := readRequestBody(recordBodyFlag)
+	if err != nil {
+		return nil, fmt.Errorf("error reading record body: %v", err)
+	}
+	if !json.Valid(body) {
+		return nil, errors.New("record body is not valid JSON")
+	}
+	return body, nil
+}
+
+// runRecordGet prints a record as JSON.
+func runRecordGet(recordType, id string) error {
+	accountID, client, err := newRecordClient()
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	if recordFieldsFlag != "" {
+		query.Set("fields", recordFieldsFlag)
+	}
+	if recordExpandFlag {
+		query.Set("expandSubResources", "true")
+	}
+	resource := recordResource(recordType, id)
+	if len(query) > 0 {
+		resource += "?" + query.Encode()
+	}
+
+	resp, err := doRESTRequest(client, accountID, http.MethodGet, resource, nil)
+	if err != nil {
+		return err
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(resp.Body, &record); err != nil {
+		return fmt.Errorf("error parsing record: %v", err)
+	}
+	delete(record, "links")
+
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling record: %v", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// runRecordCreate creates a record and prints its internal ID.
+func runRecordCreate(recordType string) error {
+	body, err := readRecordBody()
+	if err != nil {
+		return err
+	}
+	accountID, client, err := newRecordClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := doRESTRequest(client, accountID, http.MethodPost, recordResource(recordType, ""), body)
+	if err != nil {
+		return err
+	}
+
+	if location := resp.Header.Get("Location"); location != "" {
+		logInfo("Created %s %s", recordType, path.Base(location))
+		return nil
+	}
+	logInfo("Created %s", recordType)
+	return nil
+}
+
+// runRecordUpdate updates the fields of a record given in the JSON body.
+func runRecordUpdate(recordType, id string) error {
+	body, err := readRecordBody()
+	if err != nil {
+		return err
+	}
+	accountID, client, err := newRecordClient()
+	if err != nil {
+		return err
+	}
+
+	if _, err := doRESTRequest(client, accountID, http.MethodPatch, recordResource(recordType, id), body); err != nil {
+		return err
+	}
+	logInfo("Updated %s %s", recordType, id)
+	return nil
+}
+
+// runRecordDelete deletes a record after confirmation.
+func runRecordDelete(recordType, id string) error {
+	if !recordYesFlag {
+		confirmed, err := promptConfirm(bufio.NewReader(os.Stdin), fmt.Sprintf("Delete %s %s? (y/N): ", recordType, id))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Aborted.")
+			return errCancelled
+		}
+	}
+
+	accountID, client, err := newRecordClient()
+	if err != nil {
+		return err
+	}
+	if _, err := doRESTRequest(client, accountID, http.MethodDelete, recordResource(recordType, id), nil); err != nil {
+		return err
+	}
+	logInfo("Deleted %s %s", recordType, id)
+	return nil
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// standardRecordTypes lists the standard record types accepted by SDF script deployments. It is
+// used when the project has no metadata cache, see 'netsuite-cli meta sync'.
+var standardRecordTypes = []string{
+	"ACCOUNT", "ASSEMBLYBUILD", "ASSEMBLYITEM", "ASSEMBLYUNBUILD", "BILLINGSCHEDULE", "BIN",
+	"CALENDAREVENT", "CASHREFUND", "CASHSALE", "CHARGE", "CHECK", "CLASSIFICATION", "CONTACT",
+	"COUPONCODE", "CREDITMEMO", "CURRENCY", "CUSTOMER", "CUSTOMERDEPOSIT", "CUSTOMERPAYMENT",
+	"CUSTOMERREFUND", "DEPARTMENT", "DEPOSIT", "DEPOSITAPPLICATION", "DESCRIPTIONITEM",
+	"DISCOUNTITEM", "EMPLOYEE", "ESTIMATE", "EXPENSEREPORT", "GIFTCERTIFICATEITEM",
+	"INTERCOMPANYJOURNALENTRY", "INVENTORYADJUSTMENT", "INVENTORYITEM", "INVENTORYTRANSFER",
+	"INVOICE", "ISSUE", "ITEMFULFILLMENT", "ITEMGROUP", "ITEMRECEIPT", "JOB", "JOURNALENTRY",
+	"KITITEM", "LEAD", "LOCATION", "MARKUPITEM", "MESSAGE", "NONINVENTORYITEM", "NOTE",
+	"OPPORTUNITY", "OTHERCHARGEITEM", "PARTNER", "PAYMENTITEM", "PHONECALL", "PRICELEVEL",
+	"PROJECTTASK", "PROSPECT", "PURCHASEORDER", "PURCHASEREQUISITION", "RETURNAUTHORIZATION",
+	"SALESORDER", "SERVICEITEM", "SOLUTION", "STATISTICALJOURNALENTRY", "SUBSIDIARY",
+	"SUBTOTALITEM", "SUPPORTCASE", "TASK", "TERM", "TIMEBILL", "TOPIC", "TRANSFERORDER",
+	"UNITSTYPE", "VENDOR", "VENDORBILL", "VENDORCREDIT", "VENDORPAYMENT",
+	"VENDORRETURNAUTHORIZATION", "WORKORDER", "WORKORDERCLOSE", "WORKORDERCOMPLETION",
+	"WORKORDERISSUE",
+}
+
+// customRecordTypePrefixes lists the script ID prefixes of record types defined in the account,
+// which are accepted without validation.
+var customRecordTypePrefixes = []string{"customrecord", "customtransaction"}
+
+// knownRecordTypes returns the record types accepted for script deployments: the standard
+// record types plus the record types in the project's metadata cache, if any.
+func knownRecordTypes() []string {
+	seen := make(map[string]bool)
+	var types []string
+	add := func(name string) {
+		name = strings.ToUpper(name)
+		if !seen[name] {
+			seen[name] = true
+			types = append(types, name)
+		}
+	}
+
+	for _, name := range standardRecordTypes {
+		add(name)
+	}
+	if cache, err := LoadMetadataCache(); err == nil && cache != nil {
+		for _, name := range cache.RecordNames() {
+			if !isCustomRecordType(name) {
+				add(name)
+			}
+		}
+	}
+
+	sort.Strings(types)
+	return types
+}
+
+// isCustomRecordType reports whether the record type is a custom record or transaction type.
+func isCustomRecordType(recordType string) bool {
+	lower := strings.ToLower(strings.Trim(recordType, "[]"))
+	for _, prefix := range customRecordTypePrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveRecordType validates a record type entered by the user and returns it in canonical form.
+// When the record type is unknown, the error lists the closest known record types.
+func resolveRecordType(recordType string) (string, error) {
+	recordType = strings.TrimSpace(recordType)
+	if isCustomRecordType(recordType) {
+		return strings.ToLower(recordType), nil
+	}
+
+	known := knownRecordTypes()
+	upper := strings.ToUpper(recordType)
+	if containsString(known, upper) {
+		return upper, nil
+	}
+
+	if suggestions := suggestRecordTypes(upper, known); len(suggestions) > 0 {
+		return "", fmt.Errorf("'%s' is not a known record type, did you mean: %s?", recordType, strings.Join(suggestions, ", "))
+	}
+	return "", fmt.Errorf("'%s' is not a known record type, run 'netsuite-cli meta sync' to refresh the account record types", recordType)
+}
+
+// suggestRecordTypes returns up to five known record types starting with, or close to, input.
+func suggestRecordTypes(input string, known []string) []string {
+	const maxSuggestions = 5
+
+	var suggestions []string
+	for _, name := range known {
+		if strings.HasPrefix(name, input) {
+			suggestions = append(suggestions, name)
+		}
+	}
+
+	type candidate struct {
+		name     string
+		distance int
+	}
+	var candidates []candidate
+	threshold := max(2, len(input)/4)
+	for _, name := range known {
+		if containsString(suggestions, name) {
+			continue
+		}
+		if distance := levenshtein(input, name); distance <= threshold {
+			candidates = append(candidates, candidate{name, distance})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+	for _, c := range candidates {
+		suggestions = append(suggestions, c.name)
+	}
+
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+	return suggestions
+}
+
+// levenshtein returns the edit distance between two strings.
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
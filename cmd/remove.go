@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"netsuite-cli/pkg/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+var removeYesFlag bool
+
+// removeCmd represents the remove command
+var removeCmd = &cobra.Command{
+	Use:   "remove <script-name>",
+	Short: "Remove a script and its object XML",
+	Long: `Delete the TypeScript file generated for a script under SuiteScripts,
+the matching object XML under Objects, and any deploy.xml references to them.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRemove(args[0])
+	},
+}
+
+func init() {
+	removeCmd.Flags().BoolVarP(&removeYesFlag, "yes", "y", false, "Delete without asking for confirmation")
+
+	rootCmd.AddCommand(removeCmd)
+}
+
+// ScriptFiles holds the files that make up a generated script.
+type ScriptFiles struct {
+	Name       string
+	ScriptType string
+	TSPath     string
+	XMLPath    string
+}
+
+// scriptTypeNames returns the names of the script types followed by their aliases, which the
+// files of scripts generated under an alias by earlier releases are named with.
+func scriptTypeNames() []string {
+	var names []string
+	for _, t := range scaffold.ScriptTypes {
+		names = append(names, t.Name)
+	}
+	for alias := range scaffold.ScriptTypeAliases {
+		names = append(names, alias)
+	}
+	return names
+}
+
+// locateScript finds the TypeScript and object XML files generated for a script name.
+func locateScript(config *ProjectConfig, scriptName string) (*ScriptFiles, error) {
+	suiteScriptsDir, err := findSuiteScriptsDir()
+	if err != nil {
+		return nil, err
+	}
+	objectsDir, err := findObjectsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := config.Prefix()
+	baseName := strings.TrimSuffix(strings.TrimSuffix(scriptName, ".ts"), ".xml")
+	if !strings.HasPrefix(baseName, prefix+"_") {
+		baseName = prefix + "_" + baseName
+	}
+
+	files := &ScriptFiles{Name: strings.TrimPrefix(baseName, prefix+"_")}
+
+	var tsMatches []string
+	err = filepath.WalkDir(suiteScriptsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		name := d.Name()
+		ext := filepath.Ext(name)
+		if ext != ".ts" {
+			return nil
+		}
+		stem := strings.TrimSuffix(name, ext)
+		for _, scriptType := range scriptTypeNames() {
+			if naming, err := config.ScriptNaming(files.Name, scriptType); err == nil && stem == naming.FileName {
+				tsMatches = append(tsMatches, path)
+				files.ScriptType = scaffold.ResolveScriptType(scriptType)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error searching %s: %v", suiteScriptsDir, err)
+	}
+	if len(tsMatches) > 1 {
+		return nil, fmt.Errorf("script name '%s' is ambiguous, found: %s", scriptName, strings.Join(tsMatches, ", "))
+	}
+	if len(tsMatches) == 1 {
+		files.TSPath = tsMatches[0]
+	}
+
+	var xmlMatches []string
+	err = filepath.WalkDir(objectsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		stem := strings.TrimSuffix(d.Name(), ".xml")
+		for _, scriptType := range scriptTypeNames() {
+			if naming, err := config.ScriptNaming(files.Name, scriptType); err == nil && stem == naming.ObjectFileName {
+				xmlMatches = append(xmlMatches, path)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error searching %s: %v", objectsDir, err)
+	}
+	if len(xmlMatches) > 1 {
+		return nil, fmt.Errorf("script name '%s' is ambiguous, found: %s", scriptName, strings.Join(xmlMatches, ", "))
+	}
+	if len(xmlMatches) == 1 {
+		files.XMLPath = xmlMatches[0]
+	}
+
+	if files.TSPath == "" && files.XMLPath == "" {
+		return nil, fmt.Errorf("no files found for script '%s'", scriptName)
+	}
+
+	return files, nil
+}
+
+// compiledSiblings returns the SDF paths of a script file and its compiled JavaScript output.
+func compiledSiblings(tsPath string) []string {
+	sdfPath := toSDFPath(tsPath)
+	return []string{sdfPath, strings.TrimSuffix(sdfPath, ".ts") + ".js"}
+}
+
+// runRemove executes the logic for removing a script.
+func runRemove(scriptName string) error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	files, err := locateScript(config, scriptName)
+	if err != nil {
+		return err
+	}
+
+	var toDelete []string
+	var deployRefs []string
+	if files.TSPath != "" {
+		toDelete = append(toDelete, files.TSPath)
+		jsPath := strings.TrimSuffix(files.TSPath, ".ts") + ".js"
+		if _, err := os.Stat(jsPath); err == nil {
+			toDelete = append(toDelete, jsPath)
+		}
+		deployRefs = append(deployRefs, compiledSiblings(files.TSPath)...)
+	}
+	if files.XMLPath != "" {
+		toDelete = append(toDelete, files.XMLPath)
+		deployRefs = append(deployRefs, toSDFPath(files.XMLPath))
+	}
+
+	deployXMLPath, hasDeployXML := findDeployXML()
+
+	fmt.Println("The following files will be deleted:")
+	for _, path := range toDelete {
+		fmt.Printf("  %s\n", path)
+	}
+	if hasDeployXML {
+		fmt.Printf("References to these files will be removed from %s\n", deployXMLPath)
+	}
+
+	if !removeYesFlag {
+		reader := bufio.NewReader(os.Stdin)
+		promptf("Continue? (y/n): ")
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("error reading response: %v", err)
+		}
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Cancelled. No files were removed.")
+			return errCancelled
+		}
+	}
+
+	for _, path := range toDelete {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("error deleting %s: %v", path, err)
+		}
+		reportFile("Deleted", path, "")
+	}
+
+	if hasDeployXML {
+		removed, err := removeDeployXMLReferences(deployXMLPath, deployRefs)
+		if err != nil {
+			logWarn("Failed to update %s: %v", deployXMLPath, err)
+		} else if removed > 0 {
+			logInfo("Removed %d reference(s) from %s", removed, deployXMLPath)
+		}
+	}
+	return nil
+}
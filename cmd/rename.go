@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"netsuite-cli/pkg/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+// renameCmd represents the rename command
+var renameCmd = &cobra.Command{
+	Use:   "rename <old-name> <new-name>",
+	Short: "Rename a script and update its references",
+	Long: `Rename the TypeScript file and object XML of a script, update the scriptid,
+deploymentid and scriptfile path inside the XML, and fix deploy.xml references.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRename(args[0], args[1])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(renameCmd)
+}
+
+// runRename executes the logic for renaming a script.
+func runRename(oldName, newName string) error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return errors.New("new script name is required")
+	}
+
+	files, err := locateScript(config, oldName)
+	if err != nil {
+		return err
+	}
+
+	oldNaming, err := config.ScriptNaming(files.Name, files.ScriptType)
+	if err != nil {
+		return err
+	}
+	newNaming, err := config.ScriptNaming(newName, files.ScriptType)
+	if err != nil {
+		return namingError(err)
+	}
+
+	replacements := []string{
+		oldNaming.ScriptId, newNaming.ScriptId,
+		oldNaming.DeploymentId, newNaming.DeploymentId,
+	}
+
+	renames := make(map[string]string)
+	deployRenames := make(map[string]string)
+
+	if files.TSPath != "" {
+		oldBase := strings.TrimSuffix(filepath.Base(files.TSPath), ".ts")
+		newBase := newNaming.FileName
+		replacements = append(replacements, oldBase+".ts", newBase+".ts", oldBase+".js", newBase+".js")
+
+		newTSPath := filepath.Join(filepath.Dir(files.TSPath), newBase+".ts")
+		renames[files.TSPath] = newTSPath
+		oldSDF, newSDF := compiledSiblings(files.TSPath), compiledSiblings(newTSPath)
+		for i := range oldSDF {
+			deployRenames[oldSDF[i]] = newSDF[i]
+		}
+
+		jsPath := strings.TrimSuffix(files.TSPath, ".ts") + ".js"
+		if _, err := os.Stat(jsPath); err == nil {
+			renames[jsPath] = filepath.Join(filepath.Dir(jsPath), newBase+".js")
+		}
+	}
+
+	var newXMLPath string
+	if files.XMLPath != "" {
+		newXMLPath = filepath.Join(filepath.Dir(files.XMLPath), newNaming.ObjectFileName+".xml")
+		renames[files.XMLPath] = newXMLPath
+		deployRenames[toSDFPath(files.XMLPath)] = toSDFPath(newXMLPath)
+	}
+
+	for _, newPath := range renames {
+		if _, err := os.Stat(newPath); err == nil {
+			return fmt.Errorf("%s already exists", newPath)
+		}
+	}
+
+	replace := wholeWordReplacer(replacements...)
+
+	if files.TSPath != "" {
+		err := rewriteFile(files.TSPath, func(content string) string {
+			content = replace(content)
+			return strings.Replace(content, "@NScriptName "+files.Name+"\n", "@NScriptName "+newName+"\n", 1)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if files.XMLPath != "" {
+		err := rewriteFile(files.XMLPath, func(content string) string {
+			content = replace(content)
+			content = strings.Replace(content, "<name>"+scaffold.EscapeXML(files.Name)+"</name>", "<name>"+scaffold.EscapeXML(newName)+"</name>", 1)
+			return strings.ReplaceAll(content, "<title>"+scaffold.EscapeXML(files.Name)+"</title>", "<title>"+scaffold.EscapeXML(newName)+"</title>")
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for oldPath, newPath := range renames {
+		if err := os.Rename(oldPath, newPath); err != nil {
+			return fmt.Errorf("error renaming %s: %v", oldPath, err)
+		}
+		if !addJSONFile(JSONFile{Path: newPath, Action: "renamed", From: oldPath}) {
+			logInfo("Renamed %s -> %s", oldPath, newPath)
+		}
+	}
+
+	if deployXMLPath, ok := findDeployXML(); ok {
+		changed, err := rewriteDeployXMLReferences(deployXMLPath, func(path string) (string, bool) {
+			if newPath, ok := deployRenames[path]; ok {
+				return newPath, true
+			}
+			return path, true
+		})
+		if err != nil {
+			logWarn("Failed to update %s: %v", deployXMLPath, err)
+		} else if changed > 0 {
+			logInfo("Updated %d reference(s) in %s", changed, deployXMLPath)
+		}
+	}
+	return nil
+}
+
+// wholeWordReplacer returns a function replacing each old string of the old, new pairs with the
+// new one where it is a whole word, so that an ID such as customscript_x_sl_foo is renamed without
+// touching customscript_x_sl_foo_bar.
+func wholeWordReplacer(pairs ...string) func(string) string {
+	replace := make(map[string]string)
+	var olds []string
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if _, ok := replace[pairs[i]]; ok || pairs[i] == "" {
+			continue
+		}
+		replace[pairs[i]] = pairs[i+1]
+		olds = append(olds, pairs[i])
+	}
+	if len(olds) == 0 {
+		return func(s string) string { return s }
+	}
+	// Longer strings first, so one that starts with another is matched whole.
+	sort.Slice(olds, func(i, j int) bool { return len(olds[i]) > len(olds[j]) })
+	for i, old := range olds {
+		olds[i] = regexp.QuoteMeta(old)
+	}
+	re := regexp.MustCompile(`\b(?:` + strings.Join(olds, "|") + `)\b`)
+	return func(s string) string {
+		return re.ReplaceAllStringFunc(s, func(match string) string { return replace[match] })
+	}
+}
+
+// rewriteFile applies fn to the contents of the file at path and writes the result back.
+func rewriteFile(path string, fn func(content string) string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", path, err)
+	}
+
+	if err := os.WriteFile(path, []byte(fn(string(data))), 0644); err != nil {
+		return fmt.Errorf("error writing file %s: %v", path, err)
+	}
+	return nil
+}
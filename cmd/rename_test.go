@@ -0,0 +1,25 @@
+package cmd
+
+import "testing"
+
+func TestWholeWordReplacer(t *testing.T) {
+	replace := wholeWordReplacer(
+		"customscript_x_sl_foo", "customscript_x_sl_baz",
+		"customdeploy_x_sl_foo", "customdeploy_x_sl_baz",
+		"x_foo_suitelet.ts", "x_baz_suitelet.ts",
+	)
+	tests := map[string]string{
+		`<suitelet scriptid="customscript_x_sl_foo">`:    `<suitelet scriptid="customscript_x_sl_baz">`,
+		`scriptid="customdeploy_x_sl_foo"`:               `scriptid="customdeploy_x_sl_baz"`,
+		`scriptid="customscript_x_sl_foo_bar"`:           `scriptid="customscript_x_sl_foo_bar"`,
+		`[scriptid=customscript_x_sl_foo2]`:              `[scriptid=customscript_x_sl_foo2]`,
+		`<scriptfile>[/SuiteScripts/x_foo_suitelet.ts]`:  `<scriptfile>[/SuiteScripts/x_baz_suitelet.ts]`,
+		`<scriptfile>[/SuiteScripts/x_foo_suitelet.tsx]`: `<scriptfile>[/SuiteScripts/x_foo_suitelet.tsx]`,
+		"customscript_x_sl_foo\ncustomscript_x_sl_foo_b": "customscript_x_sl_baz\ncustomscript_x_sl_foo_b",
+	}
+	for in, want := range tests {
+		if got := replace(in); got != want {
+			t.Errorf("replace(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -8,8 +9,9 @@ import (
 )
 
 var (
-	verboseFlag bool
-	quietFlag   bool
+	verboseFlag    bool
+	quietFlag      bool
+	projectDirFlag string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -17,21 +19,92 @@ var rootCmd = &cobra.Command{
 	Use:   "netsuite-cli",
 	Short: "A CLI for managing NetSuite projects",
 	Long:  `A CLI for managing NetSuite projects, including project creation and setup.`,
+	// Errors are printed by Execute. Usage is only printed for invalid arguments and flags,
+	// which are checked before the pre-run hooks.
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		startTimeout()
+		if err := enterProjectDir(); err != nil {
+			return err
+		}
+		return enterWorkspaceProject()
+	},
+}
+
+// enterProjectDir changes to the directory given with --project-dir, so commands run as if
+// started there. Paths given on the command line are then relative to it, as with git -C.
+func enterProjectDir() error {
+	if projectDirFlag == "" {
+		return nil
+	}
+	dir := projectDirFlag
+	projectDirFlag = ""
+	if err := os.Chdir(dir); err != nil {
+		return configError(fmt.Errorf("cannot change to project directory: %v", err))
+	}
+	return nil
 }
 
-// Execute adds all child commands to the root command and sets flags appropriately.
+// Execute adds all child commands to the root command and sets flags appropriately. Unknown
+// commands matching a netsuite-cli-<name> executable on the PATH are run as plugins.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// Commands return their errors here; the process exit code is derived from the error type.
 func Execute() {
-	err := rootCmd.Execute()
-	if err != nil {
-		if !quietFlag {
+	args := os.Args[1:]
+	rootCmd.SetArgs(args)
+	openLogFile(args)
+	if path, ok := lookupPlugin(args); ok {
+		logDebug("running plugin %s", path)
+		code, err := runPlugin(path, args[1:])
+		if err != nil {
+			writeLog(levelError, "%v", err)
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		}
-		os.Exit(1)
+		closeLogFile(code)
+		os.Exit(code)
 	}
+
+	done := make(chan struct{})
+	handleInterrupts(done)
+	registerCompletions(rootCmd)
+	command, err := executeCommand(args)
+	err = contextError(err)
+	close(done)
+	stopTimeout()
+	killStoppedProcesses()
+	if err == nil {
+		notifyUpdate(command)
+	}
+	code := exitCode(err)
+	if err != nil && !errors.Is(err, errCancelled) && !errors.Is(err, errInterrupted) {
+		writeLog(levelError, "%v", err)
+		if jsonFlag || containsString(os.Args[1:], "--json") {
+			jsonFlag = true
+			beginJSONOutput()
+			addJSONError(err.Error())
+		} else {
+			noColorFlag = noColorFlag || containsString(os.Args[1:], "--no-color")
+			fmt.Fprintln(os.Stderr, colorize(os.Stderr, colorRed, fmt.Sprintf("Error: %v", err)))
+		}
+	}
+	recordTelemetry(command, code)
+	finishJSONOutput(code)
+	closeLogFile(code)
+	os.Exit(code)
 }
 
 func init() {
-	rootCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "v", false, "Enable verbose output")
+	cobra.OnInitialize(beginJSONOutput)
+	cobra.EnableTraverseRunHooks = true
+
+	rootCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "v", false, "Print debug messages: external commands, templates and file decisions")
 	rootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress non-error output")
+	rootCmd.PersistentFlags().BoolVar(&noColorFlag, "no-color", false, "Disable colored output (also disabled when NO_COLOR is set or the output is not a terminal)")
+	rootCmd.PersistentFlags().BoolVar(&jsonFlag, "json", false, "Emit a machine-readable JSON document instead of free-form output")
+	rootCmd.PersistentFlags().StringVarP(&projectDirFlag, "project-dir", "C", "", "Run as if started in this directory instead of the current one")
+	rootCmd.PersistentFlags().StringVarP(&projectFlag, "project", "p", "", "Run in the named project of the workspace")
+	rootCmd.PersistentFlags().DurationVar(&timeoutFlag, "timeout", 0, "Stop the command and its external commands after this long, e.g. 10m (default: no limit)")
+	rootCmd.PersistentFlags().BoolVar(&rawFlag, "raw", false, "Pass the output of the SuiteCloud CLI through unchanged instead of summarizing it")
+	rootCmd.PersistentFlags().BoolVar(&npxFlag, "npx", false, "Run the SuiteCloud CLI through npx when it is not installed globally")
 }
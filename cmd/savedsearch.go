@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"netsuite-cli/pkg/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	savedSearchSpecFlag    string
+	savedSearchTitleFlag   string
+	savedSearchColumnFlags []string
+	savedSearchFilterFlags []string
+	savedSearchPublicFlag  bool
+)
+
+// savedSearchCmd represents the add savedsearch command
+var savedSearchCmd = &cobra.Command{
+	Use:   "savedsearch [name]",
+	Short: "Saved searches define reusable record queries with columns and filters",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAddSavedSearch(args)
+	},
+}
+
+func init() {
+	savedSearchCmd.Flags().StringVar(&savedSearchSpecFlag, "spec", "", "JSON file describing the search (recordType, title, columns, filters)")
+	savedSearchCmd.Flags().StringVar(&savedSearchTitleFlag, "title", "", "Search title (default: the search name)")
+	savedSearchCmd.Flags().StringArrayVar(&savedSearchColumnFlags, "column", nil, "Result column in the form field[:label[:summary]] (repeatable)")
+	savedSearchCmd.Flags().StringArrayVar(&savedSearchFilterFlags, "filter", nil, "Filter in the form field:operator[:value,...] (repeatable)")
+	savedSearchCmd.Flags().BoolVar(&savedSearchPublicFlag, "public", false, "Make the search public")
+
+	addCmd.AddCommand(savedSearchCmd)
+}
+
+// loadSavedSearchSpec reads a saved search spec from a JSON file.
+func loadSavedSearchSpec(path string) (*scaffold.SavedSearchSpec, error) {
+	data, err := os.ReadFile(projectRelativePath(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading spec file: %v", err)
+	}
+
+	var spec scaffold.SavedSearchSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("error parsing spec file: %v", err)
+	}
+	return &spec, nil
+}
+
+// savedSearchOptions holds the values given for a saved search on the command line.
+type savedSearchOptions struct {
+	spec       string
+	title      string
+	recordType string
+	columns    []string
+	filters    []string
+	public     bool
+}
+
+// savedSearchFlags returns the options set with the add savedsearch flags.
+func savedSearchFlags() savedSearchOptions {
+	return savedSearchOptions{
+		spec:       savedSearchSpecFlag,
+		title:      savedSearchTitleFlag,
+		recordType: recordTypeFlag,
+		columns:    savedSearchColumnFlags,
+		filters:    savedSearchFilterFlags,
+		public:     savedSearchPublicFlag,
+	}
+}
+
+// addCompanionSearch generates the saved search providing the default filter of a mass update
+// script and returns its script ID. The search is named and titled after the script and searches
+// its record type, or the custom record type itself; the columns and filters not given with
+// --search-filter are prompted for.
+func addCompanionSearch(reader *bufio.Reader, scriptName, recordType string) (string, error) {
+	searchType := scaffold.SearchType(recordType)
+	if searchType == "" && isCustomRecordType(recordType) {
+		searchType = strings.ToLower(strings.Trim(recordType, "[]"))
+	}
+	logInfo("Generating the companion saved search of %s", scriptName)
+	return addSavedSearch(reader, []string{scriptName}, savedSearchOptions{
+		title:      scriptName,
+		recordType: searchType,
+		filters:    searchFilters,
+	})
+}
+
+// runAddSavedSearch executes the logic for adding a new saved search.
+func runAddSavedSearch(args []string) error {
+	_, err := addSavedSearch(bufio.NewReader(os.Stdin), args, savedSearchFlags())
+	return err
+}
+
+// addSavedSearch generates a saved search, prompting for what the options leave out, and
+// returns its script ID.
+func addSavedSearch(reader *bufio.Reader, args []string, opts savedSearchOptions) (string, error) {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return "", err
+	}
+
+	searchName := ""
+	if len(args) > 0 {
+		searchName = strings.TrimSpace(args[0])
+	}
+	if searchName == "" && !yesFlag {
+		searchName, err = promptLine(reader, "Enter saved search name: ")
+		if err != nil {
+			return "", err
+		}
+	}
+	searchName = strings.TrimPrefix(searchName, "customsearch_")
+	if searchName == "" {
+		return "", errors.New("saved search name is required")
+	}
+	search := scaffold.SavedSearch{Name: searchName}
+	scriptId, err := search.ScriptId(config)
+	if err != nil {
+		return "", validationError("%v", err)
+	}
+
+	spec := &scaffold.SavedSearchSpec{}
+	if opts.spec != "" {
+		spec, err = loadSavedSearchSpec(opts.spec)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if title := strings.TrimSpace(opts.title); title != "" {
+		spec.Title = title
+	}
+	if spec.Title == "" && !yesFlag && opts.spec == "" {
+		if spec.Title, err = promptLine(reader, fmt.Sprintf("Enter search title (default: %s): ", searchName)); err != nil {
+			return "", err
+		}
+	}
+	spec.Public = spec.Public || opts.public
+
+	if recordType := strings.TrimSpace(opts.recordType); recordType != "" {
+		spec.RecordType = recordType
+	}
+	if spec.RecordType == "" && !yesFlag {
+		if spec.RecordType, err = promptLine(reader, "Enter search record type (e.g., Transaction, Customer, Item): "); err != nil {
+			return "", err
+		}
+	}
+	if spec.RecordType == "" {
+		return "", errors.New("record type is required for saved searches")
+	}
+
+	for _, columnSpec := range opts.columns {
+		column, err := scaffold.ParseSavedSearchColumn(columnSpec)
+		if err != nil {
+			return "", fmt.Errorf("invalid --column '%s': %v", columnSpec, err)
+		}
+		spec.Columns = append(spec.Columns, column)
+	}
+	if len(spec.Columns) == 0 && !yesFlag {
+		for {
+			columnSpec, err := promptLine(reader, "Result column as field[:label[:summary]] (leave empty to finish): ")
+			if err != nil {
+				return "", err
+			}
+			if columnSpec == "" {
+				break
+			}
+			column, err := scaffold.ParseSavedSearchColumn(columnSpec)
+			if err != nil {
+				promptf("Invalid column: %v\n", err)
+				continue
+			}
+			spec.Columns = append(spec.Columns, column)
+		}
+	}
+
+	for _, filterSpec := range opts.filters {
+		filter, err := scaffold.ParseSavedSearchFilter(filterSpec)
+		if err != nil {
+			return "", fmt.Errorf("invalid --filter '%s': %v", filterSpec, err)
+		}
+		spec.Filters = append(spec.Filters, filter)
+	}
+	if len(spec.Filters) == 0 && !yesFlag && opts.spec == "" {
+		for {
+			filterSpec, err := promptLine(reader, "Filter as field:operator[:value,...] (e.g., mainline:IS:T, leave empty to finish): ")
+			if err != nil {
+				return "", err
+			}
+			if filterSpec == "" {
+				break
+			}
+			filter, err := scaffold.ParseSavedSearchFilter(filterSpec)
+			if err != nil {
+				promptf("Invalid filter: %v\n", err)
+				continue
+			}
+			spec.Filters = append(spec.Filters, filter)
+		}
+	}
+
+	search.Spec = *spec
+	if err := checkObjectIdAvailable(scriptId); err != nil {
+		return "", err
+	}
+
+	generator, err := objectGenerator(config)
+	if err != nil {
+		return "", err
+	}
+	file, err := generator.SavedSearch(search)
+	if err != nil {
+		return "", err
+	}
+	if _, err := writeObject(reader, file); err != nil {
+		return "", err
+	}
+	return scriptId, nil
+}
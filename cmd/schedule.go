@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"netsuite-cli/pkg/scaffold"
+)
+
+// promptDeploymentSchedule interactively collects the recurrence of a scheduled script deployment.
+func promptDeploymentSchedule(reader *bufio.Reader) (scaffold.DeploymentSchedule, error) {
+	for {
+		frequency, err := promptLine(reader, fmt.Sprintf("Enter schedule recurrence [%s] (default: none): ", strings.Join(scaffold.ScheduleFrequencies, ", ")))
+		if err != nil {
+			return scaffold.DeploymentSchedule{}, err
+		}
+		frequency = strings.ToLower(frequency)
+
+		var startTime, days, interval string
+		if frequency != "" && frequency != "none" {
+			if startTime, err = promptLine(reader, "Enter start time in UTC (HH:MM, default: 23:00): "); err != nil {
+				return scaffold.DeploymentSchedule{}, err
+			}
+		}
+		switch frequency {
+		case "weekly":
+			days, err = promptLine(reader, "Enter days of the week (e.g., mon,wed,fri): ")
+		case "minutes":
+			interval, err = promptLine(reader, "Repeat every N minutes [15, 30, 60, 120, 240, 360, 480, 720] (default: 15): ")
+		}
+		if err != nil {
+			return scaffold.DeploymentSchedule{}, err
+		}
+
+		schedule, err := scaffold.NewDeploymentSchedule(frequency, startTime, days, interval)
+		if err != nil {
+			promptf("Invalid schedule: %v\n", err)
+			continue
+		}
+		return schedule, nil
+	}
+}
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// rawFlag passes the output of the SuiteCloud CLI through unchanged instead of summarizing it.
+var rawFlag bool
+
+// SuiteCloudChange is an object or file changed in the account by a SuiteCloud CLI command.
+type SuiteCloudChange struct {
+	Action string `json:"action"`
+	Kind   string `json:"kind"`
+	Target string `json:"target"`
+}
+
+// SuiteCloudResult is the parsed output of a SuiteCloud CLI command.
+type SuiteCloudResult struct {
+	// Info holds the "Info -- Name [value]" lines, e.g. the account and project deployed to.
+	Info map[string]string `json:"info,omitempty"`
+	// Steps is the number of validation steps passed before a deployment.
+	Steps int `json:"validationSteps,omitempty"`
+	// Changes lists the objects and files created, updated or deleted by a deployment.
+	Changes []SuiteCloudChange `json:"changes,omitempty"`
+	// Lists holds the items of the "The following ... were ...:" sections, e.g. the files
+	// uploaded or the objects imported, by section.
+	Lists    map[string][]string `json:"lists,omitempty"`
+	Warnings []string            `json:"warnings,omitempty"`
+	Errors   []string            `json:"errors,omitempty"`
+	// Output is the unparsed output.
+	Output string `json:"-"`
+}
+
+var (
+	sdfANSIRe    = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+	sdfInfoRe    = regexp.MustCompile(`^Info -- (.+?) \[(.*)\]$`)
+	sdfStepRe    = regexp.MustCompile(`^(Validat\w+ .+?|\w+ validation) -- (\w+)\.?$`)
+	sdfChangeRe  = regexp.MustCompile(`^(Create|Update|Delete|Add|Remove|Install|Upgrade) (object|file|folder|record|translation\w*) -- (.+)$`)
+	sdfListRe    = regexp.MustCompile(`(?i)^The following (.+?) (?:were|was|will be) (not )?(\w+)\s*:$`)
+	sdfItemRe    = regexp.MustCompile(`^[-*•]\s+(.+)$`)
+	sdfDoneRe    = regexp.MustCompile(`(?i)^(.*\bInstallation COMPLETE|The deployment process has finished|.*completed successfully)`)
+	sdfPromptRe  = regexp.MustCompile(`(?i)(\?|:|\(y/n\)|\[y/n\])\s*$`)
+	sdfSuccessRe = regexp.MustCompile(`(?i)^(success|ok|passed|completed?)$`)
+)
+
+// suiteCloudOutput parses the output of a SuiteCloud CLI command as it is written. Unless quiet,
+// it prints it as concise messages: the information lines, the number of validation steps
+// passed, the objects and files changed or listed, warnings and error blocks. The banners and
+// validation steps themselves are only printed with --verbose. Prompts are passed through.
+type suiteCloudOutput struct {
+	mu      sync.Mutex
+	quiet   bool
+	partial []byte
+	raw     bytes.Buffer
+	steps   int
+	list    string
+	failed  bool
+	errors  []string
+	result  SuiteCloudResult
+}
+
+// Write parses the complete lines of p.
+func (o *suiteCloudOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.raw.Write(p)
+	o.partial = append(o.partial, p...)
+	for {
+		i := bytes.IndexByte(o.partial, '\n')
+		if i < 0 {
+			break
+		}
+		o.parseLine(string(o.partial[:i]))
+		o.partial = o.partial[i+1:]
+	}
+	if !o.quiet && len(o.partial) > 0 && sdfPromptRe.Match(o.partial) {
+		o.flushError()
+		fmt.Print(string(o.partial))
+		o.partial = nil
+	}
+	return len(p), nil
+}
+
+// Close parses the last line and prints the pending messages.
+func (o *suiteCloudOutput) Close() SuiteCloudResult {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.partial) > 0 {
+		o.parseLine(string(o.partial))
+		o.partial = nil
+	}
+	o.flushError()
+	o.flushSteps()
+	o.result.Output = o.raw.String()
+	return o.result
+}
+
+// parseLine classifies a line of output.
+func (o *suiteCloudOutput) parseLine(line string) {
+	line = strings.TrimSpace(sdfANSIRe.ReplaceAllString(strings.TrimSuffix(line, "\r"), ""))
+	if i := strings.LastIndex(line, "\r"); i >= 0 {
+		line = strings.TrimSpace(line[i+1:])
+	}
+	if line == "" {
+		o.flushError()
+		o.list = ""
+		return
+	}
+
+	if m := sdfStepRe.FindStringSubmatch(line); m != nil {
+		o.flushError()
+		if sdfSuccessRe.MatchString(m[2]) {
+			o.steps++
+			o.debug("%s", line)
+			return
+		}
+		o.errorLine(line)
+		return
+	}
+	o.flushSteps()
+
+	switch m := validateSeverityRe.FindStringSubmatch(line); {
+	case m != nil && m[2] == "", validateBannerRe.MatchString(line):
+		// "*** ERROR ***" and "Validation failed." only announce the errors that follow them.
+		o.flushError()
+		o.debug("%s", line)
+		return
+	case m != nil && strings.EqualFold(m[1], "warning"):
+		o.flushError()
+		o.warn("%s", m[2])
+		return
+	case m != nil, validateFailureRe.MatchString(line):
+		o.flushError()
+		o.errorLine(line)
+		return
+	}
+	if o.failed {
+		o.errors = append(o.errors, line)
+		return
+	}
+
+	if m := sdfInfoRe.FindStringSubmatch(line); m != nil {
+		if o.result.Info == nil {
+			o.result.Info = map[string]string{}
+		}
+		o.result.Info[m[1]] = m[2]
+		o.info("%s: %s", m[1], m[2])
+		return
+	}
+	if m := sdfChangeRe.FindStringSubmatch(line); m != nil {
+		o.result.Changes = append(o.result.Changes, SuiteCloudChange{Action: strings.ToLower(m[1]), Kind: m[2], Target: m[3]})
+		o.info("  %s %s %s", m[1], m[2], m[3])
+		return
+	}
+	if m := sdfListRe.FindStringSubmatch(line); m != nil {
+		o.list = strings.ToLower(m[1] + " " + m[2] + m[3])
+		if m[2] == "" {
+			o.info("%s:", sentenceCase(o.list))
+		}
+		return
+	}
+	if m := sdfItemRe.FindStringSubmatch(line); m != nil && o.list != "" {
+		if o.result.Lists == nil {
+			o.result.Lists = map[string][]string{}
+		}
+		o.result.Lists[o.list] = append(o.result.Lists[o.list], m[1])
+		if strings.Contains(o.list, " not ") {
+			o.warn("%s: %s", sentenceCase(o.list), m[1])
+		} else {
+			o.info("  %s", m[1])
+		}
+		return
+	}
+	if sdfDoneRe.MatchString(line) {
+		o.info("%s", line)
+		return
+	}
+	o.debug("%s", line)
+}
+
+// sentenceCase capitalizes the first letter of text.
+func sentenceCase(text string) string {
+	if text == "" {
+		return text
+	}
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+// errorLine starts an error block; the lines up to the next blank line are its details.
+func (o *suiteCloudOutput) errorLine(line string) {
+	o.failed = true
+	o.errors = []string{line}
+}
+
+// flushError prints the pending error block.
+func (o *suiteCloudOutput) flushError() {
+	if !o.failed {
+		return
+	}
+	message := strings.Join(o.errors, "\n  ")
+	o.result.Errors = append(o.result.Errors, message)
+	if !o.quiet {
+		logError("%s", message)
+	}
+	o.failed, o.errors = false, nil
+}
+
+// flushSteps prints the number of validation steps passed since the last message.
+func (o *suiteCloudOutput) flushSteps() {
+	if o.steps == 0 {
+		return
+	}
+	o.result.Steps += o.steps
+	o.info("✓ %d validation step(s) passed", o.steps)
+	o.steps = 0
+}
+
+// info prints a message unless quiet.
+func (o *suiteCloudOutput) info(format string, args ...any) {
+	if !o.quiet {
+		logInfo(format, args...)
+	}
+}
+
+// warn records a warning and prints it unless quiet.
+func (o *suiteCloudOutput) warn(format string, args ...any) {
+	o.result.Warnings = append(o.result.Warnings, fmt.Sprintf(format, args...))
+	if !o.quiet {
+		logWarn(format, args...)
+	}
+}
+
+// debug prints a line that is not summarized with --verbose.
+func (o *suiteCloudOutput) debug(format string, args ...any) {
+	if !o.quiet {
+		logDebug("suitecloud: "+format, args...)
+	}
+}
+
+// runSuiteCloud runs a SuiteCloud CLI command and returns its parsed output. Its output is
+// printed as concise messages, or passed through unchanged with --raw. When the command fails
+// without an error the parser recognizes, its whole output is printed.
+func runSuiteCloud(cmd *exec.Cmd) (SuiteCloudResult, error) {
+	output := &suiteCloudOutput{quiet: rawFlag}
+	if rawFlag {
+		cmd.Stdout = io.MultiWriter(os.Stdout, output)
+		cmd.Stderr = io.MultiWriter(os.Stderr, output)
+	} else {
+		cmd.Stdout = output
+		cmd.Stderr = output
+	}
+	logCommand(cmd)
+	err := cmd.Run()
+	result := output.Close()
+	if err != nil && !rawFlag && len(result.Errors) == 0 && strings.TrimSpace(result.Output) != "" {
+		fmt.Fprint(os.Stderr, result.Output)
+	}
+	return result, err
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+// parseSuiteCloudOutput writes output to a quiet suiteCloudOutput in chunks of size bytes, as
+// a running command would, and returns its result.
+func parseSuiteCloudOutput(output string, size int) SuiteCloudResult {
+	o := &suiteCloudOutput{quiet: true}
+	for len(output) > size {
+		o.Write([]byte(output[:size]))
+		output = output[size:]
+	}
+	o.Write([]byte(output))
+	return o.Close()
+}
+
+func TestSuiteCloudOutput(t *testing.T) {
+	account := map[string]string{
+		"Account":                       "(SANDBOX) Acme Corp",
+		"Account Customization Project": "Orders",
+		"Framework Version":             "1.0",
+	}
+	tests := map[string]SuiteCloudResult{
+		"deploy-success.txt": {
+			Info:  account,
+			Steps: 15,
+			Changes: []SuiteCloudChange{
+				{Action: "update", Kind: "object", Target: "customscript_acme_sl_orders (suitelet)"},
+				{Action: "update", Kind: "object", Target: "customrecord_acme_order (customrecordtype)"},
+				{Action: "create", Kind: "folder", Target: "~/FileCabinet/SuiteScripts/lib"},
+				{Action: "update", Kind: "file", Target: "~/FileCabinet/SuiteScripts/acme_orders_suitelet.js"},
+			},
+		},
+		"deploy-warnings.txt": {
+			Info:  account,
+			Steps: 4,
+			Changes: []SuiteCloudChange{
+				{Action: "update", Kind: "object", Target: "customscript_acme_sl_orders (suitelet)"},
+			},
+			Warnings: []string{"One or more potential issues were found during custom object validation. (customscript_acme_sl_orders)"},
+		},
+		"deploy-error.txt": {
+			Info:  account,
+			Steps: 3,
+			Errors: []string{
+				"Validate objects -- Failed\n  2026-03-05 10:31:15 (PST) Installation FAILED (0 minutes 8 seconds)",
+				"An error occurred during custom object validation. (customscript_acme_sl_orders)\n" +
+					"  Details: The scriptfile field references a file that is not in the project: [/SuiteScripts/acme_orders_suitelet.js].\n" +
+					"  File: ~/Objects/customscript_acme_sl_orders.xml",
+			},
+		},
+		"upload.txt": {
+			Lists: map[string][]string{
+				"files uploaded":     {"/SuiteScripts/acme_orders_suitelet.js", "/SuiteScripts/lib/acme_orders_lib.js"},
+				"files not uploaded": {"/SuiteScripts/acme_orders_report.js: The file is locked."},
+			},
+			Warnings: []string{"Files not uploaded: /SuiteScripts/acme_orders_report.js: The file is locked."},
+		},
+	}
+	for name, want := range tests {
+		output := readFixture(t, name)
+		for _, size := range []int{len(output), 7} {
+			got := parseSuiteCloudOutput(output, size)
+			if got.Output != output {
+				t.Errorf("%s in chunks of %d bytes: the output is not kept whole", name, size)
+			}
+			got.Output = ""
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%s in chunks of %d bytes:\n%#v\nwant\n%#v", name, size, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"netsuite-cli/internal/update"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	selfUpdateYesFlag   bool
+	selfUpdateForceFlag bool
+)
+
+// selfUpdateCmd represents the self-update command
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Replace netsuite-cli with the latest release",
+	Long: `Download the archive of the latest GitHub release for this OS and architecture, verify
+it against the SHA-256 checksums published with the release and replace the running binary
+with the one it contains. The binary is swapped in a single rename, so an interrupted update
+leaves the old version in place.
+
+Binaries installed with a package manager should be updated with it instead.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSelfUpdate()
+	},
+}
+
+func init() {
+	selfUpdateCmd.Flags().BoolVarP(&selfUpdateYesFlag, "yes", "y", false, "Update without asking for confirmation")
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateForceFlag, "force", false, "Install the latest release even when it is not newer")
+
+	rootCmd.AddCommand(selfUpdateCmd)
+}
+
+// SelfUpdateResult is the outcome of self-update.
+type SelfUpdateResult struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Path    string `json:"path"`
+	Updated bool   `json:"updated"`
+}
+
+// runSelfUpdate replaces the running binary with the latest release.
+func runSelfUpdate() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("error locating the netsuite-cli binary: %v", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+
+	release, err := checkForUpdate()
+	if err != nil {
+		return err
+	}
+	result := SelfUpdateResult{From: buildInfo.Version, To: release.Version(), Path: exe}
+	if jsonFlag {
+		setJSONResult(&result)
+	}
+	if !update.Newer(buildInfo.Version, release.Version()) && !selfUpdateForceFlag {
+		if buildInfo.Version == "dev" {
+			logInfo("This is a development build, pass --force to replace it with release %s.", release.Version())
+		} else {
+			logInfo("✓ netsuite-cli %s is the latest release.", buildInfo.Version)
+		}
+		return nil
+	}
+
+	archiveName := update.ArchiveName(runtime.GOOS, runtime.GOARCH)
+	archive, ok := release.Asset(archiveName)
+	if !ok {
+		return fmt.Errorf("release %s has no archive for %s/%s", release.Tag, runtime.GOOS, runtime.GOARCH)
+	}
+	checksums, ok := release.Asset(update.ChecksumsName)
+	if !ok {
+		return fmt.Errorf("release %s has no %s to verify the download against", release.Tag, update.ChecksumsName)
+	}
+
+	if !selfUpdateYesFlag {
+		confirmed, err := promptConfirm(bufio.NewReader(os.Stdin), fmt.Sprintf("Update %s from %s to %s? (y/N): ", exe, buildInfo.Version, release.Version()))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Aborted.")
+			return errCancelled
+		}
+	}
+
+	logInfo("Downloading %s...", archive.Name)
+	sums, err := update.Download(runContext, checksums)
+	if err != nil {
+		return err
+	}
+	data, err := update.Download(runContext, archive)
+	if err != nil {
+		return err
+	}
+	if err := update.VerifyChecksum(sums, archive.Name, data); err != nil {
+		return err
+	}
+	logDebug("checksum of %s verified", archive.Name)
+	binary, err := update.ExtractBinary(archive.Name, data, update.BinaryName(runtime.GOOS))
+	if err != nil {
+		return err
+	}
+	if err := update.ReplaceExecutable(exe, binary); err != nil {
+		return err
+	}
+
+	result.Updated = true
+	logInfo("✓ Updated netsuite-cli %s → %s (%s).", buildInfo.Version, release.Version(), exe)
+	if release.URL != "" {
+		logInfo("Release notes: %s", release.URL)
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"netsuite-cli/pkg/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+// setupCmd represents the setup command
+var setupCmd = &cobra.Command{
+	Use:   "setup",
+	Short: "Add development tooling to the project",
+}
+
+// setupLintCmd represents the setup lint command
+var setupLintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Add ESLint and Prettier configuration",
+	Long: `Write .eslintrc.json with SuiteScript friendly rules and .prettierrc, and add the
+ESLint and Prettier devDependencies and the lint and format scripts to package.json.
+Existing files and package.json entries are left unchanged.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := loadProjectConfig(); err != nil {
+			return err
+		}
+		beginGeneration("netsuite-cli setup lint")
+		err := setupLint(".")
+		if commitErr := commitGeneration("."); commitErr != nil {
+			logWarn("Failed to update %s: %v", lockFileName, commitErr)
+		}
+		if err != nil {
+			return err
+		}
+		logInfo("Run 'npm install' to install the new dependencies.")
+		return nil
+	},
+}
+
+// setupTestsCmd represents the setup tests command
+var setupTestsCmd = &cobra.Command{
+	Use:   "tests",
+	Short: "Add Jest and SuiteCloud Unit Testing",
+	Long: `Write a jest.config.js using the SuiteCloud Unit Testing stubs for the N/ modules and
+ts-jest for TypeScript, a sample test in __tests__, and add the test dependencies and the
+test script to package.json. Once set up, add generates a test stub for every new script.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := loadProjectConfig(); err != nil {
+			return err
+		}
+		beginGeneration("netsuite-cli setup tests")
+		err := setupTests(".")
+		if commitErr := commitGeneration("."); commitErr != nil {
+			logWarn("Failed to update %s: %v", lockFileName, commitErr)
+		}
+		if err != nil {
+			return err
+		}
+		logInfo("Run 'npm install' to install the new dependencies, then 'npm test'.")
+		return nil
+	},
+}
+
+var (
+	setupCIProviderFlag string
+	setupCIBranchFlag   string
+	setupCIAuthIDFlag   string
+)
+
+// setupCICmd represents the setup ci command
+var setupCICmd = &cobra.Command{
+	Use:   "ci",
+	Short: "Add a CI pipeline validating and deploying the project",
+	Long: `Write a GitHub Actions workflow (--provider github) or a .gitlab-ci.yml (--provider gitlab)
+that validates the project on pull requests and deploys it on pushes to the main branch.
+
+The pipeline authenticates with the SuiteCloud CLI machine-to-machine flow and expects
+the NS_ACCOUNT_ID, NS_CERTIFICATE_ID, NS_PRIVATE_KEY and SUITECLOUD_CI_PASSKEY secrets
+(or CI/CD variables) to be defined in the repository settings.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := loadProjectConfig(); err != nil {
+			return err
+		}
+		beginGeneration("netsuite-cli setup ci")
+		err := setupCI(".", setupCIProviderFlag)
+		if commitErr := commitGeneration("."); commitErr != nil {
+			logWarn("Failed to update %s: %v", lockFileName, commitErr)
+		}
+		if err != nil {
+			return err
+		}
+		logInfo("Define the NS_ACCOUNT_ID, NS_CERTIFICATE_ID, NS_PRIVATE_KEY and SUITECLOUD_CI_PASSKEY secrets before pushing.")
+		return nil
+	},
+}
+
+func init() {
+	setupCICmd.Flags().StringVar(&setupCIProviderFlag, "provider", "github", "CI provider: "+strings.Join(ciProviderNames(), ", "))
+	setupCICmd.Flags().StringVar(&setupCIBranchFlag, "branch", "main", "Branch deployed to NetSuite on push")
+	setupCICmd.Flags().StringVar(&setupCIAuthIDFlag, "authid", "ci", "Auth ID registered by the pipeline")
+
+	setupCmd.AddCommand(setupLintCmd)
+	setupCmd.AddCommand(setupTestsCmd)
+	setupCmd.AddCommand(setupCICmd)
+	rootCmd.AddCommand(setupCmd)
+}
+
+// lintDevDependencies are the packages required by the generated ESLint and Prettier configuration.
+var lintDevDependencies = map[string]string{
+	"eslint":                           "^8.57.1",
+	"@typescript-eslint/parser":        "^7.18.0",
+	"@typescript-eslint/eslint-plugin": "^7.18.0",
+	"eslint-config-prettier":           "^9.1.0",
+	"prettier":                         "^3.3.3",
+}
+
+// lintScripts are the package.json scripts running ESLint and Prettier.
+var lintScripts = map[string]string{
+	"lint":         `eslint "src/**/*.ts"`,
+	"lint:fix":     `eslint --fix "src/**/*.ts"`,
+	"format":       `prettier --write "src/**/*.ts"`,
+	"format:check": `prettier --check "src/**/*.ts"`,
+}
+
+// testDevDependencies are the packages required by the generated Jest configuration.
+var testDevDependencies = map[string]string{
+	"jest":                            "^29.7.0",
+	"ts-jest":                         "^29.2.5",
+	"@types/jest":                     "^29.5.14",
+	"@oracle/suitecloud-unit-testing": "^1.7.0",
+}
+
+// testScripts are the package.json scripts running Jest.
+var testScripts = map[string]string{
+	"test": "jest",
+}
+
+// ciProvider describes where a CI provider expects its pipeline definition.
+type ciProvider struct {
+	path         string
+	templatePath string
+}
+
+// ciProviders lists the supported CI providers.
+var ciProviders = map[string]ciProvider{
+	"github": {path: filepath.Join(".github", "workflows", "netsuite.yml"), templatePath: "github-workflow.yml.tmpl"},
+	"gitlab": {path: ".gitlab-ci.yml", templatePath: "gitlab-ci.yml.tmpl"},
+}
+
+// ciProviderNames returns the names of the supported CI providers.
+func ciProviderNames() []string {
+	return []string{"github", "gitlab"}
+}
+
+// testsDir is the folder holding the unit tests of a project.
+const testsDir = "__tests__"
+
+// setupLint writes the ESLint and Prettier configuration to projectDir and registers the
+// dependencies and scripts in its package.json.
+func setupLint(projectDir string) error {
+	if err := writeSetupFile(filepath.Join(projectDir, ".eslintrc.json"), "eslintrc.json.tmpl"); err != nil {
+		return err
+	}
+	if err := writeSetupFile(filepath.Join(projectDir, ".prettierrc"), "prettierrc.tmpl"); err != nil {
+		return err
+	}
+
+	updated, err := updatePackageJSON(projectDir, lintScripts, lintDevDependencies)
+	if err != nil {
+		return err
+	}
+	if updated {
+		logInfo("Updated package.json with the lint scripts and dependencies")
+	}
+	return nil
+}
+
+// setupTests writes the Jest configuration and a sample test to projectDir and registers the
+// dependencies and scripts in its package.json.
+func setupTests(projectDir string) error {
+	if err := writeSetupFile(filepath.Join(projectDir, "jest.config.js"), "jest.config.js.tmpl"); err != nil {
+		return err
+	}
+
+	dir := filepath.Join(projectDir, testsDir)
+	recordCreatedDirs(dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("error creating directory %s: %v", dir, err)
+	}
+	if err := writeSetupFile(filepath.Join(dir, "sample.test.ts"), "sample.test.ts.tmpl"); err != nil {
+		return err
+	}
+
+	updated, err := updatePackageJSON(projectDir, testScripts, testDevDependencies)
+	if err != nil {
+		return err
+	}
+	if updated {
+		logInfo("Updated package.json with the test script and dependencies")
+	}
+	return nil
+}
+
+// testsConfigured reports whether the project in the current directory was set up for unit tests.
+func testsConfigured() bool {
+	_, err := os.Stat("jest.config.js")
+	return err == nil
+}
+
+// writeSetupFile writes an embedded template to path unless the file already exists.
+func writeSetupFile(path, templatePath string) error {
+	if _, err := os.Stat(path); err == nil {
+		reportFile("Skipped", path, "(already exists)")
+		return nil
+	}
+	if err := createFileFromTemplate(path, templatePath, nil); err != nil {
+		return err
+	}
+	reportFile("Created", path, "")
+	return nil
+}
+
+// setupCI writes the pipeline definition of the given CI provider to projectDir. The CI
+// templates use [[ ]] delimiters so the ${{ }} expressions of GitHub Actions are kept as is.
+func setupCI(projectDir, providerName string) error {
+	provider, ok := ciProviders[strings.ToLower(strings.TrimSpace(providerName))]
+	if !ok {
+		return fmt.Errorf("unsupported CI provider '%s' (supported: %s)", providerName, strings.Join(ciProviderNames(), ", "))
+	}
+
+	path := filepath.Join(projectDir, provider.path)
+	if _, err := os.Stat(path); err == nil {
+		reportFile("Skipped", path, "(already exists)")
+		return nil
+	}
+
+	tmplContent, err := scaffold.Embedded(provider.templatePath)
+	if err != nil {
+		return &TemplateError{Template: provider.templatePath, Err: err}
+	}
+	tmpl, err := template.New("ci").Delims("[[", "]]").Funcs(scaffold.Funcs(DateFormat{})).Parse(string(tmplContent))
+	if err != nil {
+		return &TemplateError{Template: provider.templatePath, Err: err}
+	}
+	var buf bytes.Buffer
+	data := map[string]string{
+		"Branch":      setupCIBranchFlag,
+		"AuthID":      setupCIAuthIDFlag,
+		"NodeVersion": "20",
+		"JavaVersion": "17",
+	}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return &TemplateError{Template: provider.templatePath, Err: err}
+	}
+
+	dir := filepath.Dir(path)
+	recordCreatedDirs(dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("error creating directory %s: %v", dir, err)
+	}
+	recordGeneratedFile(path, nil, false)
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("error creating %s: %v", path, err)
+	}
+	reportFile("Created", path, "")
+	return nil
+}
@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Summarize the project and account state",
+	Long: `Show the project name, the configured company and user, the environment and SDF
+authentication ID commands run against, the number of objects by type, the generated files
+that are not committed to git yet and the result of the last deploy and validate runs.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStatus()
+	},
+}
+
+func init() {
+	statusCmd.Flags().StringVarP(&envFlag, "env", "e", "", "Project environment to show")
+
+	rootCmd.AddCommand(statusCmd)
+}
+
+// CommandResult is the outcome of a deploy or validate run, kept in the project state.
+type CommandResult struct {
+	Time        time.Time `json:"time"`
+	Success     bool      `json:"success"`
+	Environment string    `json:"environment,omitempty"`
+	AuthID      string    `json:"authId,omitempty"`
+	Message     string    `json:"message,omitempty"`
+}
+
+// ProjectState holds the results of the last runs of the commands talking to the account.
+type ProjectState struct {
+	LastDeploy   *CommandResult `json:"lastDeploy,omitempty"`
+	LastValidate *CommandResult `json:"lastValidate,omitempty"`
+}
+
+// projectStatePath returns the path of the project state file in the cache folder.
+func projectStatePath() string {
+	return filepath.Join(metadataCacheDir, "state.json")
+}
+
+// LoadProjectState reads the project state. A missing state file yields an empty state.
+func LoadProjectState() (*ProjectState, error) {
+	data, err := os.ReadFile(projectStatePath())
+	if os.IsNotExist(err) {
+		return &ProjectState{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading project state: %v", err)
+	}
+
+	var state ProjectState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("error parsing project state: %v", err)
+	}
+	return &state, nil
+}
+
+// SaveProjectState writes the project state to the cache folder.
+func SaveProjectState(state *ProjectState) error {
+	if err := os.MkdirAll(metadataCacheDir, 0755); err != nil {
+		return fmt.Errorf("error creating %s: %v", metadataCacheDir, err)
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling project state: %v", err)
+	}
+	if err := os.WriteFile(projectStatePath(), data, 0644); err != nil {
+		return fmt.Errorf("error writing project state: %v", err)
+	}
+	return nil
+}
+
+// recordCommandResult stores the outcome of a deploy or validate run in the project state.
+// Failures to save the state are reported as warnings.
+func recordCommandResult(command string, config *ProjectConfig, success bool, message string) {
+	state, err := LoadProjectState()
+	if err != nil {
+		state = &ProjectState{}
+	}
+
+	result := &CommandResult{Time: time.Now().UTC(), Success: success, Message: message}
+	result.Environment = envFlag
+	if result.Environment == "" {
+		result.Environment = config.DefaultEnvironment
+	}
+	authID, _ := resolveAuthID(config, envFlag)
+	result.AuthID = firstNonEmpty(authID, projectJSONAuthID())
+
+	switch command {
+	case "deploy":
+		state.LastDeploy = result
+	case "validate":
+		state.LastValidate = result
+	}
+	if err := SaveProjectState(state); err != nil {
+		logWarn("Failed to save the %s result: %v", command, err)
+	}
+}
+
+// StatusReport is the summary printed by the status command.
+type StatusReport struct {
+	Project      string            `json:"project"`
+	Company      string            `json:"company"`
+	Prefix       string            `json:"prefix"`
+	UserName     string            `json:"userName"`
+	UserEmail    string            `json:"userEmail,omitempty"`
+	Environment  string            `json:"environment,omitempty"`
+	AuthID       string            `json:"authId,omitempty"`
+	Objects      map[string]int    `json:"objects"`
+	Uncommitted  []UncommittedFile `json:"uncommitted"`
+	LastDeploy   *CommandResult    `json:"lastDeploy,omitempty"`
+	LastValidate *CommandResult    `json:"lastValidate,omitempty"`
+}
+
+// UncommittedFile is a generated file that is new or modified according to git.
+type UncommittedFile struct {
+	Status string `json:"status"`
+	Path   string `json:"path"`
+}
+
+// runStatus prints a summary of the project state.
+func runStatus() error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	report := StatusReport{
+		Project:     config.ProjectName,
+		Company:     config.CompanyName,
+		Prefix:      config.Prefix(),
+		UserName:    config.UserName,
+		UserEmail:   config.UserEmail,
+		Environment: firstNonEmpty(envFlag, config.DefaultEnvironment),
+	}
+	authID, authErr := resolveAuthID(config, envFlag)
+	report.AuthID = firstNonEmpty(authID, projectJSONAuthID())
+	counts, countErr := countLocalObjects()
+	report.Objects = counts
+	files, filesErr := uncommittedGeneratedFiles()
+	report.Uncommitted = append([]UncommittedFile{}, files...)
+	state, stateErr := LoadProjectState()
+	if stateErr != nil {
+		logWarn("%v", stateErr)
+		state = &ProjectState{}
+	}
+	report.LastDeploy, report.LastValidate = state.LastDeploy, state.LastValidate
+
+	if jsonFlag {
+		for _, err := range []error{authErr, countErr, filesErr} {
+			if err != nil {
+				logWarn("%v", err)
+			}
+		}
+		setJSONResult(report)
+		return nil
+	}
+
+	fmt.Printf("Project:     %s\n", report.Project)
+	fmt.Printf("Company:     %s (prefix %s)\n", report.Company, report.Prefix)
+	user := report.UserName
+	if report.UserEmail != "" {
+		user += " <" + report.UserEmail + ">"
+	}
+	fmt.Printf("User:        %s\n", user)
+
+	environment := firstNonEmpty(report.Environment, "(none)")
+	if authErr != nil {
+		fmt.Printf("Environment: %s (%v)\n", environment, authErr)
+	} else {
+		fmt.Printf("Environment: %s, authid %s\n", environment, firstNonEmpty(report.AuthID, "(not set)"))
+	}
+
+	fmt.Println("\nObjects:")
+	if countErr != nil {
+		fmt.Printf("  Error: %v\n", countErr)
+	} else if len(counts) == 0 {
+		fmt.Println("  (none)")
+	} else {
+		types := make([]string, 0, len(counts))
+		for objectType := range counts {
+			types = append(types, objectType)
+		}
+		sort.Strings(types)
+		for _, objectType := range types {
+			fmt.Printf("  %-30s %d\n", objectType, counts[objectType])
+		}
+	}
+
+	fmt.Println("\nUncommitted generated files:")
+	switch {
+	case filesErr != nil:
+		fmt.Printf("  %v\n", filesErr)
+	case len(files) == 0:
+		fmt.Println("  (none)")
+	default:
+		for _, file := range files {
+			fmt.Printf("  %s %s\n", file.Status, file.Path)
+		}
+	}
+
+	fmt.Println()
+	printCommandResult("Last deploy:  ", report.LastDeploy)
+	printCommandResult("Last validate:", report.LastValidate)
+	return nil
+}
+
+// projectJSONAuthID returns the default authentication ID set in project.json.
+func projectJSONAuthID() string {
+	data, err := os.ReadFile("project.json")
+	if err != nil {
+		return ""
+	}
+	var projectJSON struct {
+		DefaultAuthID string `json:"defaultAuthId"`
+	}
+	if json.Unmarshal(data, &projectJSON) != nil {
+		return ""
+	}
+	return projectJSON.DefaultAuthID
+}
+
+// countLocalObjects returns the number of object XML files of the project by object type. Unlike
+// findLocalObjects it does not create a missing Objects folder.
+func countLocalObjects() (map[string]int, error) {
+	counts := make(map[string]int)
+	for _, dir := range []string{"src/Objects", "Objects"} {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		objects, err := findLocalObjects()
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range objects {
+			counts[object.Type]++
+		}
+		break
+	}
+	return counts, nil
+}
+
+// uncommittedGeneratedFiles returns the files recorded in the lock file that are new or modified
+// according to git.
+func uncommittedGeneratedFiles() ([]UncommittedFile, error) {
+	lock, err := LoadLockFile(".")
+	if err != nil {
+		return nil, err
+	}
+	generated := make(map[string]bool)
+	for _, generation := range lock.Generations {
+		for _, file := range generation.Files {
+			generated[filepath.ToSlash(file.Path)] = true
+		}
+	}
+	if len(generated) == 0 {
+		return nil, nil
+	}
+
+	out, err := externalCommand("git", "status", "--porcelain", "--untracked-files=all", "--no-renames", ".").Output()
+	if err != nil {
+		return nil, fmt.Errorf("not a git repository, %d generated file(s) recorded in %s", len(generated), lockFileName)
+	}
+	prefix, _ := externalCommand("git", "rev-parse", "--show-prefix").Output()
+	projectPrefix := strings.TrimSpace(string(prefix))
+
+	var files []UncommittedFile
+	for _, line := range strings.Split(string(out), "\n") {
+		if len(line) < 4 {
+			continue
+		}
+		path := strings.Trim(line[3:], `"`)
+		path = strings.TrimPrefix(path, projectPrefix)
+		if generated[path] {
+			files = append(files, UncommittedFile{Status: line[:2], Path: path})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// printCommandResult prints the outcome of the last run of a command.
+func printCommandResult(label string, result *CommandResult) {
+	if result == nil {
+		fmt.Printf("%s never run\n", label)
+		return
+	}
+	outcome := "succeeded"
+	if !result.Success {
+		outcome = "failed"
+	}
+	line := fmt.Sprintf("%s %s %s", label, outcome, result.Time.Local().Format("2006-01-02 15:04"))
+	var target []string
+	if result.Environment != "" {
+		target = append(target, result.Environment)
+	}
+	if result.AuthID != "" {
+		target = append(target, "authid "+result.AuthID)
+	}
+	if len(target) > 0 {
+		line += " (" + strings.Join(target, ", ") + ")"
+	}
+	if result.Message != "" {
+		line += ": " + result.Message
+	}
+	fmt.Println(line)
+}
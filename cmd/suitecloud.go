@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// suiteCloudPackage is the npm package providing the SuiteCloud CLI.
+const suiteCloudPackage = "@oracle/suitecloud-cli"
+
+// npxFlag runs the SuiteCloud CLI through npx when it is not installed globally.
+var npxFlag bool
+
+// getNpmCommand checks for the availability of the npm command.
+func getNpmCommand() string {
+	return lookPathCommand("npm")
+}
+
+// packageManagers lists the supported Node.js package managers in order of preference.
+var packageManagers = []string{"npm", "pnpm", "yarn"}
+
+// lookPathCommand returns name, or its Windows .cmd shim, if available in the PATH.
+func lookPathCommand(name string) string {
+	for _, candidate := range []string{name, name + ".cmd"} {
+		if _, err := exec.LookPath(candidate); err == nil {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// detectPackageManager returns the command of the package manager to install project
+// dependencies with. The package manager that launched the CLI, as reported by
+// npm_config_user_agent, is preferred; otherwise the first available one is used.
+func detectPackageManager(preferred string) (string, error) {
+	if preferred != "" {
+		if !containsString(packageManagers, preferred) {
+			return "", fmt.Errorf("unsupported package manager '%s' (supported: %s)", preferred, strings.Join(packageManagers, ", "))
+		}
+		if command := lookPathCommand(preferred); command != "" {
+			return command, nil
+		}
+		return "", fmt.Errorf("%s is not available in the command line", preferred)
+	}
+
+	if agent, _, _ := strings.Cut(os.Getenv("npm_config_user_agent"), "/"); containsString(packageManagers, agent) {
+		if command := lookPathCommand(agent); command != "" {
+			return command, nil
+		}
+	}
+	for _, name := range packageManagers {
+		if command := lookPathCommand(name); command != "" {
+			return command, nil
+		}
+	}
+	return "", fmt.Errorf("no package manager found, install Node.js from https://nodejs.org")
+}
+
+// suiteCloudExec returns the command running the SuiteCloud CLI with the given arguments.
+// suiteCloudCmd is the value returned by getSuiteCloudCommand, which may include the npx
+// arguments of the fallback execution mode.
+func suiteCloudExec(suiteCloudCmd string, args ...string) *exec.Cmd {
+	fields := strings.Fields(suiteCloudCmd)
+	return externalCommand(fields[0], append(fields[1:], args...)...)
+}
+
+// ensureSuiteCloudCommand returns the SuiteCloud CLI command. When it is not installed the
+// user is offered to install it globally with npm or to run it through npx; a ToolError is
+// returned if neither is possible, the user declines, or there is no terminal to ask on.
+func ensureSuiteCloudCommand() (string, error) {
+	if suiteCloudCmd := getSuiteCloudCommand(); suiteCloudCmd != "" {
+		return suiteCloudCmd, nil
+	}
+
+	fmt.Println("The suitecloud CLI is not available in the command line.")
+	npmCmd := getNpmCommand()
+	npxCmd := getNpxCommand()
+	if npmCmd == "" && npxCmd == "" {
+		return "", toolError("suitecloud", fmt.Errorf("npm is not available either, install Node.js from https://nodejs.org, then run: npm install -g %s", suiteCloudPackage))
+	}
+	required := toolError("suitecloud", fmt.Errorf("the suitecloud CLI is required, install it using: npm install -g %s, or pass --npx to run it with npx", suiteCloudPackage))
+	if !isTerminal(os.Stdin) {
+		return "", required
+	}
+
+	var options []string
+	if npmCmd != "" {
+		options = append(options, "[i]nstall it globally with npm")
+	}
+	if npxCmd != "" {
+		options = append(options, "run it with [n]px")
+	}
+	options = append(options, "[a]bort")
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		answer, err := promptLine(reader, strings.Join(options, ", ")+"? ")
+		if err != nil {
+			return "", required
+		}
+		switch strings.ToLower(answer) {
+		case "i", "install":
+			if npmCmd == "" {
+				continue
+			}
+			logInfo("Running: %s install -g %s", npmCmd, suiteCloudPackage)
+			installCmd := externalCommand(npmCmd, "install", "-g", suiteCloudPackage)
+			installCmd.Stdout = os.Stdout
+			installCmd.Stderr = os.Stderr
+			attachTerminal(installCmd)
+			logCommand(installCmd)
+			if err := installCmd.Run(); err != nil {
+				return "", toolError("npm", fmt.Errorf("error installing the suitecloud CLI: %v", err))
+			}
+			if suiteCloudCmd := getSuiteCloudCommand(); suiteCloudCmd != "" {
+				logInfo("✓ suitecloud CLI installed.")
+				return suiteCloudCmd, nil
+			}
+			if npxCmd == "" {
+				return "", toolError("suitecloud", errors.New("suitecloud was installed but is not in your PATH, add the npm global bin directory to your PATH"))
+			}
+			logWarn("suitecloud was installed but is not in your PATH, running it with npx.")
+			npxFlag = true
+			return getSuiteCloudCommand(), nil
+		case "n", "npx":
+			if npxCmd == "" {
+				continue
+			}
+			npxFlag = true
+			return getSuiteCloudCommand(), nil
+		case "a", "abort", "":
+			return "", required
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"netsuite-cli/internal/auth"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	taskDeployFlag        string
+	taskParamFlags        []string
+	taskWaitFlag          bool
+	taskIntervalFlag      time.Duration
+	taskRestletFlag       string
+	taskRestletDeployFlag string
+	taskAccountFlag       string
+)
+
+// taskCmd represents the task command
+var taskCmd = &cobra.Command{
+	Use:   "task",
+	Short: "Submit and monitor scheduled and map/reduce tasks",
+	Long: `Submit scheduled and map/reduce script tasks and monitor their progress.
+
+NetSuite has no REST API for tasks, so the commands go through a helper RESTlet
+deployed in the account. Generate it in your project with:
+
+  netsuite-cli add restlet netsuite_cli_task --variant task
+
+and deploy the project before running these commands.`,
+}
+
+// taskRunCmd represents the task run command
+var taskRunCmd = &cobra.Command{
+	Use:     "run <scriptid>",
+	Short:   "Submit a scheduled or map/reduce script task",
+	Example: `  netsuite-cli task run customscript_acm_sync_mapreduce --param custscript_acm_date=2024-01-31 --wait`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTaskRun(args[0])
+	},
+}
+
+// taskStatusCmd represents the task status command
+var taskStatusCmd = &cobra.Command{
+	Use:   "status <taskid>",
+	Short: "Show the status of a submitted task",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTaskStatus(args[0])
+	},
+}
+
+func init() {
+	taskCmd.PersistentFlags().StringVar(&taskRestletFlag, "restlet", "customscript_netsuite_cli_task", "Script ID of the task helper RESTlet")
+	taskCmd.PersistentFlags().StringVar(&taskRestletDeployFlag, "restlet-deploy", "customdeploy_netsuite_cli_task", "Deployment ID of the task helper RESTlet")
+	taskCmd.PersistentFlags().StringVarP(&taskAccountFlag, "account", "a", "", "Account profile to use (default: the default profile)")
+	taskCmd.PersistentFlags().DurationVarP(&taskIntervalFlag, "interval", "i", 5*time.Second, "Polling interval when waiting for a task")
+
+	taskRunCmd.Flags().StringVarP(&taskDeployFlag, "deploy", "d", "", "Deployment ID to run (default: the first available deployment)")
+	taskRunCmd.Flags().StringArrayVar(&taskParamFlags, "param", nil, "Script parameter in the form custscript_id=value (repeatable)")
+	taskRunCmd.Flags().BoolVarP(&taskWaitFlag, "wait", "w", false, "Wait for the task to finish, printing its progress")
+	taskStatusCmd.Flags().BoolVarP(&taskWaitFlag, "watch", "w", false, "Keep polling until the task finishes")
+
+	taskCmd.AddCommand(taskRunCmd)
+	taskCmd.AddCommand(taskStatusCmd)
+	rootCmd.AddCommand(taskCmd)
+}
+
+// TaskStatus is the status of a submitted task as reported by the helper RESTlet.
+type TaskStatus struct {
+	TaskId          string  `json:"taskId"`
+	Status          string  `json:"status"`
+	Stage           string  `json:"stage,omitempty"`
+	PercentComplete float64 `json:"percentComplete,omitempty"`
+	TotalSize       int     `json:"totalSize,omitempty"`
+	Error           string  `json:"error,omitempty"`
+}
+
+// Done reports whether the task has finished.
+func (s TaskStatus) Done() bool {
+	return s.Status == "COMPLETE" || s.Status == "FAILED"
+}
+
+// String formats the status for display.
+func (s TaskStatus) String() string {
+	if s.Stage == "" {
+		return s.Status
+	}
+	return fmt.Sprintf("%s, stage %s, %.0f%% complete", s.Status, s.Stage, s.PercentComplete)
+}
+
+// callTaskRestlet calls the task helper RESTlet and decodes its JSON response into result.
+func callTaskRestlet(client *auth.Client, accountID, method string, query url.Values, body []byte, result any) error {
+	query.Set("script", taskRestletFlag)
+	query.Set("deploy", taskRestletDeployFlag)
+
+	resp, err := doJSONRequest(client, method, auth.RESTletBaseURL(accountID)+"?"+query.Encode(), body)
+	if err != nil {
+		return fmt.Errorf("error calling task RESTlet %s: %v", taskRestletFlag, err)
+	}
+	if err := json.Unmarshal(resp.Body, result); err != nil {
+		return fmt.Errorf("unexpected response from task RESTlet %s: %s", taskRestletFlag, strings.TrimSpace(string(resp.Body)))
+	}
+	return nil
+}
+
+// newTaskClient returns the account ID and OAuth 2.0 client of the selected account profile.
+func newTaskClient() (string, *auth.Client, error) {
+	account, err := resolveAccount(taskAccountFlag)
+	if err != nil {
+		return "", nil, err
+	}
+	client, err := newAuthClient(account)
+	if err != nil {
+		return "", nil, err
+	}
+	return account.AccountID, client, nil
+}
+
+// runTaskRun submits a task and optionally waits for it to finish.
+func runTaskRun(scriptId string) error {
+	params := make(map[string]string)
+	for _, param := range taskParamFlags {
+		key, value, ok := strings.Cut(param, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("invalid --param '%s', expected custscript_id=value", param)
+		}
+		params[key] = value
+	}
+
+	request := map[string]any{"scriptId": scriptId}
+	if taskDeployFlag != "" {
+		request["deploymentId"] = taskDeployFlag
+	}
+	if len(params) > 0 {
+		request["params"] = params
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	accountID, client, err := newTaskClient()
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		TaskId string `json:"taskId"`
+		Error  string `json:"error"`
+	}
+	if err := callTaskRestlet(client, accountID, http.MethodPost, url.Values{}, body, &result); err != nil {
+		return err
+	}
+	if result.Error != "" || result.TaskId == "" {
+		return fmt.Errorf("task submission failed: %s", result.Error)
+	}
+
+	logInfo("Submitted %s as task %s", scriptId, result.TaskId)
+
+	if taskWaitFlag {
+		return waitForTask(client, accountID, result.TaskId)
+	}
+	return nil
+}
+
+// runTaskStatus prints the status of a task, polling until it finishes when --watch is set.
+func runTaskStatus(taskId string) error {
+	accountID, client, err := newTaskClient()
+	if err != nil {
+		return err
+	}
+	if taskWaitFlag {
+		return waitForTask(client, accountID, taskId)
+	}
+
+	status, err := fetchTaskStatus(client, accountID, taskId)
+	if err != nil {
+		return err
+	}
+	fmt.Println(status)
+	if status.Status == "FAILED" {
+		return fmt.Errorf("task %s failed", taskId)
+	}
+	return nil
+}
+
+// fetchTaskStatus requests the current status of a task.
+func fetchTaskStatus(client *auth.Client, accountID, taskId string) (TaskStatus, error) {
+	var status TaskStatus
+	if err := callTaskRestlet(client, accountID, http.MethodGet, url.Values{"taskId": {taskId}}, nil, &status); err != nil {
+		return TaskStatus{}, err
+	}
+	if status.Error != "" {
+		return TaskStatus{}, fmt.Errorf("%s", status.Error)
+	}
+	return status, nil
+}
+
+// waitForTask polls the task status, printing every change, until the task finishes. It
+// returns an error if the task failed.
+func waitForTask(client *auth.Client, accountID, taskId string) error {
+	start := time.Now()
+	last := ""
+	for {
+		status, err := fetchTaskStatus(client, accountID, taskId)
+		if err != nil {
+			return err
+		}
+		if line := status.String(); line != last {
+			fmt.Printf("[%s] %s\n", time.Since(start).Round(time.Second), line)
+			last = line
+		}
+
+		if status.Done() {
+			if status.Status == "FAILED" {
+				return fmt.Errorf("task %s failed", taskId)
+			}
+			return nil
+		}
+		if err := sleepContext(taskIntervalFlag); err != nil {
+			return err
+		}
+	}
+}
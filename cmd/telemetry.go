@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"netsuite-cli/internal/telemetry"
+
+	"github.com/spf13/cobra"
+)
+
+// telemetryCmd represents the telemetry command
+var telemetryCmd = &cobra.Command{
+	Use:   "telemetry",
+	Short: "Turn anonymous usage telemetry on or off",
+	Long: `Anonymous usage telemetry is off unless you turn it on. When on, each command records its
+name (e.g. 'add suitelet' or 'deploy'), how long it ran, whether it succeeded and its exit code,
+together with the CLI version, the OS and architecture and a random installation ID. Arguments,
+flags, paths, project, script and account names are never recorded.
+
+The events are queued in the user configuration directory and sent in the background in
+batches. Setting DO_NOT_TRACK=1 or NETSUITE_CLI_TELEMETRY=off turns telemetry off whatever the
+setting.`,
+}
+
+// telemetryOnCmd represents the telemetry on command
+var telemetryOnCmd = &cobra.Command{
+	Use:   "on",
+	Short: "Opt in to anonymous usage telemetry",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTelemetrySet(true)
+	},
+}
+
+// telemetryOffCmd represents the telemetry off command
+var telemetryOffCmd = &cobra.Command{
+	Use:   "off",
+	Short: "Opt out of usage telemetry and delete the queued events",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTelemetrySet(false)
+	},
+}
+
+// telemetryStatusCmd represents the telemetry status command
+var telemetryStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether usage telemetry is on and the events queued",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTelemetryStatus()
+	},
+}
+
+// telemetryFlushCmd represents the telemetry flush command, run in the background to send the
+// queued events.
+var telemetryFlushCmd = &cobra.Command{
+	Use:    "flush",
+	Short:  "Send the queued telemetry events",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTelemetryFlush()
+	},
+}
+
+func init() {
+	telemetryCmd.AddCommand(telemetryOnCmd)
+	telemetryCmd.AddCommand(telemetryOffCmd)
+	telemetryCmd.AddCommand(telemetryStatusCmd)
+	telemetryCmd.AddCommand(telemetryFlushCmd)
+	rootCmd.AddCommand(telemetryCmd)
+}
+
+// TelemetryStatus is the state of usage telemetry reported by telemetry status.
+type TelemetryStatus struct {
+	Enabled    bool   `json:"enabled"`
+	DisabledBy string `json:"disabledBy,omitempty"`
+	Endpoint   string `json:"endpoint,omitempty"`
+	Queued     int    `json:"queued"`
+}
+
+// telemetryDir returns the directory of the user configuration the telemetry queue is kept in.
+func telemetryDir() (string, error) {
+	userDir, err := UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(userDir, "telemetry"), nil
+}
+
+// telemetryEndpoint returns the URL the events are sent to: NETSUITE_CLI_TELEMETRY_URL when set,
+// else the endpoint the binary was built with.
+func telemetryEndpoint() string {
+	if url := os.Getenv("NETSUITE_CLI_TELEMETRY_URL"); url != "" {
+		return url
+	}
+	return telemetry.Endpoint
+}
+
+// telemetryDisabledBy returns the environment variable turning telemetry off, if any.
+func telemetryDisabledBy() string {
+	if value := os.Getenv("DO_NOT_TRACK"); value != "" && value != "0" {
+		return "DO_NOT_TRACK"
+	}
+	switch strings.ToLower(os.Getenv("NETSUITE_CLI_TELEMETRY")) {
+	case "off", "0", "false":
+		return "NETSUITE_CLI_TELEMETRY"
+	}
+	return ""
+}
+
+// telemetryEnabled reports whether the user opted in to telemetry and no environment variable
+// turns it off.
+func telemetryEnabled() bool {
+	if telemetryDisabledBy() != "" {
+		return false
+	}
+	userConfig, err := LoadUserConfig()
+	return err == nil && userConfig != nil && userConfig.Telemetry == "on"
+}
+
+// runTelemetrySet turns telemetry on or off. Turning it off deletes the queued events and the
+// installation ID.
+func runTelemetrySet(on bool) error {
+	userConfig, err := loadUserConfig()
+	if err != nil {
+		return err
+	}
+	dir, err := telemetryDir()
+	if err != nil {
+		return configError(err)
+	}
+
+	if !on {
+		userConfig.Telemetry = "off"
+		if err := SaveUserConfig(userConfig); err != nil {
+			return configError(err)
+		}
+		if err := telemetry.Clear(dir); err != nil {
+			return fmt.Errorf("error deleting the telemetry queue: %v", err)
+		}
+		logInfo("Telemetry is off. The queued events were deleted.")
+		return nil
+	}
+
+	userConfig.Telemetry = "on"
+	if err := SaveUserConfig(userConfig); err != nil {
+		return configError(err)
+	}
+	if _, err := telemetry.InstallID(dir); err != nil {
+		return fmt.Errorf("error creating the telemetry installation ID: %v", err)
+	}
+	logInfo("✓ Telemetry is on, thank you. Only command names, durations and outcomes are recorded.")
+	if by := telemetryDisabledBy(); by != "" {
+		logWarn("%s is set, so no events are recorded until it is unset.", by)
+	}
+	return nil
+}
+
+// runTelemetryStatus prints whether telemetry is on, where the events are sent and how many are
+// queued.
+func runTelemetryStatus() error {
+	dir, err := telemetryDir()
+	if err != nil {
+		return configError(err)
+	}
+	events, err := telemetry.Pending(dir)
+	if err != nil {
+		return err
+	}
+	status := TelemetryStatus{
+		Enabled:    telemetryEnabled(),
+		DisabledBy: telemetryDisabledBy(),
+		Endpoint:   telemetryEndpoint(),
+		Queued:     len(events),
+	}
+	if jsonFlag {
+		setJSONResult(status)
+		return nil
+	}
+
+	switch {
+	case status.Enabled:
+		fmt.Println("Telemetry: on")
+	case status.DisabledBy != "":
+		fmt.Printf("Telemetry: off (%s is set)\n", status.DisabledBy)
+	default:
+		fmt.Println("Telemetry: off")
+	}
+	if status.Endpoint != "" {
+		fmt.Printf("Endpoint:  %s\n", status.Endpoint)
+	} else {
+		fmt.Println("Endpoint:  none, events are only queued")
+	}
+	fmt.Printf("Queued:    %d event(s) in %s\n", status.Queued, filepath.Join(dir, telemetry.QueueName))
+	return nil
+}
+
+// runTelemetryFlush sends the queued events. Errors only go to the log file: the command runs in
+// the background with nobody to report them to.
+func runTelemetryFlush() error {
+	endpoint := telemetryEndpoint()
+	dir, err := telemetryDir()
+	if err != nil || endpoint == "" || !telemetryEnabled() {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(runContext, 10*time.Second)
+	defer cancel()
+	sent, err := telemetry.Flush(ctx, dir, endpoint)
+	if err != nil {
+		writeLog(levelWarn, "%v", err)
+		return nil
+	}
+	logDebug("sent %d telemetry event(s)", sent)
+	return nil
+}
+
+// recordTelemetry queues the event of the command run when telemetry is on, and starts a
+// background flush when the queue is due. Only the command path is recorded, never its
+// arguments. The telemetry command itself, help, completion and plugins are not recorded.
+func recordTelemetry(command *cobra.Command, code int) {
+	if command == nil || command == rootCmd || !command.Runnable() {
+		return
+	}
+	for c := command; c != nil; c = c.Parent() {
+		switch c {
+		case telemetryCmd, completionCmd:
+			return
+		}
+	}
+	if strings.HasPrefix(command.Name(), "__") || !telemetryEnabled() {
+		return
+	}
+	dir, err := telemetryDir()
+	if err != nil {
+		return
+	}
+	id, err := telemetry.InstallID(dir)
+	if err != nil {
+		return
+	}
+	event := telemetry.Event{
+		Time:       time.Now().UTC(),
+		InstallID:  id,
+		Command:    strings.TrimPrefix(command.CommandPath(), rootCmd.Name()+" "),
+		DurationMs: time.Since(logStarted).Milliseconds(),
+		Success:    code == exitOK,
+		ExitCode:   code,
+		Version:    buildInfo.Version,
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+	}
+	if err := telemetry.Append(dir, event); err != nil {
+		writeLog(levelWarn, "error queuing telemetry: %v", err)
+		return
+	}
+	if telemetryEndpoint() != "" && telemetry.Due(dir) {
+		startTelemetryFlush()
+	}
+}
+
+// startTelemetryFlush starts 'telemetry flush' detached from the CLI, so sending the events never
+// delays the command.
+func startTelemetryFlush() {
+	exe, err := os.Executable()
+	if err != nil {
+		return
+	}
+	flushCmd := exec.Command(exe, "telemetry", "flush")
+	detachProcess(flushCmd)
+	if err := flushCmd.Start(); err != nil {
+		writeLog(levelWarn, "error starting the telemetry flush: %v", err)
+		return
+	}
+	flushCmd.Process.Release()
+}
@@ -0,0 +1,319 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"netsuite-cli/pkg/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	templateSetFlags   []string
+	templateParamFlags []string
+	templateGlobalFlag bool
+	templateForceFlag  bool
+)
+
+// templateCmd represents the template command
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Work with the script templates",
+	Long: `Work with the templates add generates scripts from, including the overrides in the
+project and user templates folders.`,
+}
+
+// templatePreviewCmd represents the template preview command
+var templatePreviewCmd = &cobra.Command{
+	Use:   "preview <type>",
+	Short: "Render the templates of a script type to stdout",
+	Long: `Render the script, unit test and object XML templates of a script type to stdout
+without writing any file, using the project configuration and template overrides. Outside
+a project sample values are used. Template fields can be replaced with --set, e.g.
+--set ScriptName=order_page --set TypedStages=true.`,
+	Args: cobra.ExactArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		names := make([]string, len(scaffold.ScriptTypes))
+		for i, t := range scaffold.ScriptTypes {
+			names[i] = t.Name
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTemplatePreview(args[0])
+	},
+}
+
+// templateEjectCmd represents the template eject command
+var templateEjectCmd = &cobra.Command{
+	Use:   "eject [type]",
+	Short: "Copy the embedded templates into the templates folder for editing",
+	Long: fmt.Sprintf(`Write the embedded templates to the project's .netsuite-cli-templates/ folder, or with
+--global to the user templates folder, so they can be customized starting from the shipped
+versions. With a type, only its templates are written, e.g. 'suitelet' for suitelet.ts.tmpl,
+suitelet.js.tmpl, suitelet.xml.tmpl and any suitelet_<variant> templates, or 'partials' for
+partials.tmpl.
+
+Ejected templates in .netsuite-cli-templates/ take precedence over the project templates/
+folder, the pinned template pack and the user templates folder, which take precedence over
+the embedded templates.
+
+The user templates folder is %s.`, userDirHelp("templates")),
+	Args: cobra.MaximumNArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var names []string
+		for _, name := range scaffold.Overridable() {
+			base, _, _ := strings.Cut(name, ".")
+			if !containsString(names, base) {
+				names = append(names, base)
+			}
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		return runTemplateEject(name)
+	},
+}
+
+// templateListCmd represents the template list command
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the templates and the override used for each",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTemplateList()
+	},
+}
+
+func init() {
+	templatePreviewCmd.Flags().StringArrayVar(&templateSetFlags, "set", nil, "Template field to replace, as key=value (repeatable)")
+	templatePreviewCmd.Flags().StringArrayVar(&templateParamFlags, "param", nil, "Script parameter in the form name:type[:label] (repeatable)")
+	templatePreviewCmd.Flags().StringVar(&langFlag, "lang", "", "Language of the previewed script: ts or js (default: the project language)")
+	templatePreviewCmd.Flags().StringVar(&variantFlag, "variant", "", "Template variant for suitelet, restlet and portlet scripts")
+	templatePreviewCmd.Flags().StringVar(&returnTypeFlag, "return-type", "", "Return type of workflowaction scripts")
+
+	templateEjectCmd.Flags().BoolVar(&templateGlobalFlag, "global", false, fmt.Sprintf("Write to the user templates folder (%s) instead of the project", userDirHelp("templates")))
+	templateEjectCmd.Flags().BoolVar(&templateForceFlag, "force", false, "Overwrite templates that were already ejected")
+
+	templateCmd.AddCommand(templatePreviewCmd)
+	templateCmd.AddCommand(templateEjectCmd)
+	templateCmd.AddCommand(templateListCmd)
+	rootCmd.AddCommand(templateCmd)
+}
+
+// sampleProjectConfig returns the configuration used to preview templates outside a project.
+func sampleProjectConfig() *ProjectConfig {
+	return &ProjectConfig{
+		ProjectName: "SampleProject",
+		CompanyName: "Sample Company",
+		UserName:    "Sample User",
+		UserEmail:   "sample.user@example.com",
+	}
+}
+
+// runTemplatePreview prints the files add would generate for a script type.
+func runTemplatePreview(scriptType string) error {
+	scriptType = scaffold.ResolveScriptType(scriptType)
+	config, err := LoadConfig()
+	if err != nil {
+		config = sampleProjectConfig()
+	}
+
+	overrides := make(map[string]string)
+	var keys []string
+	for _, set := range templateSetFlags {
+		key, value, ok := strings.Cut(set, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return validationError("invalid --set '%s', expected key=value", set)
+		}
+		if _, seen := overrides[key]; !seen {
+			keys = append(keys, key)
+		}
+		overrides[key] = value
+	}
+
+	var params []scaffold.ScriptParam
+	for _, spec := range templateParamFlags {
+		param, err := scaffold.ParseScriptParam(spec)
+		if err != nil {
+			return validationError("invalid --param '%s': %v", spec, err)
+		}
+		params = append(params, param)
+	}
+
+	recordType := ""
+	if scaffold.UsesRecordType(scriptType) {
+		recordType = "CUSTOMER"
+	}
+	returnRecordType := ""
+	if scriptType == "workflowaction" {
+		returnRecordType = "-2"
+	}
+
+	generator := scaffold.Generator{
+		Config:          config,
+		Templates:       projectTemplates(),
+		SuiteScriptsDir: "src/FileCabinet/SuiteScripts",
+		ObjectsDir:      "src/Objects",
+		TestsDir:        testsDir,
+		Override: func(data *scaffold.TemplateData) error {
+			for _, key := range keys {
+				if err := data.Set(key, overrides[key]); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	files, err := generator.Script(scaffold.Script{
+		Type:             scriptType,
+		Name:             "sample_script",
+		Description:      "Sample script",
+		RecordType:       recordType,
+		Params:           params,
+		Variant:          variantFlag,
+		ReturnType:       returnTypeFlag,
+		ReturnRecordType: returnRecordType,
+		Language:         strings.ToLower(strings.TrimSpace(langFlag)),
+		Test:             scriptType != "common",
+	})
+	if err != nil {
+		return err
+	}
+
+	if jsonFlag {
+		type renderedFile struct {
+			Path    string `json:"path"`
+			Content string `json:"content"`
+		}
+		rendered := make([]renderedFile, len(files))
+		for i, file := range files {
+			rendered[i] = renderedFile{Path: file.Path, Content: string(file.Content)}
+		}
+		setJSONResult(rendered)
+		return nil
+	}
+
+	for i, file := range files {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("==> %s <==\n", file.Path)
+		fmt.Print(string(file.Content))
+		if !strings.HasSuffix(string(file.Content), "\n") {
+			fmt.Println()
+		}
+	}
+	return nil
+}
+
+// ejectedTemplatesDir is the project folder holding ejected templates. The project configuration
+// already uses the .netsuite-cli file name, so the folder lives next to it.
+const ejectedTemplatesDir = ".netsuite-cli-templates"
+
+// templatesDir returns the ejected templates folder of the project in the current directory, or
+// the user templates folder when global is set.
+func templatesDir(global bool) (string, error) {
+	if global {
+		userDir, err := UserConfigDir()
+		if err != nil {
+			return "", configError(err)
+		}
+		return filepath.Join(userDir, "templates"), nil
+	}
+	if _, err := loadProjectConfig(); err != nil {
+		return "", err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("error getting current directory: %v", err)
+	}
+	return filepath.Join(cwd, ejectedTemplatesDir), nil
+}
+
+// templateMatches reports whether template is one of the templates of name, a script type or
+// object such as suitelet, including its variant templates such as suitelet_form.ts.tmpl.
+func templateMatches(template, name string) bool {
+	return template == name || strings.HasPrefix(template, name+".") || strings.HasPrefix(template, name+"_")
+}
+
+// runTemplateEject writes the embedded templates matching name, or every overridable template
+// when name is empty, to the project or user templates folder.
+func runTemplateEject(name string) error {
+	var names []string
+	for _, template := range scaffold.Overridable() {
+		if name == "" || templateMatches(template, name) {
+			names = append(names, template)
+		}
+	}
+	if len(names) == 0 {
+		return validationError("no template named '%s'", name)
+	}
+
+	dir, err := templatesDir(templateGlobalFlag)
+	if err != nil {
+		return err
+	}
+	if err := makeDir(dir); err != nil {
+		return fmt.Errorf("error creating directory %s: %v", dir, err)
+	}
+
+	for _, template := range names {
+		path := filepath.Join(dir, template)
+		if fileExists(path) && !templateForceFlag {
+			reportFile("Skipped", path, "(already exists, use --force to overwrite)")
+			continue
+		}
+		content, err := scaffold.Embedded(template)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return fmt.Errorf("error writing %s: %v", path, err)
+		}
+		reportFile("Created", path, "")
+	}
+	return nil
+}
+
+// runTemplateList prints the overridable templates with the override used for each.
+func runTemplateList() error {
+	templates := projectTemplates()
+	names := scaffold.Overridable()
+
+	if jsonFlag {
+		type template struct {
+			Name     string `json:"name"`
+			Override string `json:"override,omitempty"`
+		}
+		list := make([]template, len(names))
+		for i, name := range names {
+			list[i] = template{Name: name, Override: templates.Locate(name)}
+		}
+		setJSONResult(list)
+		return nil
+	}
+
+	for _, name := range names {
+		source := "embedded"
+		if path := templates.Locate(name); path != "" {
+			source = path
+		}
+		fmt.Printf("%-30s %s\n", name, source)
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var templateInstallForceFlag bool
+
+// templateInstallCmd represents the template install command
+var templateInstallCmd = &cobra.Command{
+	Use:   "install [source[@version]]",
+	Short: "Install a template pack from a git repository",
+	Long: `Clone a template pack into the user configuration directory. The source is a git URL
+or a repository path such as github.com/acme/ns-templates, cloned over HTTPS, and the
+version a tag, branch or commit (default: the latest commit of the default branch).
+
+Inside a project the pack is pinned in .netsuite-cli as templatePack, so add uses its
+templates and teammates install the same version by running 'template install' without
+arguments. The project .netsuite-cli-templates/ and templates/ folders still take precedence
+over the pack, and the pack over the user templates folder.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref := ""
+		if len(args) > 0 {
+			ref = args[0]
+		}
+		return runTemplateInstall(ref)
+	},
+}
+
+func init() {
+	templateInstallCmd.Flags().BoolVar(&templateInstallForceFlag, "force", false, "Download the pack again even when it is installed")
+	templateCmd.AddCommand(templateInstallCmd)
+}
+
+// splitTemplatePack splits a template pack reference into its source and version. The version
+// follows the last '@', which is not taken for the user of an scp-like URL such as
+// git@github.com:acme/ns-templates.
+func splitTemplatePack(ref string) (string, string) {
+	i := strings.LastIndex(ref, "@")
+	if i <= 0 || strings.ContainsAny(ref[i+1:], "/:") {
+		return ref, ""
+	}
+	return ref[:i], ref[i+1:]
+}
+
+// templatePackURL returns the URL a template pack source is cloned from. URLs and absolute paths
+// are used as is and relative paths made absolute, other sources are repository paths cloned
+// over HTTPS.
+func templatePackURL(source string) string {
+	if strings.Contains(source, "://") || strings.HasPrefix(source, "git@") || filepath.IsAbs(source) {
+		return source
+	}
+	if strings.HasPrefix(source, ".") {
+		if abs, err := filepath.Abs(projectRelativePath(source)); err == nil {
+			return abs
+		}
+		return source
+	}
+	return "https://" + source
+}
+
+// templatePacksDir returns the folder of the user configuration directory the template packs are
+// installed in.
+func templatePacksDir() (string, error) {
+	userDir, err := UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(userDir, "packs"), nil
+}
+
+// templatePackDir returns the directory a template pack version is installed in.
+func templatePackDir(source, version string) (string, error) {
+	packsDir, err := templatePacksDir()
+	if err != nil {
+		return "", err
+	}
+	name := source
+	if i := strings.Index(name, "://"); i >= 0 {
+		name = name[i+3:]
+	}
+	name = strings.TrimPrefix(name, "git@")
+	name = strings.TrimSuffix(strings.ReplaceAll(name, ":", "/"), ".git")
+	var parts []string
+	for _, part := range strings.Split(filepath.ToSlash(name), "/") {
+		if part != "" && part != "." && part != ".." {
+			parts = append(parts, part)
+		}
+	}
+	return filepath.Join(packsDir, filepath.Join(parts...)+"@"+version), nil
+}
+
+// templatePackTemplatesDir returns the folder holding the templates of an installed pack: its
+// templates/ folder when it has one, else the root of the pack.
+func templatePackTemplatesDir(dir string) string {
+	if info, err := os.Stat(filepath.Join(dir, "templates")); err == nil && info.IsDir() {
+		return filepath.Join(dir, "templates")
+	}
+	return dir
+}
+
+// pinnedTemplatePackDir returns the templates folder of the template pack pinned by the project,
+// or an empty string when the project pins none. A pinned pack that is not installed is reported
+// with a warning, once.
+func pinnedTemplatePackDir(config *ProjectConfig) string {
+	if config.TemplatePack == "" {
+		return ""
+	}
+	source, version := splitTemplatePack(config.TemplatePack)
+	dir, err := templatePackDir(source, version)
+	if err != nil {
+		return ""
+	}
+	if _, err := os.Stat(dir); err != nil {
+		if !templatePackWarned {
+			logWarn("Template pack %s is not installed, run 'netsuite-cli template install' to install it.", config.TemplatePack)
+			templatePackWarned = true
+		}
+		return ""
+	}
+	return templatePackTemplatesDir(dir)
+}
+
+// templatePackWarned records that the missing pinned template pack was reported.
+var templatePackWarned bool
+
+// runTemplateInstall installs the template pack ref, or the pack pinned by the project when ref
+// is empty, and pins it in the project.
+func runTemplateInstall(ref string) error {
+	config, configErr := LoadConfig()
+	if ref == "" {
+		if configErr != nil {
+			return configError(configErr)
+		}
+		if config.TemplatePack == "" {
+			return validationError("the project pins no template pack, give the source to install")
+		}
+		ref = config.TemplatePack
+	}
+
+	source, version := splitTemplatePack(ref)
+	if source == "" {
+		return validationError("invalid template pack '%s'", ref)
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return toolError("git", fmt.Errorf("git is required to install template packs: %v", err))
+	}
+
+	packsDir, err := templatePacksDir()
+	if err != nil {
+		return configError(err)
+	}
+	if err := makeDir(packsDir); err != nil {
+		return fmt.Errorf("error creating directory %s: %v", packsDir, err)
+	}
+	tmpDir, err := os.MkdirTemp(packsDir, ".install-")
+	if err != nil {
+		return fmt.Errorf("error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	url := templatePackURL(source)
+	logInfo("Cloning %s...", url)
+	if err := runGit(tmpDir, "clone", "--quiet", url, "."); err != nil {
+		return toolError("git", fmt.Errorf("error cloning %s: %v", url, err))
+	}
+	if version != "" {
+		if err := runGit(tmpDir, "checkout", "--quiet", "--detach", version); err != nil {
+			return toolError("git", fmt.Errorf("error checking out %s: %v", version, err))
+		}
+	} else {
+		out, err := externalCommand("git", "-C", tmpDir, "rev-parse", "--short", "HEAD").Output()
+		if err != nil {
+			return toolError("git", fmt.Errorf("error reading the pack version: %v", err))
+		}
+		version = strings.TrimSpace(string(out))
+	}
+
+	dir, err := templatePackDir(source, version)
+	if err != nil {
+		return configError(err)
+	}
+	if fileExists(dir) {
+		if !templateInstallForceFlag {
+			logInfo("Template pack %s@%s is already installed in %s", source, version, dir)
+			return pinTemplatePack(config, source+"@"+version)
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("error removing %s: %v", dir, err)
+		}
+	}
+	if err := os.RemoveAll(filepath.Join(tmpDir, ".git")); err != nil {
+		return fmt.Errorf("error removing git metadata: %v", err)
+	}
+	if err := makeDir(filepath.Dir(dir)); err != nil {
+		return fmt.Errorf("error creating directory %s: %v", filepath.Dir(dir), err)
+	}
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return fmt.Errorf("error installing template pack: %v", err)
+	}
+	logInfo("Installed template pack %s@%s in %s", source, version, dir)
+	return pinTemplatePack(config, source+"@"+version)
+}
+
+// pinTemplatePack sets the template pack of the project when config, the configuration of the
+// current project, is not nil.
+func pinTemplatePack(config *ProjectConfig, pack string) error {
+	if config == nil || config.TemplatePack == pack {
+		return nil
+	}
+	config.TemplatePack = pack
+	if err := saveProjectConfig(config); err != nil {
+		return err
+	}
+	logInfo("Pinned template pack %s in .netsuite-cli", pack)
+	return nil
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+)
+
+var typesOutputFlag string
+
+// typesCmd represents the types command
+var typesCmd = &cobra.Command{
+	Use:   "types",
+	Short: "Generate TypeScript types from the account metadata",
+}
+
+// typesGenerateCmd represents the types generate command
+var typesGenerateCmd = &cobra.Command{
+	Use:   "generate <recordtype>...",
+	Short: "Generate TypeScript interfaces for record types",
+	Long: `Generate a TypeScript interface for each record type from the fields in the
+metadata cache (see 'netsuite-cli meta sync'). Select fields with enumerated values
+are typed as union types, so scripts get compile-time checking of field ids.`,
+	Example: `  netsuite-cli types generate salesorder customer
+  import {SalesOrder} from "../../types/salesorder";`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTypesGenerate(args)
+	},
+}
+
+func init() {
+	typesGenerateCmd.Flags().StringVarP(&typesOutputFlag, "output", "o", "types", "Output folder for the generated files")
+
+	typesCmd.AddCommand(typesGenerateCmd)
+	rootCmd.AddCommand(typesCmd)
+}
+
+// tsFieldTypes maps metadata field types to TypeScript types. Unlisted types map to string.
+var tsFieldTypes = map[string]string{
+	"boolean":   "boolean",
+	"integer":   "number",
+	"int32":     "number",
+	"int64":     "number",
+	"number":    "number",
+	"float":     "number",
+	"double":    "number",
+	"date":      "Date",
+	"date-time": "Date",
+}
+
+// toPascalCase converts a label such as "Sales Order" into an identifier such as SalesOrder.
+func toPascalCase(s string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, s)
+
+	var result strings.Builder
+	for _, word := range strings.Fields(cleaned) {
+		runes := []rune(word)
+		result.WriteRune(unicode.ToUpper(runes[0]))
+		result.WriteString(string(runes[1:]))
+	}
+	name := result.String()
+	if name != "" && unicode.IsDigit([]rune(name)[0]) {
+		name = "Record" + name
+	}
+	return name
+}
+
+// tsFieldType returns the TypeScript type of a field.
+func tsFieldType(field FieldMetadata) string {
+	if len(field.Values) > 0 {
+		literals := make([]string, len(field.Values))
+		for i, value := range field.Values {
+			literals[i] = fmt.Sprintf("%q", value)
+		}
+		return strings.Join(literals, " | ")
+	}
+	if tsType, ok := tsFieldTypes[field.Type]; ok {
+		return tsType
+	}
+	return "string"
+}
+
+// generateRecordInterface renders the TypeScript declarations of a record type.
+func generateRecordInterface(record *RecordMetadata) string {
+	label := record.Label
+	if label == "" {
+		label = record.Name
+	}
+	name := toPascalCase(label)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "/**\n")
+	fmt.Fprintf(&b, " * %s (%s) record fields\n", label, record.Name)
+	fmt.Fprintf(&b, " *\n")
+	fmt.Fprintf(&b, " * WARNING:\n")
+	fmt.Fprintf(&b, " * Generated by netsuite-cli from the account metadata, do not edit directly\n")
+	fmt.Fprintf(&b, " */\n")
+	fmt.Fprintf(&b, "export interface %s {\n", name)
+
+	for _, field := range record.Fields {
+		if field.Type == "sublist" {
+			continue
+		}
+		id := strings.ToLower(field.Id)
+		if field.Label != "" {
+			fmt.Fprintf(&b, "    /** %s */\n", strings.ReplaceAll(field.Label, "*/", "*\\/"))
+		}
+		fmt.Fprintf(&b, "    %s?: %s;\n", id, tsFieldType(field))
+	}
+	fmt.Fprintf(&b, "}\n\n")
+
+	fmt.Fprintf(&b, "/** Field ids of the %s record */\n", record.Name)
+	fmt.Fprintf(&b, "export type %sFieldId = keyof %s;\n", name, name)
+
+	return b.String()
+}
+
+// runTypesGenerate writes the TypeScript declarations of the given record types.
+func runTypesGenerate(recordTypes []string) error {
+	if _, err := loadProjectConfig(); err != nil {
+		return err
+	}
+
+	cache, err := LoadMetadataCache()
+	if err != nil {
+		return err
+	}
+	if cache == nil {
+		return errors.New("no metadata cached, run 'netsuite-cli meta sync' first")
+	}
+
+	if err := os.MkdirAll(typesOutputFlag, 0755); err != nil {
+		return fmt.Errorf("error creating directory %s: %v", typesOutputFlag, err)
+	}
+
+	for _, recordType := range recordTypes {
+		record := cache.FindRecord(recordType)
+		if record == nil {
+			return fmt.Errorf("record type '%s' is not in the metadata cache", recordType)
+		}
+		if len(record.Fields) == 0 {
+			return fmt.Errorf("no fields cached for %s, run 'netsuite-cli meta sync --record %s' first", record.Name, record.Name)
+		}
+
+		path := filepath.Join(typesOutputFlag, strings.ToLower(record.Name)+".d.ts")
+		if err := os.WriteFile(path, []byte(generateRecordInterface(record)), 0644); err != nil {
+			return fmt.Errorf("error writing %s: %v", path, err)
+		}
+		reportFile("Created", path, "")
+	}
+	return nil
+}
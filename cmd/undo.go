@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	undoYesFlag   bool
+	undoForceFlag bool
+)
+
+// undoCmd represents the undo command
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Roll back the most recent add",
+	Long: `Roll back the most recent generation recorded in the ` + lockFileName + ` manifest:
+delete the files it created, restore the files it overwrote, remove the directories
+it created if they are empty, and drop deploy.xml references to the deleted files.
+Files edited since they were generated are kept unless --force is given; they stay in
+the manifest, so a later 'undo --force' can still roll them back.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runUndo()
+	},
+}
+
+func init() {
+	undoCmd.Flags().BoolVarP(&undoYesFlag, "yes", "y", false, "Roll back without asking for confirmation")
+	undoCmd.Flags().BoolVar(&undoForceFlag, "force", false, "Also delete or restore the files edited since they were generated")
+
+	rootCmd.AddCommand(undoCmd)
+}
+
+// runUndo rolls back the most recent generation.
+func runUndo() error {
+	if _, err := loadProjectConfig(); err != nil {
+		return err
+	}
+
+	lock, err := LoadLockFile(".")
+	if err != nil {
+		return err
+	}
+	if len(lock.Generations) == 0 {
+		fmt.Println("Nothing to undo.")
+		return nil
+	}
+
+	generation := lock.Generations[len(lock.Generations)-1]
+	if strings.HasPrefix(generation.Command, "netsuite-cli create") {
+		return errors.New("the most recent generation created the project, delete the project directory to undo it")
+	}
+
+	modified := make(map[string]bool)
+	for _, file := range generation.Files {
+		modified[file.Path] = file.modifiedSince()
+	}
+
+	fmt.Printf("Undo '%s' (%s):\n", generation.Command, generation.Time.Local().Format("2006-01-02 15:04:05"))
+	for _, file := range generation.Files {
+		action := "delete "
+		if file.Previous != nil {
+			action = "restore"
+		}
+		switch {
+		case modified[file.Path] && !undoForceFlag:
+			fmt.Printf("  keep    %s (edited since it was generated, use --force to %s it)\n", file.Path, strings.TrimSpace(action))
+		case modified[file.Path]:
+			fmt.Printf("  %s %s (edited since it was generated)\n", action, file.Path)
+		default:
+			fmt.Printf("  %s %s\n", action, file.Path)
+		}
+	}
+
+	if !undoYesFlag {
+		reader := bufio.NewReader(os.Stdin)
+		confirmed, err := promptConfirm(reader, "Continue? (y/n): ")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Cancelled. No files were changed.")
+			return errCancelled
+		}
+	}
+
+	var deployRefs []string
+	var kept []GeneratedFile
+	for i := len(generation.Files) - 1; i >= 0; i-- {
+		file := generation.Files[i]
+		path := filepath.FromSlash(file.Path)
+		if modified[file.Path] && !undoForceFlag {
+			logWarn("Kept %s, it was edited since it was generated", path)
+			kept = append([]GeneratedFile{file}, kept...)
+			continue
+		}
+		if file.Previous != nil {
+			if err := os.WriteFile(path, []byte(*file.Previous), 0644); err != nil {
+				return fmt.Errorf("error restoring %s: %v", path, err)
+			}
+			reportFile("Restored", path, "")
+			continue
+		}
+
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error deleting %s: %v", path, err)
+		}
+		reportFile("Deleted", path, "")
+		if strings.HasSuffix(path, ".ts") {
+			jsPath := strings.TrimSuffix(path, ".ts") + ".js"
+			if err := os.Remove(jsPath); err == nil {
+				reportFile("Deleted", jsPath, "")
+			}
+			deployRefs = append(deployRefs, compiledSiblings(path)...)
+		} else {
+			deployRefs = append(deployRefs, toSDFPath(path))
+		}
+	}
+
+	var keptDirs []string
+	for i := len(generation.Directories) - 1; i >= 0; i-- {
+		dir := filepath.FromSlash(generation.Directories[i])
+		if err := os.Remove(dir); err == nil {
+			reportFile("Deleted", dir, "")
+		} else if !os.IsNotExist(err) {
+			keptDirs = append([]string{generation.Directories[i]}, keptDirs...)
+		}
+	}
+
+	if deployXMLPath, ok := findDeployXML(); ok && len(deployRefs) > 0 {
+		removed, err := removeDeployXMLReferences(deployXMLPath, deployRefs)
+		if err != nil {
+			logWarn("Failed to update %s: %v", deployXMLPath, err)
+		} else if removed > 0 {
+			logInfo("Removed %d reference(s) from %s", removed, deployXMLPath)
+		}
+	}
+
+	if len(kept) > 0 {
+		generation.Files = kept
+		generation.Directories = keptDirs
+		lock.Generations[len(lock.Generations)-1] = generation
+		logInfo("The %d kept file(s) remain in %s, use 'undo --force' to roll them back", len(kept), lockFileName)
+	} else {
+		lock.Generations = lock.Generations[:len(lock.Generations)-1]
+	}
+	if err := SaveLockFile(".", lock); err != nil {
+		return err
+	}
+	logInfo("✓ Undo complete.")
+	return nil
+}
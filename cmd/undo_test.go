@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// generate records a generation writing the given files, as add does.
+func generate(t *testing.T, command string, files ...string) {
+	t.Helper()
+	beginGeneration(command)
+	for _, file := range files {
+		recordGeneratedFile(file, nil, false)
+		if err := os.WriteFile(file, []byte(file), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := commitGeneration("."); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUndoKeepsEditedFilesInLock(t *testing.T) {
+	useDirs(t)
+	if err := os.WriteFile(".netsuite-cli", []byte(`{"version": 1, "projectName": "demo", "companyName": "acme"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	undoYesFlag = true
+	t.Cleanup(func() { undoYesFlag = false })
+
+	generate(t, "netsuite-cli add suitelet", "first.txt", "second.txt")
+	if err := os.WriteFile("second.txt", []byte("edited"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runUndo(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat("first.txt"); !os.IsNotExist(err) {
+		t.Error("first.txt was not deleted")
+	}
+	if _, err := os.Stat("second.txt"); err != nil {
+		t.Error("the edited second.txt was deleted")
+	}
+	lock, err := LoadLockFile(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lock.Generations) != 1 || len(lock.Generations[0].Files) != 1 || lock.Generations[0].Files[0].Path != "second.txt" {
+		t.Errorf("lock after undo = %+v, want the generation with second.txt only", lock.Generations)
+	}
+}
+
+func TestCommitGenerationDropsOldest(t *testing.T) {
+	useDirs(t)
+	for i := range maxGenerations + 5 {
+		generate(t, fmt.Sprintf("netsuite-cli add %d", i), fmt.Sprintf("file%d.txt", i))
+	}
+
+	lock, err := LoadLockFile(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lock.Generations) != maxGenerations {
+		t.Fatalf("%d generations kept, want %d", len(lock.Generations), maxGenerations)
+	}
+	if first := lock.Generations[0].Command; first != "netsuite-cli add 5" {
+		t.Errorf("oldest generation kept = %q, want netsuite-cli add 5", first)
+	}
+}
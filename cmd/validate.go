@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the project using the SuiteCloud CLI",
+	Long: `Run 'suitecloud project:validate' in the current project, parse its output
+and report errors and warnings grouped by file or object.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runValidate()
+	},
+}
+
+func init() {
+	validateCmd.Flags().StringVarP(&envFlag, "env", "e", "", "Project environment to validate against")
+
+	rootCmd.AddCommand(validateCmd)
+}
+
+// ValidationIssue represents a single error or warning reported by project:validate.
+type ValidationIssue struct {
+	Severity string `json:"severity"`
+	File     string `json:"file,omitempty"`
+	Object   string `json:"object,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Message  string `json:"message"`
+}
+
+// ValidationResult holds the parsed outcome of a project:validate run.
+type ValidationResult struct {
+	Success  bool              `json:"success"`
+	Errors   int               `json:"errors"`
+	Warnings int               `json:"warnings"`
+	Issues   []ValidationIssue `json:"issues"`
+}
+
+var (
+	validateSeverityRe = regexp.MustCompile(`(?i)^\s*(?:\*+\s*)?(error|warning)\b[\s:*-]*(.*)$`)
+	validateFileRe     = regexp.MustCompile(`(?i)^\s*(?:file|path)\s*:\s*(.+?)\s*$`)
+	validateObjectRe   = regexp.MustCompile(`(?i)^\s*object\s*:\s*(.+?)\s*$`)
+	validateDetailsRe  = regexp.MustCompile(`(?i)^\s*details\s*:\s*(.*)$`)
+	validateScriptIdRe = regexp.MustCompile(`\((custom[a-z]*_[a-z0-9_]+)\)`)
+	validateLineRe     = regexp.MustCompile(`(?i)\blines?\s*:?\s*(\d+)`)
+	validatePathLineRe = regexp.MustCompile(`([~\w./\\-]+\.(?:xml|js|ts|json))(?::(\d+))?`)
+	validateFailureRe  = regexp.MustCompile(`(?i)(validation (?:has )?failed|an error occurred|\berrors? (?:were|was) found)`)
+	validateBannerRe   = regexp.MustCompile(`(?i)^validation (?:has )?failed\.?$`)
+)
+
+// runValidate executes the project validation process.
+func runValidate() error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	suiteCloudCmd, err := ensureSuiteCloudCommand()
+	if err != nil {
+		return err
+	}
+
+	restore, err := activateEnvironment(config, envFlag)
+	if err != nil {
+		return err
+	}
+
+	var output bytes.Buffer
+	validateProjectCmd := suiteCloudExec(suiteCloudCmd, "project:validate")
+	validateProjectCmd.Stdout = &output
+	validateProjectCmd.Stderr = &output
+	logCommand(validateProjectCmd)
+	runErr := validateProjectCmd.Run()
+	restore()
+
+	if _, ok := runErr.(*exec.ExitError); runErr != nil && !ok {
+		return toolError("suitecloud", fmt.Errorf("error running project:validate: %v", runErr))
+	}
+
+	result := parseValidateOutput(output.String())
+	if runErr != nil && result.Errors == 0 {
+		result.Issues = append(result.Issues, ValidationIssue{
+			Severity: "error",
+			Message:  fmt.Sprintf("project:validate exited with an error: %v", runErr),
+		})
+		result.Errors++
+	}
+	result.Success = result.Errors == 0
+	recordCommandResult("validate", config, result.Success, fmt.Sprintf("%d error(s), %d warning(s)", result.Errors, result.Warnings))
+
+	if jsonFlag {
+		setJSONResult(result)
+	} else {
+		if rawFlag {
+			fmt.Print(output.String())
+		} else {
+			logDebug("suitecloud output:\n%s", strings.TrimRight(output.String(), "\n"))
+		}
+		printValidationResult(result)
+	}
+
+	if !result.Success {
+		return validationError("project validation failed with %d error(s)", result.Errors)
+	}
+	return nil
+}
+
+// parseValidateOutput extracts errors and warnings from the project:validate output.
+func parseValidateOutput(output string) ValidationResult {
+	result := ValidationResult{Issues: []ValidationIssue{}}
+	var current *ValidationIssue
+	failed := false
+	banner := ""
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.Message = strings.TrimSpace(current.Message)
+		if current.Message != "" || current.File != "" || current.Object != "" {
+			if current.Severity == "error" {
+				result.Errors++
+			} else {
+				result.Warnings++
+			}
+			result.Issues = append(result.Issues, *current)
+		}
+		current = nil
+	}
+
+	for _, rawLine := range strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n") {
+		line := strings.TrimSpace(rawLine)
+		if line == "" {
+			flush()
+			continue
+		}
+
+		if validateFailureRe.MatchString(line) {
+			failed = true
+		}
+		// "Validation failed." only announces the errors that follow it.
+		if validateBannerRe.MatchString(line) {
+			flush()
+			banner = line
+			continue
+		}
+
+		if m := validateSeverityRe.FindStringSubmatch(line); m != nil {
+			flush()
+			current = &ValidationIssue{Severity: strings.ToLower(m[1]), Message: m[2]}
+			fillIssueLocation(current, m[2])
+			continue
+		}
+
+		if current == nil {
+			if !failed {
+				continue
+			}
+			current = &ValidationIssue{Severity: "error"}
+		}
+
+		switch {
+		case validateFileRe.MatchString(line):
+			current.File = validateFileRe.FindStringSubmatch(line)[1]
+			fillIssueLocation(current, current.File)
+		case validateObjectRe.MatchString(line):
+			current.Object = validateObjectRe.FindStringSubmatch(line)[1]
+		case validateDetailsRe.MatchString(line):
+			details := validateDetailsRe.FindStringSubmatch(line)[1]
+			current.Message = joinMessage(current.Message, details)
+			fillIssueLocation(current, details)
+		default:
+			current.Message = joinMessage(current.Message, line)
+			fillIssueLocation(current, line)
+		}
+	}
+	flush()
+
+	if banner != "" && result.Errors == 0 {
+		result.Issues = append(result.Issues, ValidationIssue{Severity: "error", Message: banner})
+		result.Errors++
+	}
+	return result
+}
+
+// fillIssueLocation populates missing file, object and line information from a text fragment.
+func fillIssueLocation(issue *ValidationIssue, text string) {
+	if issue.Object == "" {
+		if m := validateScriptIdRe.FindStringSubmatch(text); m != nil {
+			issue.Object = m[1]
+		}
+	}
+	if m := validatePathLineRe.FindStringSubmatch(text); m != nil {
+		if issue.File == "" {
+			issue.File = m[1]
+		}
+		if issue.Line == 0 && m[2] != "" {
+			issue.Line, _ = strconv.Atoi(m[2])
+		}
+	}
+	if issue.Line == 0 {
+		if m := validateLineRe.FindStringSubmatch(text); m != nil {
+			issue.Line, _ = strconv.Atoi(m[1])
+		}
+	}
+}
+
+// joinMessage appends a line of text to an existing message.
+func joinMessage(message, text string) string {
+	if message == "" {
+		return text
+	}
+	return message + " " + text
+}
+
+// printValidationResult prints the validation issues grouped by file or object.
+func printValidationResult(result ValidationResult) {
+	if len(result.Issues) == 0 {
+		fmt.Println("✓ Validation passed with no errors or warnings.")
+		return
+	}
+
+	groups := make(map[string][]ValidationIssue)
+	for _, issue := range result.Issues {
+		key := issue.File
+		if key == "" {
+			key = issue.Object
+		}
+		if key == "" {
+			key = "(project)"
+		}
+		groups[key] = append(groups[key], issue)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Printf("\n%s\n", key)
+		for _, issue := range groups[key] {
+			location := ""
+			if issue.Line > 0 {
+				location = fmt.Sprintf("line %d: ", issue.Line)
+			}
+			fmt.Printf("  %s: %s%s\n", strings.ToUpper(issue.Severity), location, issue.Message)
+		}
+	}
+
+	fmt.Printf("\n%d error(s), %d warning(s)\n", result.Errors, result.Warnings)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// readFixture returns the contents of a captured SuiteCloud CLI output in testdata/suitecloud.
+func readFixture(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "suitecloud", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestParseValidateOutput(t *testing.T) {
+	tests := map[string]ValidationResult{
+		"validate-success.txt": {Issues: []ValidationIssue{}},
+		"validate-warnings.txt": {
+			Warnings: 2,
+			Issues: []ValidationIssue{
+				{
+					Severity: "warning",
+					File:     "~/Objects/customscript_acme_sl_orders.xml",
+					Object:   "customscript_acme_sl_orders",
+					Message:  "One or more potential issues were found during custom object validation. (customscript_acme_sl_orders) The isinactive field of the deployment customdeploy_acme_sl_orders is deprecated.",
+				},
+				{
+					Severity: "warning",
+					File:     "~/manifest.xml",
+					Message:  "The manifest declares the SUBSIDIARIES feature, which no object of the project uses.",
+				},
+			},
+		},
+		"validate-errors.txt": {
+			Errors:   2,
+			Warnings: 1,
+			Issues: []ValidationIssue{
+				{
+					Severity: "error",
+					File:     "~/Objects/customscript_acme_sl_orders.xml",
+					Object:   "customscript_acme_sl_orders",
+					Message:  "An error occurred during custom object validation. (customscript_acme_sl_orders) The scriptfile field references a file that is not in the project: [/SuiteScripts/acme_orders_suitelet.js].",
+				},
+				{
+					Severity: "error",
+					File:     "~/Objects/customrecord_acme_order.xml",
+					Object:   "customrecord_acme_order",
+					Line:     4,
+					Message:  "An error occurred during custom object validation. (customrecord_acme_order) The recordname field is missing, line 4.",
+				},
+				{
+					Severity: "warning",
+					File:     "~/Objects/customsearch_acme_orders.xml",
+					Object:   "customsearch_acme_orders",
+					Message:  "One or more potential issues were found during custom object validation. (customsearch_acme_orders) The search references the inactive field custbody_acme_legacy.",
+				},
+			},
+		},
+	}
+	for name, want := range tests {
+		got := parseValidateOutput(readFixture(t, name))
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: parseValidateOutput =\n%+v\nwant\n%+v", name, got, want)
+		}
+	}
+}
+
+func TestParseValidateOutputBannerOnly(t *testing.T) {
+	got := parseValidateOutput("*** ERROR ***\n\nValidation failed.\n")
+	want := ValidationResult{Errors: 1, Issues: []ValidationIssue{{Severity: "error", Message: "Validation failed."}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseValidateOutput =\n%+v\nwant\n%+v", got, want)
+	}
+}
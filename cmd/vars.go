@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// varFlags holds the template variables given to add with --var.
+var varFlags []string
+
+// resolveTemplateVars returns the template variables of the project and user configuration, with
+// the values given with --var. Variables left empty are prompted for, or fail with --yes.
+func resolveTemplateVars(config *ProjectConfig, reader *bufio.Reader) (map[string]string, error) {
+	userConfig, err := LoadUserConfig()
+	if err != nil {
+		return nil, configError(err)
+	}
+	vars := TemplateVars(userConfig, config)
+	for _, spec := range varFlags {
+		name, value, ok := strings.Cut(spec, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, validationError("invalid --var '%s', expected name=value", spec)
+		}
+		vars[name] = value
+	}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if vars[name] != "" {
+			continue
+		}
+		if yesFlag {
+			return nil, validationError("template variable %s has no value, set it with --var %s=<value>", name, name)
+		}
+		for vars[name] == "" {
+			value, err := promptLine(reader, fmt.Sprintf("Enter %s: ", name))
+			if err != nil {
+				return nil, err
+			}
+			vars[name] = value
+		}
+	}
+	return vars, nil
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"netsuite-cli/internal/update"
+
+	"github.com/spf13/cobra"
+)
+
+var versionCheckFlag bool
+
+// buildInfo describes the running binary. Release builds get it from the linker flags set by
+// GoReleaser, binaries built with 'go install' from the module and VCS information.
+var buildInfo = BuildInfo{Version: "dev"}
+
+// BuildInfo describes the running binary and its environment.
+type BuildInfo struct {
+	Version    string `json:"version"`
+	Commit     string `json:"commit,omitempty"`
+	Date       string `json:"date,omitempty"`
+	GoVersion  string `json:"goVersion"`
+	Platform   string `json:"platform"`
+	SuiteCloud string `json:"suiteCloud,omitempty"`
+	Latest     string `json:"latest,omitempty"`
+	UpdateURL  string `json:"updateUrl,omitempty"`
+}
+
+// SetBuildInfo sets the version, commit and build date of the binary. Empty values are taken
+// from the build information embedded by the Go toolchain.
+func SetBuildInfo(version, commit, date string) {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		// Untagged builds get a 0.0.0 pseudo-version, which is no release.
+		if v := info.Main.Version; v != "" && v != "(devel)" && !strings.HasPrefix(v, "v0.0.0-") {
+			buildInfo.Version = strings.TrimPrefix(v, "v")
+		}
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				buildInfo.Commit = setting.Value
+			case "vcs.time":
+				buildInfo.Date = setting.Value
+			}
+		}
+	}
+	if version != "" {
+		buildInfo.Version = strings.TrimPrefix(version, "v")
+	}
+	if commit != "" {
+		buildInfo.Commit = commit
+	}
+	if date != "" {
+		buildInfo.Date = date
+	}
+	rootCmd.Version = buildInfo.Version
+}
+
+// versionCmd represents the version command
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the version of netsuite-cli and of the SuiteCloud CLI",
+	Long: `Print the version, commit and build date of netsuite-cli, the Go version it was built
+with and the version of the SuiteCloud CLI found on the PATH.
+
+With --check the latest release is looked up on GitHub. To be notified of new releases
+after any command, at most once a day, enable the check in the user configuration:
+
+  netsuite-cli config set updateCheck true --global`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runVersion()
+	},
+}
+
+func init() {
+	versionCmd.Flags().BoolVar(&versionCheckFlag, "check", false, "Check GitHub for a newer release")
+
+	rootCmd.AddCommand(versionCmd)
+}
+
+// runVersion prints the build information and, with --check, the latest release.
+func runVersion() error {
+	info := buildInfo
+	info.GoVersion = runtime.Version()
+	info.Platform = runtime.GOOS + "/" + runtime.GOARCH
+	if suiteCloudCmd := getSuiteCloudCommand(); suiteCloudCmd != "" {
+		if output, err := runVersionCommand(suiteCloudCmd + " --version"); err == nil {
+			info.SuiteCloud = firstLine(output)
+		}
+	}
+
+	var checkErr error
+	if versionCheckFlag {
+		var release *update.Release
+		if release, checkErr = checkForUpdate(); checkErr == nil {
+			info.Latest = release.Version()
+			info.UpdateURL = release.URL
+		}
+	}
+
+	if jsonFlag {
+		setJSONResult(info)
+		return checkErr
+	}
+
+	fmt.Printf("netsuite-cli %s\n", info.Version)
+	if info.Commit != "" {
+		fmt.Printf("  commit:     %s\n", info.Commit)
+	}
+	if info.Date != "" {
+		fmt.Printf("  built:      %s\n", info.Date)
+	}
+	fmt.Printf("  go:         %s %s\n", info.GoVersion, info.Platform)
+	if info.SuiteCloud != "" {
+		fmt.Printf("  suitecloud: %s\n", info.SuiteCloud)
+	} else {
+		fmt.Println("  suitecloud: not found")
+	}
+	if checkErr != nil {
+		return checkErr
+	}
+	if versionCheckFlag {
+		if update.Newer(info.Version, info.Latest) {
+			printUpdateNotice(info.Latest, info.UpdateURL)
+		} else {
+			logInfo("✓ netsuite-cli is up to date (latest release: %s).", info.Latest)
+		}
+	}
+	return nil
+}
+
+// updateCheckState is the outcome of the last automatic update check, saved so GitHub is
+// queried at most once per updateCheckInterval.
+type updateCheckState struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	Latest    string    `json:"latest"`
+	URL       string    `json:"url"`
+}
+
+// updateCheckInterval is how often the automatic update check queries GitHub.
+const updateCheckInterval = 24 * time.Hour
+
+// updateCheckTimeout bounds the request of the automatic update check, so it never holds up a
+// command for long.
+const updateCheckTimeout = 3 * time.Second
+
+// checkForUpdate returns the latest release and saves it as the last automatic check.
+func checkForUpdate() (*update.Release, error) {
+	ctx, cancel := context.WithTimeout(runContext, 10*time.Second)
+	defer cancel()
+	release, err := update.Latest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	saveUpdateCheckState(updateCheckState{CheckedAt: time.Now(), Latest: release.Version(), URL: release.URL})
+	return release, nil
+}
+
+// updateCheckStatePath returns the path of the file holding the last automatic update check.
+func updateCheckStatePath() (string, error) {
+	userDir, err := UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(userDir, "update-check.json"), nil
+}
+
+// saveUpdateCheckState saves the outcome of an update check. Errors are ignored, the check is
+// simply repeated by the next command.
+func saveUpdateCheckState(state updateCheckState) {
+	path, err := updateCheckStatePath()
+	if err != nil {
+		return
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err == nil {
+		os.WriteFile(path, data, 0o644)
+	}
+}
+
+// notifyUpdate prints a notice to stderr when the user enabled updateCheck and a newer release
+// exists. GitHub is queried at most once a day; in between the saved result is used. Nothing is
+// printed with --json or --quiet, when stderr is not a terminal or for the version command,
+// which reports updates itself.
+func notifyUpdate(command *cobra.Command) {
+	if jsonFlag || quietFlag || !isTerminal(os.Stderr) || command == versionCmd {
+		return
+	}
+	userConfig, err := LoadUserConfig()
+	if err != nil || userConfig == nil || !strings.EqualFold(userConfig.UpdateCheck, "true") {
+		return
+	}
+
+	path, err := updateCheckStatePath()
+	if err != nil {
+		return
+	}
+	var state updateCheckState
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &state)
+	}
+	if time.Since(state.CheckedAt) > updateCheckInterval {
+		ctx, cancel := context.WithTimeout(context.Background(), updateCheckTimeout)
+		defer cancel()
+		release, err := update.Latest(ctx)
+		if err != nil {
+			logDebug("update check failed: %v", err)
+			// Retry on the next day rather than slowing down every command while offline.
+			state.CheckedAt = time.Now()
+			saveUpdateCheckState(state)
+			return
+		}
+		state = updateCheckState{CheckedAt: time.Now(), Latest: release.Version(), URL: release.URL}
+		saveUpdateCheckState(state)
+	}
+	if update.Newer(buildInfo.Version, state.Latest) {
+		printUpdateNotice(state.Latest, state.URL)
+	}
+}
+
+// printUpdateNotice prints to stderr that a newer release exists.
+func printUpdateNotice(latest, url string) {
+	notice := fmt.Sprintf("A new version of netsuite-cli is available: %s → %s", buildInfo.Version, latest)
+	fmt.Fprintln(os.Stderr, colorize(os.Stderr, colorYellow, notice))
+	if url != "" {
+		fmt.Fprintf(os.Stderr, "  %s\n", url)
+	}
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchDeployFlag   bool
+	watchIntervalFlag time.Duration
+)
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Rebuild scripts on change and optionally upload them",
+	Long: `Monitor the SuiteScripts tree for changes to TypeScript files, rebuild the
+project when they change and, with --deploy, upload the compiled files to the
+File Cabinet using 'suitecloud file:upload'.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWatch()
+	},
+}
+
+func init() {
+	watchCmd.Flags().BoolVarP(&watchDeployFlag, "deploy", "d", false, "Upload changed files with 'suitecloud file:upload' after each build")
+	watchCmd.Flags().DurationVarP(&watchIntervalFlag, "interval", "i", time.Second, "Polling interval for file changes")
+	watchCmd.Flags().StringVar(&buildTsconfigFlag, "tsconfig", "tsconfig.json", "Path to the tsconfig file")
+
+	rootCmd.AddCommand(watchCmd)
+}
+
+// toFileCabinetPath converts a local FileCabinet path into the absolute File Cabinet path used by file:upload.
+func toFileCabinetPath(localPath string) string {
+	return strings.TrimPrefix(toSDFPath(localPath), "~/FileCabinet")
+}
+
+// snapshotSources records the modification time of every TypeScript file under dir.
+func snapshotSources(dir string) map[string]time.Time {
+	snapshot := make(map[string]time.Time)
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".ts" {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			snapshot[path] = info.ModTime()
+		}
+		return nil
+	})
+	return snapshot
+}
+
+// changedSources returns the files that were added or modified between two snapshots.
+func changedSources(previous, current map[string]time.Time) []string {
+	var changed []string
+	for path, modTime := range current {
+		if prev, ok := previous[path]; !ok || !prev.Equal(modTime) {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// uploadFiles uploads local FileCabinet files with 'suitecloud file:upload'.
+func uploadFiles(suiteCloudCmd string, localPaths []string) error {
+	args := []string{"file:upload", "--paths"}
+	for _, path := range localPaths {
+		args = append(args, toFileCabinetPath(path))
+	}
+
+	uploadCmd := suiteCloudExec(suiteCloudCmd, args...)
+	attachTerminal(uploadCmd)
+	if _, err := runSuiteCloud(uploadCmd); err != nil {
+		return toolError("suitecloud", fmt.Errorf("file upload failed: %v", err))
+	}
+	return nil
+}
+
+// runWatch executes the watch loop.
+func runWatch() error {
+	if _, err := loadProjectConfig(); err != nil {
+		return err
+	}
+
+	suiteCloudCmd := ""
+	if watchDeployFlag {
+		var err error
+		if suiteCloudCmd, err = ensureSuiteCloudCommand(); err != nil {
+			return err
+		}
+	}
+
+	suiteScriptsDir, err := findSuiteScriptsDir()
+	if err != nil {
+		return err
+	}
+
+	logInfo("Watching %s for changes (press Ctrl+C to stop)...", suiteScriptsDir)
+	snapshot := snapshotSources(suiteScriptsDir)
+
+	for {
+		if err := sleepContext(watchIntervalFlag); err != nil {
+			return err
+		}
+
+		current := snapshotSources(suiteScriptsDir)
+		changed := changedSources(snapshot, current)
+		snapshot = current
+		if len(changed) == 0 {
+			continue
+		}
+
+		logInfo("\n[%s] Changed: %s", time.Now().Format("15:04:05"), strings.Join(changed, ", "))
+		if err := compileTypeScript(buildTsconfigFlag); err != nil {
+			logError("%v", err)
+			continue
+		}
+		logInfo("✓ Build completed successfully.")
+
+		if !watchDeployFlag {
+			continue
+		}
+
+		var uploads []string
+		for _, path := range changed {
+			jsPath := strings.TrimSuffix(path, ".ts") + ".js"
+			if _, err := os.Stat(jsPath); err == nil {
+				uploads = append(uploads, jsPath)
+			}
+		}
+		if len(uploads) == 0 {
+			continue
+		}
+		if err := uploadFiles(suiteCloudCmd, uploads); err != nil {
+			logError("%v", err)
+			continue
+		}
+		logInfo("✓ Uploaded %d file(s).", len(uploads))
+	}
+}
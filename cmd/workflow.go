@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"netsuite-cli/pkg/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	workflowWithActionFlag bool
+	workflowActionNameFlag string
+)
+
+// workflowCmd represents the add workflow command
+var workflowCmd = &cobra.Command{
+	Use:   "workflow [name]",
+	Short: "Workflows automate record processes through states and transitions, optionally calling workflow action scripts",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAddWorkflow(args)
+	},
+}
+
+func init() {
+	workflowCmd.Flags().BoolVar(&workflowWithActionFlag, "with-action", false, "Also generate a companion workflow action script wired into the start state")
+	workflowCmd.Flags().StringVar(&workflowActionNameFlag, "action-name", "", "Name of the companion workflow action script (default: <workflow>_action)")
+
+	addCmd.AddCommand(workflowCmd)
+}
+
+// runAddWorkflow executes the logic for adding a new workflow.
+func runAddWorkflow(args []string) error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+
+	workflowName := ""
+	if len(args) > 0 {
+		workflowName = strings.TrimSpace(args[0])
+	}
+	if workflowName == "" && !yesFlag {
+		workflowName, err = promptLine(reader, "Enter workflow name: ")
+		if err != nil {
+			return err
+		}
+	}
+	workflowName = strings.TrimPrefix(workflowName, "customworkflow_")
+	if workflowName == "" {
+		return errors.New("workflow name is required")
+	}
+	workflow := scaffold.Workflow{Name: workflowName}
+	scriptId, err := workflow.ScriptId(config)
+	if err != nil {
+		return validationError("%v", err)
+	}
+
+	workflow.Description = strings.TrimSpace(descriptionFlag)
+	if workflow.Description == "" && !yesFlag {
+		workflow.Description, err = promptLine(reader, fmt.Sprintf("Enter workflow description (default: %s description): ", workflowName))
+		if err != nil {
+			return err
+		}
+	}
+	description := workflow.Description
+	if description == "" {
+		description = workflowName + " description"
+	}
+
+	recordType := strings.TrimSpace(recordTypeFlag)
+	if recordType == "" && !yesFlag {
+		recordType, err = promptLine(reader, "Enter record type (e.g., CUSTOMER, SALESORDER, INVOICE): ")
+		if err != nil {
+			return err
+		}
+	}
+	if recordType == "" {
+		return errors.New("record type is required for workflows")
+	}
+
+	withAction := workflowWithActionFlag
+	if !withAction && !yesFlag {
+		if withAction, err = promptConfirm(reader, "Generate a companion workflow action script? (y/n, default: n): "); err != nil {
+			return err
+		}
+	}
+
+	workflow.RecordType = recordType
+	if err := checkObjectIdAvailable(scriptId); err != nil {
+		return err
+	}
+
+	if withAction {
+		actionName := strings.TrimSpace(workflowActionNameFlag)
+		if actionName == "" {
+			actionName = toScriptId(workflowName) + "_action"
+		}
+		actionNaming, err := config.ScriptNaming(actionName, "workflowaction")
+		if err != nil {
+			return err
+		}
+		if err := checkObjectIdAvailable(actionNaming.ScriptId); err != nil {
+			return err
+		}
+		if err := checkObjectIdAvailable(actionNaming.DeploymentId); err != nil {
+			return err
+		}
+
+		opts := scriptOptions{description: description, recordType: recordType}
+		if err := addScript(reader, "workflowaction", []string{actionName}, opts); err != nil {
+			return err
+		}
+		workflow.ActionName = actionName
+	}
+
+	generator, err := objectGenerator(config)
+	if err != nil {
+		return err
+	}
+	file, err := generator.Workflow(workflow)
+	if err != nil {
+		return err
+	}
+	if written, err := writeObject(reader, file); err != nil || !written {
+		return err
+	}
+	ensureManifestFeatures("workflow")
+	return nil
+}
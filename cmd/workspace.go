@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"netsuite-cli/pkg/config"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectFlag   string
+	keepGoingFlag bool
+)
+
+// wsCmd represents the ws command
+var wsCmd = &cobra.Command{
+	Use:   "ws",
+	Short: "Work with the projects of a workspace",
+	Long: `Work with the SDF projects listed in the .netsuite-cli.workspace file at the root of a
+repository. Run any other command against one of them with --project or -p.`,
+}
+
+var wsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the projects of the workspace",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWorkspaceList()
+	},
+}
+
+var wsDeployAllCmd = &cobra.Command{
+	Use:   "deploy-all",
+	Short: "Deploy every project of the workspace",
+	Long: `Run 'deploy' in every project of the workspace, in the order they are listed. It stops
+at the first failing project unless --keep-going is given.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		deployArgs := []string{"deploy"}
+		if envFlag != "" {
+			deployArgs = append(deployArgs, "--env", envFlag)
+		}
+		if deploySkipBuildFlag {
+			deployArgs = append(deployArgs, "--skip-build")
+		}
+		failed, err := runInWorkspaceProjects(deployArgs, keepGoingFlag)
+		if err != nil {
+			return err
+		}
+		if len(failed) > 0 {
+			return toolError("suitecloud", fmt.Errorf("deployment failed for %s", strings.Join(failed, ", ")))
+		}
+		return nil
+	},
+}
+
+var wsValidateAllCmd = &cobra.Command{
+	Use:   "validate-all",
+	Short: "Validate every project of the workspace",
+	Long:  `Run 'validate' in every project of the workspace and report the projects that failed.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validateArgs := []string{"validate"}
+		if envFlag != "" {
+			validateArgs = append(validateArgs, "--env", envFlag)
+		}
+		failed, err := runInWorkspaceProjects(validateArgs, true)
+		if err != nil {
+			return err
+		}
+		if len(failed) > 0 {
+			return validationError("validation failed for %s", strings.Join(failed, ", "))
+		}
+		return nil
+	},
+}
+
+func init() {
+	wsDeployAllCmd.Flags().StringVarP(&envFlag, "env", "e", "", "Project environment to deploy to")
+	wsDeployAllCmd.Flags().BoolVar(&deploySkipBuildFlag, "skip-build", false, "Skip the TypeScript build before deploying")
+	wsDeployAllCmd.Flags().BoolVar(&keepGoingFlag, "keep-going", false, "Deploy the remaining projects when one fails")
+	wsValidateAllCmd.Flags().StringVarP(&envFlag, "env", "e", "", "Project environment to validate against")
+
+	wsCmd.AddCommand(wsListCmd, wsDeployAllCmd, wsValidateAllCmd)
+	rootCmd.AddCommand(wsCmd)
+}
+
+// loadWorkspace finds and reads the workspace containing the current directory.
+func loadWorkspace() (string, *config.Workspace, error) {
+	root, err := config.FindWorkspace(".")
+	if err != nil {
+		return "", nil, configError(err)
+	}
+	workspace, err := config.LoadWorkspace(root)
+	if err != nil {
+		return "", nil, configError(err)
+	}
+	return root, workspace, nil
+}
+
+// enterWorkspaceProject changes to the directory of the workspace project selected with --project.
+func enterWorkspaceProject() error {
+	if projectFlag == "" {
+		return nil
+	}
+	name := projectFlag
+	projectFlag = ""
+
+	root, workspace, err := loadWorkspace()
+	if err != nil {
+		return err
+	}
+	project := workspace.Project(name)
+	if project == nil {
+		return configError(fmt.Errorf("unknown workspace project '%s' (available: %s)", name, strings.Join(workspace.Names(), ", ")))
+	}
+	if err := os.Chdir(filepath.Join(root, project.Path)); err != nil {
+		return configError(fmt.Errorf("cannot change to workspace project %s: %v", project.Name, err))
+	}
+	return nil
+}
+
+// runWorkspaceList prints the projects of the workspace with the name and prefix of their
+// configuration.
+func runWorkspaceList() error {
+	root, workspace, err := loadWorkspace()
+	if err != nil {
+		return err
+	}
+
+	type workspaceProject struct {
+		Name        string `json:"name"`
+		Path        string `json:"path"`
+		ProjectName string `json:"projectName,omitempty"`
+		Prefix      string `json:"prefix,omitempty"`
+		Error       string `json:"error,omitempty"`
+	}
+	projects := make([]workspaceProject, 0, len(workspace.Projects))
+	for _, project := range workspace.Projects {
+		entry := workspaceProject{Name: project.Name, Path: filepath.ToSlash(project.Path)}
+		if loaded, _, err := config.Load(filepath.Join(root, project.Path)); err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.ProjectName, entry.Prefix = loaded.ProjectName, loaded.Prefix()
+		}
+		projects = append(projects, entry)
+	}
+
+	if jsonFlag {
+		setJSONResult(projects)
+		return nil
+	}
+	if len(projects) == 0 {
+		fmt.Printf("No projects listed in %s.\n", filepath.Join(root, config.WorkspaceFileName))
+		return nil
+	}
+	for _, project := range projects {
+		details := fmt.Sprintf("%s (prefix %s)", project.ProjectName, project.Prefix)
+		if project.Error != "" {
+			details = "Warning: " + project.Error
+		}
+		fmt.Printf("%s\t%s\t%s\n", project.Name, project.Path, details)
+	}
+	return nil
+}
+
+// runInWorkspaceProjects runs the CLI with args in every project of the workspace and returns
+// the names of the projects where it failed. Without keepGoing it stops at the first failure.
+func runInWorkspaceProjects(args []string, keepGoing bool) ([]string, error) {
+	root, workspace, err := loadWorkspace()
+	if err != nil {
+		return nil, err
+	}
+	if len(workspace.Projects) == 0 {
+		return nil, configError(errors.New("the workspace lists no projects"))
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("error locating the netsuite-cli executable: %v", err)
+	}
+
+	var failed []string
+	for i, project := range workspace.Projects {
+		fmt.Printf("\n==> %s (%s)\n", project.Name, filepath.ToSlash(project.Path))
+
+		projectArgs := append([]string{"--project-dir", filepath.Join(root, project.Path)}, args...)
+		if npxFlag {
+			projectArgs = append(projectArgs, "--npx")
+		}
+		projectCmd := externalCommand(executable, projectArgs...)
+		attachTerminal(projectCmd)
+		projectCmd.Stdout = os.Stdout
+		projectCmd.Stderr = os.Stderr
+		logCommand(projectCmd)
+		if err := projectCmd.Run(); err != nil {
+			failed = append(failed, project.Name)
+			if !keepGoing {
+				if remaining := len(workspace.Projects) - i - 1; remaining > 0 {
+					logInfo("Skipped %d remaining project(s), use --keep-going to continue after a failure.", remaining)
+				}
+				break
+			}
+		}
+	}
+	return failed, nil
+}
@@ -0,0 +1,194 @@
+// Package auth implements the NetSuite OAuth 2.0 client credentials (machine-to-machine) flow.
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultScopes are the scopes requested when the credentials do not specify any.
+var DefaultScopes = []string{"restlets", "rest_webservices"}
+
+// assertionLifetime is how long a signed client assertion is valid. NetSuite rejects assertions
+// valid for more than one hour.
+const assertionLifetime = 5 * time.Minute
+
+// Credentials holds the integration record and certificate used for machine-to-machine authentication.
+type Credentials struct {
+	AccountID      string
+	ClientID       string
+	CertificateID  string
+	PrivateKeyPath string
+	// PrivateKey is the PEM encoded private key, used instead of PrivateKeyPath when set.
+	PrivateKey []byte
+	Scopes     []string
+}
+
+// Validate reports whether all the fields required to request a token are set.
+func (c Credentials) Validate() error {
+	var missing []string
+	if c.AccountID == "" {
+		missing = append(missing, "account ID")
+	}
+	if c.ClientID == "" {
+		missing = append(missing, "client ID")
+	}
+	if c.CertificateID == "" {
+		missing = append(missing, "certificate ID")
+	}
+	if c.PrivateKeyPath == "" && len(c.PrivateKey) == 0 {
+		missing = append(missing, "private key")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// AccountHost returns the account specific host name, e.g. 1234567-sb1 for account 1234567_SB1.
+func AccountHost(accountID string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(accountID)), "_", "-")
+}
+
+// RESTBaseURL returns the base URL of the SuiteTalk REST services of the account.
+func RESTBaseURL(accountID string) string {
+	return fmt.Sprintf("https://%s.suitetalk.api.netsuite.com/services/rest", AccountHost(accountID))
+}
+
+// RESTletBaseURL returns the base URL of the RESTlet services of the account.
+func RESTletBaseURL(accountID string) string {
+	return fmt.Sprintf("https://%s.restlets.api.netsuite.com/app/site/hosting/restlet.nl", AccountHost(accountID))
+}
+
+// TokenURL returns the OAuth 2.0 token endpoint of the account.
+func TokenURL(accountID string) string {
+	return RESTBaseURL(accountID) + "/auth/oauth2/v1/token"
+}
+
+// loadPrivateKey returns the private key of the credentials.
+func loadPrivateKey(creds Credentials) (crypto.Signer, error) {
+	if len(creds.PrivateKey) > 0 {
+		return ParsePrivateKey(creds.PrivateKey)
+	}
+	data, err := os.ReadFile(creds.PrivateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading private key: %v", err)
+	}
+	key, err := ParsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", creds.PrivateKeyPath, err)
+	}
+	return key, nil
+}
+
+// ParsePrivateKey parses a PEM encoded RSA or EC private key.
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("no private key found")
+		}
+
+		switch block.Type {
+		case "PRIVATE KEY":
+			key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing private key: %v", err)
+			}
+			signer, ok := key.(crypto.Signer)
+			if !ok {
+				return nil, fmt.Errorf("unsupported private key type %T", key)
+			}
+			return signer, nil
+		case "RSA PRIVATE KEY":
+			key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing private key: %v", err)
+			}
+			return key, nil
+		case "EC PRIVATE KEY":
+			key, err := x509.ParseECPrivateKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing private key: %v", err)
+			}
+			return key, nil
+		}
+	}
+}
+
+// signAssertion builds the JWT client assertion for the credentials, signed with PS256 for RSA
+// keys or ES256 for EC keys.
+func signAssertion(creds Credentials, key crypto.Signer, now time.Time) (string, error) {
+	var alg string
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		alg = "PS256"
+	case *ecdsa.PrivateKey:
+		alg = "ES256"
+	default:
+		return "", fmt.Errorf("unsupported private key type %T", key)
+	}
+
+	scopes := creds.Scopes
+	if len(scopes) == 0 {
+		scopes = DefaultScopes
+	}
+
+	header := map[string]string{
+		"alg": alg,
+		"typ": "JWT",
+		"kid": creds.CertificateID,
+	}
+	claims := map[string]any{
+		"iss":   creds.ClientID,
+		"scope": strings.Join(scopes, ","),
+		"aud":   TokenURL(creds.AccountID),
+		"iat":   now.Unix(),
+		"exp":   now.Add(assertionLifetime).Unix(),
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPSS(rand.Reader, k, crypto.SHA256, digest[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	case *ecdsa.PrivateKey:
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k, digest[:])
+		if err == nil {
+			size := (k.Curve.Params().BitSize + 7) / 8
+			signature = make([]byte, 2*size)
+			r.FillBytes(signature[:size])
+			s.FillBytes(signature[size:])
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("error signing client assertion: %v", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,199 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testCredentials = Credentials{
+	AccountID:     "1234567_SB1",
+	ClientID:      "client-id",
+	CertificateID: "certificate-id",
+}
+
+// decodeAssertion splits a signed assertion into its header and claims, the SHA-256 digest of its
+// signing input and its signature.
+func decodeAssertion(t *testing.T, assertion string) (map[string]any, map[string]any, []byte, []byte) {
+	t.Helper()
+	parts := strings.Split(assertion, ".")
+	if len(parts) != 3 {
+		t.Fatalf("assertion has %d parts, want 3", len(parts))
+	}
+	var header, claims map[string]any
+	for i, v := range []*map[string]any{&header, &claims} {
+		data, err := base64.RawURLEncoding.DecodeString(parts[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(data, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	return header, claims, digest[:], signature
+}
+
+// checkClaims checks the header and claims of an assertion signed with alg at now for
+// testCredentials.
+func checkClaims(t *testing.T, header, claims map[string]any, alg string, now time.Time) {
+	t.Helper()
+	if header["alg"] != alg || header["typ"] != "JWT" || header["kid"] != "certificate-id" {
+		t.Errorf("header = %v, want alg %s, typ JWT and kid certificate-id", header, alg)
+	}
+	want := map[string]any{
+		"iss":   "client-id",
+		"scope": "restlets,rest_webservices",
+		"aud":   "https://1234567-sb1.suitetalk.api.netsuite.com/services/rest/auth/oauth2/v1/token",
+		"iat":   float64(now.Unix()),
+		"exp":   float64(now.Add(assertionLifetime).Unix()),
+	}
+	for key, value := range want {
+		if claims[key] != value {
+			t.Errorf("claim %s = %v, want %v", key, claims[key], value)
+		}
+	}
+}
+
+func TestSignAssertionRSA(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	assertion, err := signAssertion(testCredentials, key, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header, claims, digest, signature := decodeAssertion(t, assertion)
+	checkClaims(t, header, claims, "PS256", now)
+	if err := rsa.VerifyPSS(&key.PublicKey, crypto.SHA256, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}); err != nil {
+		t.Errorf("PS256 signature does not verify: %v", err)
+	}
+}
+
+func TestSignAssertionECDSA(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	creds := testCredentials
+	creds.Scopes = []string{"rest_webservices"}
+	assertion, err := signAssertion(creds, key, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header, claims, digest, signature := decodeAssertion(t, assertion)
+	if header["alg"] != "ES256" {
+		t.Errorf("alg = %v, want ES256", header["alg"])
+	}
+	if claims["scope"] != "rest_webservices" {
+		t.Errorf("scope = %v, want the scopes of the credentials", claims["scope"])
+	}
+	// ES256 signatures are r and s as fixed size big-endian integers, not ASN.1.
+	if len(signature) != 64 {
+		t.Fatalf("signature is %d bytes, want 64", len(signature))
+	}
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	if !ecdsa.Verify(&key.PublicKey, digest, r, s) {
+		t.Error("ES256 signature does not verify")
+	}
+}
+
+func TestSignAssertionUnsupportedKey(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signAssertion(testCredentials, key, time.Now()); err == nil {
+		t.Error("an Ed25519 key did not fail")
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("not a key")})
+
+	tests := map[string]struct {
+		data []byte
+		want any
+	}{
+		"PKCS #1":           {pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}), &rsa.PrivateKey{}},
+		"PKCS #8":           {pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), &rsa.PrivateKey{}},
+		"EC":                {pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}), &ecdsa.PrivateKey{}},
+		"after certificate": {append(certificate, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER})...), &ecdsa.PrivateKey{}},
+		"certificate only":  {certificate, nil},
+		"not PEM":           {[]byte("private key"), nil},
+		"corrupt PKCS #1":   {pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: []byte("x")}), nil},
+	}
+	for name, test := range tests {
+		key, err := ParsePrivateKey(test.data)
+		switch {
+		case test.want == nil && err == nil:
+			t.Errorf("%s: parsed %T, want an error", name, key)
+		case test.want != nil && err != nil:
+			t.Errorf("%s: %v", name, err)
+		case test.want != nil && reflect.TypeOf(key) != reflect.TypeOf(test.want):
+			t.Errorf("%s: parsed %T, want %T", name, key, test.want)
+		}
+	}
+}
+
+func TestParseExpiresIn(t *testing.T) {
+	tests := []struct {
+		value any
+		want  time.Duration
+	}{
+		{"3600", time.Hour},
+		{"1800", 30 * time.Minute},
+		{float64(900), 15 * time.Minute},
+		{"90.5", 90 * time.Second},
+		{"0", time.Hour},
+		{"-5", time.Hour},
+		{"soon", time.Hour},
+		{"", time.Hour},
+		{nil, time.Hour},
+		{true, time.Hour},
+	}
+	for _, test := range tests {
+		if got := parseExpiresIn(test.value); got != test.want {
+			t.Errorf("parseExpiresIn(%#v) = %v, want %v", test.value, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"netsuite-cli/internal/credentials"
+)
+
+// expiryMargin is subtracted from the token lifetime so a token is never used right as it expires.
+const expiryMargin = time.Minute
+
+// Token is an OAuth 2.0 access token issued by NetSuite.
+type Token struct {
+	AccessToken string    `json:"accessToken"`
+	TokenType   string    `json:"tokenType"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+// Valid reports whether the token is set and not about to expire.
+func (t *Token) Valid() bool {
+	return t != nil && t.AccessToken != "" && time.Now().Add(expiryMargin).Before(t.ExpiresAt)
+}
+
+// Client issues access tokens for a set of credentials and caches them in a credential store.
+type Client struct {
+	Credentials Credentials
+	// Store is the credential store tokens are cached in. Caching is disabled when nil.
+	Store credentials.Store
+	// HTTPClient is used for token requests, http.DefaultClient when nil.
+	HTTPClient *http.Client
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// NewClient returns a client for the given credentials caching tokens in store.
+func NewClient(creds Credentials, store credentials.Store) *Client {
+	return &Client{Credentials: creds, Store: store}
+}
+
+// Token returns a valid access token, requesting a new one when the cached token has expired.
+func (c *Client) Token(ctx context.Context) (*Token, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token.Valid() {
+		return c.token, nil
+	}
+	if cached := c.Cached(); cached.Valid() {
+		c.token = cached
+		return c.token, nil
+	}
+
+	token, err := c.requestToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.token = token
+	c.writeCache(token)
+	return token, nil
+}
+
+// Invalidate discards the cached token so the next call to Token requests a new one. Callers
+// use it when a request is rejected with 401 Unauthorized.
+func (c *Client) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.token = nil
+	if c.Store != nil {
+		c.Store.Delete(c.cacheKey())
+	}
+}
+
+// Do sends the request with a bearer token, retrying once with a new token when it is rejected
+// as unauthorized. The request body must be replayable through GetBody to be retried.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		token, err := c.Token(req.Context())
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+		resp, err := c.httpClient().Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 0 || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+
+		resp.Body.Close()
+		c.Invalidate()
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// requestToken exchanges a signed client assertion for an access token.
+func (c *Client) requestToken(ctx context.Context) (*Token, error) {
+	if err := c.Credentials.Validate(); err != nil {
+		return nil, err
+	}
+
+	key, err := loadPrivateKey(c.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	assertion, err := signAssertion(c.Credentials, key, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":            {"client_credentials"},
+		"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+		"client_assertion":      {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, TokenURL(c.Credentials.AccountID), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting access token: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading token response: %v", err)
+	}
+
+	var payload struct {
+		AccessToken      string `json:"access_token"`
+		TokenType        string `json:"token_type"`
+		ExpiresIn        any    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("error parsing token response (%s): %v", resp.Status, err)
+	}
+	if resp.StatusCode != http.StatusOK || payload.AccessToken == "" {
+		message := payload.Error
+		if payload.ErrorDescription != "" {
+			message += ": " + payload.ErrorDescription
+		}
+		if message == "" {
+			message = resp.Status
+		}
+		return nil, fmt.Errorf("token request rejected: %s", message)
+	}
+
+	return &Token{
+		AccessToken: payload.AccessToken,
+		TokenType:   payload.TokenType,
+		ExpiresAt:   time.Now().Add(parseExpiresIn(payload.ExpiresIn)),
+	}, nil
+}
+
+// parseExpiresIn converts the expires_in value, sent by NetSuite as a string, into a duration.
+func parseExpiresIn(value any) time.Duration {
+	seconds := 0.0
+	switch v := value.(type) {
+	case float64:
+		seconds = v
+	case string:
+		fmt.Sscanf(v, "%g", &seconds)
+	}
+	if seconds <= 0 {
+		seconds = 3600
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// httpClient returns the HTTP client used for requests.
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// TokenKey returns the key the token of a client of the account is cached under in the
+// credential store.
+func TokenKey(accountID, clientID string) string {
+	return "token:" + AccountHost(accountID) + "-" + clientID
+}
+
+// cacheKey returns the key the token of the credentials is cached under.
+func (c *Client) cacheKey() string {
+	return TokenKey(c.Credentials.AccountID, c.Credentials.ClientID)
+}
+
+// Cached returns the token cached in the credential store, valid or not, or nil if none is cached.
+func (c *Client) Cached() *Token {
+	if c.Store == nil {
+		return nil
+	}
+	data, err := c.Store.Get(c.cacheKey())
+	if err != nil {
+		return nil
+	}
+	var token Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil
+	}
+	return &token
+}
+
+// writeCache stores the token so it can be reused by later invocations. Tokens are not cached
+// when the credential store cannot be written.
+func (c *Client) writeCache(token *Token) {
+	if c.Store == nil {
+		return
+	}
+	data, err := json.Marshal(token)
+	if err != nil {
+		return
+	}
+	c.Store.Set(c.cacheKey(), data)
+}
@@ -0,0 +1,77 @@
+// Package credentials keeps secrets such as access tokens and private keys in the credential
+// store of the operating system: the Keychain on macOS, the Secret Service (libsecret) on Linux
+// and files encrypted with DPAPI on Windows.
+package credentials
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// Service is the service name the secrets are stored under.
+const Service = "netsuite-cli"
+
+// ErrNotFound is returned by Get when no secret is stored under the key.
+var ErrNotFound = errors.New("credential not found")
+
+// ErrUnavailable is returned when the operating system offers no credential store.
+var ErrUnavailable = errors.New("no credential store available")
+
+// Store keeps secrets by key.
+type Store interface {
+	// Name describes the store, e.g. "macOS Keychain".
+	Name() string
+	// Get returns the secret stored under key, or ErrNotFound.
+	Get(key string) ([]byte, error)
+	// Set stores secret under key, replacing any previous value.
+	Set(key string, secret []byte) error
+	// Delete removes the secret stored under key. Deleting a missing key is not an error.
+	Delete(key string) error
+}
+
+// Open returns the credential store of the operating system. dir is the directory file based
+// stores keep their encrypted files in. When the operating system offers no store, every
+// operation of the returned store fails with ErrUnavailable.
+func Open(dir string) Store {
+	return open(dir)
+}
+
+// unavailable is the store used when the operating system offers none.
+type unavailable struct {
+	reason string
+}
+
+func (s unavailable) Name() string {
+	return "none (" + s.reason + ")"
+}
+
+func (s unavailable) Get(key string) ([]byte, error) {
+	return nil, s.err()
+}
+
+func (s unavailable) Set(key string, secret []byte) error {
+	return s.err()
+}
+
+func (s unavailable) Delete(key string) error {
+	return s.err()
+}
+
+func (s unavailable) err() error {
+	return fmt.Errorf("%w: %s", ErrUnavailable, s.reason)
+}
+
+// encode returns a secret as text, since the command line stores only keep strings reliably.
+func encode(secret []byte) string {
+	return base64.StdEncoding.EncodeToString(secret)
+}
+
+// decode returns the secret encoded by encode.
+func decode(text string) ([]byte, error) {
+	secret, err := base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding credential: %v", err)
+	}
+	return secret, nil
+}
@@ -0,0 +1,111 @@
+package credentials
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	crypt32                = syscall.NewLazyDLL("crypt32.dll")
+	kernel32               = syscall.NewLazyDLL("kernel32.dll")
+	procCryptProtectData   = crypt32.NewProc("CryptProtectData")
+	procCryptUnprotectData = crypt32.NewProc("CryptUnprotectData")
+	procLocalFree          = kernel32.NewProc("LocalFree")
+)
+
+// cryptProtectUIForbidden makes DPAPI fail rather than prompt the user.
+const cryptProtectUIForbidden = 0x1
+
+// dataBlob is the DATA_BLOB structure DPAPI reads and writes data through.
+type dataBlob struct {
+	size uint32
+	data *byte
+}
+
+func newDataBlob(data []byte) *dataBlob {
+	if len(data) == 0 {
+		return &dataBlob{}
+	}
+	return &dataBlob{size: uint32(len(data)), data: &data[0]}
+}
+
+// bytes copies the data of a blob allocated by DPAPI and frees it.
+func (b *dataBlob) bytes() []byte {
+	defer procLocalFree.Call(uintptr(unsafe.Pointer(b.data)))
+	data := make([]byte, b.size)
+	copy(data, unsafe.Slice(b.data, b.size))
+	return data
+}
+
+// dpapi stores each secret in a file encrypted with the Data Protection API, which only the
+// current Windows user can decrypt.
+type dpapi struct {
+	dir string
+}
+
+func open(dir string) Store {
+	if dir == "" {
+		return unavailable{reason: "no user configuration directory"}
+	}
+	if err := procCryptProtectData.Find(); err != nil {
+		return unavailable{reason: "DPAPI is not available"}
+	}
+	return dpapi{dir: filepath.Join(dir, "credentials")}
+}
+
+func (dpapi) Name() string {
+	return "Windows DPAPI"
+}
+
+// path returns the file the secret of key is stored in.
+func (s dpapi) path(key string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '.' || r == '_' {
+			return r
+		}
+		return '_'
+	}, key)
+	return filepath.Join(s.dir, name+".bin")
+}
+
+func (s dpapi) Get(key string) ([]byte, error) {
+	encrypted, err := os.ReadFile(s.path(key))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", key, err)
+	}
+	var out dataBlob
+	r, _, err := procCryptUnprotectData.Call(uintptr(unsafe.Pointer(newDataBlob(encrypted))), 0, 0, 0, 0, cryptProtectUIForbidden, uintptr(unsafe.Pointer(&out)))
+	if r == 0 {
+		return nil, fmt.Errorf("error decrypting %s: %v", key, err)
+	}
+	return out.bytes(), nil
+}
+
+func (s dpapi) Set(key string, secret []byte) error {
+	var out dataBlob
+	r, _, err := procCryptProtectData.Call(uintptr(unsafe.Pointer(newDataBlob(secret))), 0, 0, 0, 0, cryptProtectUIForbidden, uintptr(unsafe.Pointer(&out)))
+	if r == 0 {
+		return fmt.Errorf("error encrypting %s: %v", key, err)
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("error creating directory %s: %v", s.dir, err)
+	}
+	if err := os.WriteFile(s.path(key), out.bytes(), 0600); err != nil {
+		return fmt.Errorf("error writing %s: %v", key, err)
+	}
+	return nil
+}
+
+func (s dpapi) Delete(key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error deleting %s: %v", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package credentials
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// errItemNotFound is the exit status of the security tool when no keychain item matches.
+const errItemNotFound = 44
+
+// keychain stores secrets as generic passwords of the login keychain, through the security tool.
+type keychain struct{}
+
+func open(dir string) Store {
+	if _, err := exec.LookPath("security"); err != nil {
+		return unavailable{reason: "the security tool was not found"}
+	}
+	return keychain{}
+}
+
+func (keychain) Name() string {
+	return "macOS Keychain"
+}
+
+func (keychain) Get(key string) ([]byte, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", Service, "-a", key, "-w").Output()
+	if exitCode(err) == errItemNotFound {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s from the keychain: %v", key, err)
+	}
+	return decode(strings.TrimSpace(string(out)))
+}
+
+func (keychain) Set(key string, secret []byte) error {
+	// The secret goes through the interactive mode on stdin so it never shows in the process list.
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n", Service, key, encode(secret)))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil || stderr.Len() > 0 {
+		return fmt.Errorf("error writing %s to the keychain: %s", key, errorMessage(err, stderr.String()))
+	}
+	return nil
+}
+
+func (keychain) Delete(key string) error {
+	err := exec.Command("security", "delete-generic-password", "-s", Service, "-a", key).Run()
+	if err != nil && exitCode(err) != errItemNotFound {
+		return fmt.Errorf("error deleting %s from the keychain: %v", key, err)
+	}
+	return nil
+}
+
+// exitCode returns the exit status of a failed command, or -1.
+func exitCode(err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// errorMessage returns the output of a failed command, or its error when it printed nothing.
+func errorMessage(err error, stderr string) string {
+	if message := strings.TrimSpace(stderr); message != "" {
+		return message
+	}
+	return err.Error()
+}
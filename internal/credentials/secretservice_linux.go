@@ -0,0 +1,67 @@
+package credentials
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// secretService stores secrets in the Secret Service (GNOME Keyring, KWallet) through the
+// secret-tool command of libsecret.
+type secretService struct{}
+
+func open(dir string) Store {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return unavailable{reason: "secret-tool was not found, install libsecret-tools"}
+	}
+	return secretService{}
+}
+
+func (secretService) Name() string {
+	return "Secret Service (libsecret)"
+}
+
+func (secretService) Get(key string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("secret-tool", "lookup", "service", Service, "account", key)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		// lookup exits with 1 and prints nothing when no item matches.
+		if len(out) == 0 && stderr.Len() == 0 {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("error reading %s from the Secret Service: %s", key, errorMessage(err, stderr.String()))
+	}
+	return decode(strings.TrimSpace(string(out)))
+}
+
+func (secretService) Set(key string, secret []byte) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("secret-tool", "store", "--label", Service+" "+key, "service", Service, "account", key)
+	cmd.Stdin = strings.NewReader(encode(secret))
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("error writing %s to the Secret Service: %s", key, errorMessage(err, stderr.String()))
+	}
+	return nil
+}
+
+func (secretService) Delete(key string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("secret-tool", "clear", "service", Service, "account", key)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil && stderr.Len() > 0 {
+		return fmt.Errorf("error deleting %s from the Secret Service: %s", key, errorMessage(err, stderr.String()))
+	}
+	return nil
+}
+
+// errorMessage returns the output of a failed command, or its error when it printed nothing.
+func errorMessage(err error, stderr string) string {
+	if message := strings.TrimSpace(stderr); message != "" {
+		return message
+	}
+	return err.Error()
+}
@@ -0,0 +1,7 @@
+//go:build !darwin && !linux && !windows
+
+package credentials
+
+func open(dir string) Store {
+	return unavailable{reason: "not supported on this operating system"}
+}
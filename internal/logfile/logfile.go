@@ -0,0 +1,123 @@
+// Package logfile writes the debug log of each invocation to a file that is rotated once it
+// grows past a size limit, keeping a fixed number of older files.
+package logfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Name is the file name of the current log.
+	Name = "netsuite-cli.log"
+	// MaxSize is the size in bytes past which the current log is rotated.
+	MaxSize = 1 << 20
+	// Keep is the number of rotated logs kept next to the current one.
+	Keep = 5
+)
+
+// Log appends timestamped lines to the current log file.
+type Log struct {
+	file *os.File
+}
+
+// Open opens the current log in dir for appending, creating dir when needed. The current log
+// is rotated first when it is larger than MaxSize.
+func Open(dir string) (*Log, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("error creating log directory: %v", err)
+	}
+	if err := rotate(dir); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(filepath.Join(dir, Name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening log file: %v", err)
+	}
+	return &Log{file: file}, nil
+}
+
+// Printf appends a line prefixed with the current time and the process ID, which tells apart the
+// lines of invocations running at the same time. Write errors are ignored, the log must
+// never make a command fail.
+func (l *Log) Printf(format string, args ...any) {
+	if l == nil {
+		return
+	}
+	line := fmt.Sprintf(format, args...)
+	line = strings.TrimRight(line, "\n")
+	fmt.Fprintf(l.file, "%s [%d] %s\n", time.Now().Format("2006-01-02T15:04:05.000Z07:00"), os.Getpid(), line)
+}
+
+// Close closes the log file.
+func (l *Log) Close() error {
+	if l == nil {
+		return nil
+	}
+	return l.file.Close()
+}
+
+// rotate renames the current log to netsuite-cli.1.log, shifting the older ones up and removing
+// the ones past Keep, when it is larger than MaxSize.
+func rotate(dir string) error {
+	current := filepath.Join(dir, Name)
+	info, err := os.Stat(current)
+	if err != nil || info.Size() < MaxSize {
+		return nil
+	}
+	os.Remove(rotated(dir, Keep))
+	for i := Keep - 1; i >= 1; i-- {
+		os.Rename(rotated(dir, i), rotated(dir, i+1))
+	}
+	if err := os.Rename(current, rotated(dir, 1)); err != nil {
+		return fmt.Errorf("error rotating log file: %v", err)
+	}
+	return nil
+}
+
+// rotated returns the path of the n-th rotated log.
+func rotated(dir string, n int) string {
+	return filepath.Join(dir, fmt.Sprintf("netsuite-cli.%d.log", n))
+}
+
+// Files returns the log files in dir, the current one first and the rotated ones from the most
+// to the least recent.
+func Files(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading log directory: %v", err)
+	}
+	var files []string
+	numbers := map[string]int{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if name == Name {
+			files = append(files, filepath.Join(dir, name))
+			continue
+		}
+		n, ok := strings.CutPrefix(name, "netsuite-cli.")
+		if !ok {
+			continue
+		}
+		n, ok = strings.CutSuffix(n, ".log")
+		number, err := strconv.Atoi(n)
+		if !ok || err != nil {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		numbers[path] = number
+		files = append(files, path)
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return numbers[files[i]] < numbers[files[j]]
+	})
+	return files, nil
+}
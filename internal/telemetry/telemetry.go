@@ -0,0 +1,225 @@
+// Package telemetry queues anonymous usage events on disk and sends them to the telemetry
+// endpoint in batches. Events only hold the command run, how long it took and whether it
+// succeeded: never arguments, paths, names or any other project data.
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Endpoint is the URL the events are posted to. It is set at build time with
+// -ldflags "-X netsuite-cli/internal/telemetry.Endpoint=..."; without one the events are only
+// queued.
+var Endpoint = ""
+
+const (
+	// QueueName is the file name of the queue of events not sent yet, one JSON event per line.
+	QueueName = "queue.jsonl"
+	// IDName is the file name of the random installation ID.
+	IDName = "id"
+	// MaxQueued is the number of events kept in the queue; older ones are dropped.
+	MaxQueued = 1000
+	// FlushSize is the number of queued events that triggers a flush.
+	FlushSize = 20
+	// FlushInterval is the age of the oldest queued event that triggers a flush.
+	FlushInterval = 24 * time.Hour
+)
+
+// Event records a command run.
+type Event struct {
+	Time       time.Time `json:"time"`
+	InstallID  string    `json:"installId"`
+	Command    string    `json:"command"`
+	DurationMs int64     `json:"durationMs"`
+	Success    bool      `json:"success"`
+	ExitCode   int       `json:"exitCode"`
+	Version    string    `json:"version"`
+	OS         string    `json:"os"`
+	Arch       string    `json:"arch"`
+}
+
+// InstallID returns the random ID of the installation kept in dir, creating it when needed. It
+// tells the events of one installation apart without identifying the user.
+func InstallID(dir string) (string, error) {
+	path := filepath.Join(dir, IDName)
+	if data, err := os.ReadFile(path); err == nil && len(bytes.TrimSpace(data)) > 0 {
+		return string(bytes.TrimSpace(data)), nil
+	}
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(buf)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(id+"\n"), 0o600); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Append adds an event to the queue in dir. When the queue holds more than MaxQueued events the
+// oldest ones are dropped.
+func Append(dir string, event Event) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, QueueName)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(line, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	lines, err := readLines(path)
+	if err != nil || len(lines) <= MaxQueued {
+		return err
+	}
+	return writeLines(path, lines[len(lines)-MaxQueued:])
+}
+
+// Pending returns the events queued in dir.
+func Pending(dir string) ([]Event, error) {
+	lines, err := readLines(filepath.Join(dir, QueueName))
+	if err != nil {
+		return nil, err
+	}
+	events := make([]Event, 0, len(lines))
+	for _, line := range lines {
+		var event Event
+		if json.Unmarshal([]byte(line), &event) == nil {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// Due reports whether the queue in dir should be flushed: it holds FlushSize events or its
+// oldest event is older than FlushInterval.
+func Due(dir string) bool {
+	events, err := Pending(dir)
+	if err != nil || len(events) == 0 {
+		return false
+	}
+	return len(events) >= FlushSize || time.Since(events[0].Time) > FlushInterval
+}
+
+// Flush posts the events queued in dir to endpoint as a JSON array and returns how many were
+// sent. The queue is moved aside first, so events recorded meanwhile are kept for the next
+// flush and two flushes never send the same events; the events are queued again when the
+// request fails.
+func Flush(ctx context.Context, dir, endpoint string) (int, error) {
+	if endpoint == "" {
+		return 0, fmt.Errorf("no telemetry endpoint configured")
+	}
+	sending := filepath.Join(dir, "sending-"+strconv.Itoa(os.Getpid())+".jsonl")
+	if err := os.Rename(filepath.Join(dir, QueueName), sending); err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer os.Remove(sending)
+
+	lines, err := readLines(sending)
+	if err != nil || len(lines) == 0 {
+		return 0, err
+	}
+	body := "[" + strings.Join(lines, ",") + "]"
+	if err := post(ctx, endpoint, body); err != nil {
+		requeue(dir, lines)
+		return 0, err
+	}
+	return len(lines), nil
+}
+
+// post sends a batch of events.
+func post(ctx context.Context, endpoint, body string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending telemetry: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("error sending telemetry: %s", resp.Status)
+	}
+	return nil
+}
+
+// requeue puts events that could not be sent back in front of the queue.
+func requeue(dir string, lines []string) {
+	path := filepath.Join(dir, QueueName)
+	queued, _ := readLines(path)
+	lines = append(lines, queued...)
+	if len(lines) > MaxQueued {
+		lines = lines[len(lines)-MaxQueued:]
+	}
+	writeLines(path, lines)
+}
+
+// Clear removes the queue and the installation ID kept in dir.
+func Clear(dir string) error {
+	return os.RemoveAll(dir)
+}
+
+// readLines returns the non-empty lines of a file, none when it does not exist.
+func readLines(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// writeLines replaces the content of a file with lines, writing a temporary file that is renamed
+// over it.
+func writeLines(path string, lines []string) error {
+	tmp := path + ".tmp-" + strconv.Itoa(os.Getpid())
+	content := strings.Join(lines, "\n")
+	if content != "" {
+		content += "\n"
+	}
+	if err := os.WriteFile(tmp, []byte(content), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
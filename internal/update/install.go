@@ -0,0 +1,182 @@
+package update
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ChecksumsName is the name of the release asset listing the SHA-256 checksums of the archives.
+const ChecksumsName = "checksums.txt"
+
+// ArchiveName returns the name of the release archive for an OS and architecture, following
+// the name template of .goreleaser.yaml, e.g. netsuite-cli_Linux_x86_64.tar.gz.
+func ArchiveName(goos, goarch string) string {
+	arch := goarch
+	switch goarch {
+	case "amd64":
+		arch = "x86_64"
+	case "386":
+		arch = "i386"
+	}
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	return "netsuite-cli_" + strings.ToUpper(goos[:1]) + goos[1:] + "_" + arch + ext
+}
+
+// BinaryName returns the name of the executable inside the release archive for an OS.
+func BinaryName(goos string) string {
+	if goos == "windows" {
+		return "netsuite-cli.exe"
+	}
+	return "netsuite-cli"
+}
+
+// Asset returns the asset of the release with the given name.
+func (r *Release) Asset(name string) (*Asset, bool) {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i], true
+		}
+	}
+	return nil, false
+}
+
+// Download returns the content of a release asset.
+func Download(ctx context.Context, asset *Asset) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, asset.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading %s: %v", asset.Name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error downloading %s: %s", asset.Name, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading %s: %v", asset.Name, err)
+	}
+	return data, nil
+}
+
+// VerifyChecksum checks data against the SHA-256 checksum of the file name listed in checksums,
+// the content of the checksums.txt asset with one "<hex digest>  <name>" line per file.
+func VerifyChecksum(checksums []byte, name string, data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+			return fmt.Errorf("checksum mismatch for %s: expected %s, got %x", name, fields[0], sum)
+		}
+		return nil
+	}
+	return fmt.Errorf("no checksum for %s in %s", name, ChecksumsName)
+}
+
+// ExtractBinary returns the executable named binary from a .tar.gz or .zip release archive.
+func ExtractBinary(archiveName string, data []byte, binary string) ([]byte, error) {
+	if strings.HasSuffix(archiveName, ".zip") {
+		reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %v", archiveName, err)
+		}
+		for _, file := range reader.File {
+			if file.FileInfo().IsDir() || path.Base(file.Name) != binary {
+				continue
+			}
+			rc, err := file.Open()
+			if err != nil {
+				return nil, fmt.Errorf("error reading %s: %v", archiveName, err)
+			}
+			defer rc.Close()
+			return io.ReadAll(rc)
+		}
+		return nil, fmt.Errorf("%s does not contain %s", archiveName, binary)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", archiveName, err)
+	}
+	defer gz.Close()
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s does not contain %s", archiveName, binary)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %v", archiveName, err)
+		}
+		if header.Typeflag == tar.TypeReg && path.Base(header.Name) == binary {
+			return io.ReadAll(reader)
+		}
+	}
+}
+
+// ReplaceExecutable replaces the executable at exe with binary. The new binary is written next to
+// it and renamed over it, so exe is either the old or the new binary, never a partial one. Windows
+// does not allow replacing a running executable, so there the old one is first moved aside to
+// exe.old, which is removed by the next update.
+func ReplaceExecutable(exe string, binary []byte) error {
+	info, err := os.Stat(exe)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(exe), "."+filepath.Base(exe)+".new-*")
+	if err != nil {
+		return fmt.Errorf("error writing the new binary: %v", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing the new binary: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing the new binary: %v", err)
+	}
+	if err := os.Chmod(tmpPath, info.Mode().Perm()|0o111); err != nil {
+		return fmt.Errorf("error writing the new binary: %v", err)
+	}
+
+	if runtime.GOOS == "windows" {
+		old := exe + ".old"
+		os.Remove(old)
+		if err := os.Rename(exe, old); err != nil {
+			return fmt.Errorf("error moving the old binary aside: %v", err)
+		}
+		if err := os.Rename(tmpPath, exe); err != nil {
+			os.Rename(old, exe)
+			return fmt.Errorf("error replacing the binary: %v", err)
+		}
+		return nil
+	}
+	if err := os.Rename(tmpPath, exe); err != nil {
+		return fmt.Errorf("error replacing the binary: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Package update looks up the releases of the CLI published on GitHub.
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Repository is the GitHub repository the CLI is released from.
+const Repository = "felipechang/netsuite-cli"
+
+// APIURL is the base URL of the GitHub REST API.
+var APIURL = "https://api.github.com"
+
+// Release is a published release of the CLI.
+type Release struct {
+	Tag    string  `json:"tag_name"`
+	URL    string  `json:"html_url"`
+	Assets []Asset `json:"assets"`
+}
+
+// Version returns the version of the release, the tag without its v prefix.
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.Tag, "v")
+}
+
+// Asset is a file attached to a release, such as the archive of a platform.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Latest returns the latest release, ignoring drafts and prereleases.
+func Latest(ctx context.Context) (*Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, APIURL+"/repos/"+Repository+"/releases/latest", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error checking for a new release: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the release: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error checking for a new release: %s", resp.Status)
+	}
+	var release Release
+	if err := json.Unmarshal(body, &release); err != nil {
+		return nil, fmt.Errorf("error parsing the release: %v", err)
+	}
+	if release.Tag == "" {
+		return nil, fmt.Errorf("error parsing the release: no tag name")
+	}
+	return &release, nil
+}
+
+// Newer reports whether version latest is newer than current. Versions are compared as
+// major.minor.patch with an optional -prerelease, which sorts before the release. A current
+// version that is not a release, such as "dev", is never out of date.
+func Newer(current, latest string) bool {
+	c, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+	l, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	for i := range 3 {
+		if l.numbers[i] != c.numbers[i] {
+			return l.numbers[i] > c.numbers[i]
+		}
+	}
+	switch {
+	case c.prerelease == "":
+		return false
+	case l.prerelease == "":
+		return true
+	}
+	return l.prerelease > c.prerelease
+}
+
+// version is a parsed major.minor.patch[-prerelease] version.
+type version struct {
+	numbers    [3]int
+	prerelease string
+}
+
+// parseVersion parses a version, with or without a v prefix.
+func parseVersion(text string) (version, bool) {
+	var v version
+	text = strings.TrimPrefix(strings.TrimSpace(text), "v")
+	text, v.prerelease, _ = strings.Cut(text, "-")
+	parts := strings.Split(text, ".")
+	if len(parts) != 3 {
+		return v, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v.numbers[i] = n
+	}
+	return v, true
+}
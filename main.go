@@ -1,8 +1,21 @@
 package main
 
-import "netsuite-cli/cmd"
+import (
+	// The time zone database is embedded for the timeZone setting on systems without one.
+	_ "time/tzdata"
+
+	"netsuite-cli/cmd"
+)
+
+// Build information, set by GoReleaser through -ldflags "-X main.version=...".
+var (
+	version = ""
+	commit  = ""
+	date    = ""
+)
 
 // main is the entry point of the application.
 func main() {
+	cmd.SetBuildInfo(version, commit, date)
 	cmd.Execute()
 }
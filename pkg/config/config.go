@@ -0,0 +1,339 @@
+// Package config reads and writes the netsuite-cli project, team and user configuration files
+// and applies the project naming conventions. It does not prompt or print, so it can be used by
+// other Go tools working with netsuite-cli projects.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// FileName is the name of the personal project configuration file, and of the user
+	// configuration file in the home directory.
+	FileName = ".netsuite-cli"
+	// TeamFileName is the committed file holding the project settings shared by the team. Values
+	// in the personal configuration take precedence over it.
+	TeamFileName = ".netsuite-cli.team"
+)
+
+// ErrNoProject is returned by FindRoot when no directory holds a project configuration.
+var ErrNoProject = errors.New(".netsuite-cli file not found, please run 'create' first")
+
+// Project represents the configuration for a specific project.
+type Project struct {
+	// Version is the schema version of the file, see SchemaVersion and Migrate.
+	Version int `json:"version,omitempty"`
+
+	ProjectName string `json:"projectName"`
+	CompanyName string `json:"companyName"`
+	UserName    string `json:"userName"`
+	UserEmail   string `json:"userEmail"`
+
+	Environments       map[string]string `json:"environments,omitempty"`
+	DefaultEnvironment string            `json:"defaultEnvironment,omitempty"`
+
+	// Conventions usually shared by the team through the .netsuite-cli.team file.
+	CompanyPrefix  string `json:"companyPrefix,omitempty"`
+	ScriptIdPrefix string `json:"scriptIdPrefix,omitempty"`
+	DefaultFolder  string `json:"defaultFolder,omitempty"`
+	ApiVersion     string `json:"apiVersion,omitempty"`
+	GitHooks       string `json:"gitHooks,omitempty"`
+	Language       string `json:"language,omitempty"`
+
+	// Status and log level of the script deployments generated by add, see DeployStatuses and
+	// LogLevels. Unset, each script type keeps the values of its template.
+	DeployStatus string `json:"deployStatus,omitempty"`
+	LogLevel     string `json:"logLevel,omitempty"`
+
+	// Formatting of the dates written into generated files, see Dates.
+	DateFormat string `json:"dateFormat,omitempty"`
+	TimeZone   string `json:"timeZone,omitempty"`
+	Locale     string `json:"locale,omitempty"`
+
+	// License header added to generated TypeScript and JavaScript files, see LicenseHeaderStyles.
+	License         string `json:"license,omitempty"`
+	CopyrightHolder string `json:"copyrightHolder,omitempty"`
+	CopyrightYear   string `json:"copyrightYear,omitempty"`
+	LicenseHeader   string `json:"licenseHeader,omitempty"`
+
+	// Template pack the project generates scripts from, as source@version, e.g.
+	// github.com/acme/ns-templates@v1.2.0. Installed with 'template install'.
+	TemplatePack string `json:"templatePack,omitempty"`
+
+	// Naming patterns, text/template strings rendered with NamingData.
+	ScriptIdPattern       string `json:"scriptIdPattern,omitempty"`
+	DeploymentIdPattern   string `json:"deploymentIdPattern,omitempty"`
+	FileNamePattern       string `json:"fileNamePattern,omitempty"`
+	ObjectFileNamePattern string `json:"objectFileNamePattern,omitempty"`
+
+	// Shell commands run at points of the command lifecycle, keyed by hook name (see HookNames).
+	Hooks map[string]string `json:"hooks,omitempty"`
+
+	// Module aliases mapped to folders under SuiteScripts, e.g. "@lib" to "lib", written to the
+	// tsconfig paths and to the amdconfig.json of the project.
+	Aliases map[string]string `json:"aliases,omitempty"`
+
+	// Variables exposed to the script templates as .Vars, e.g. "Department" to "IT". An empty
+	// value declares a variable add prompts for.
+	Vars map[string]string `json:"vars,omitempty"`
+}
+
+// HookNames lists the lifecycle hooks that can be set in the project configuration.
+var HookNames = []string{"preAdd", "postAdd", "preBuild", "postBuild", "preDeploy", "postDeploy"}
+
+// Prefix returns the prefix used for file names and object IDs, derived from the company name
+// unless set explicitly. The derivation is the legacy one, so that projects created before the
+// prefix was saved keep the IDs of their existing scripts.
+func (c *Project) Prefix() string {
+	if c.CompanyPrefix != "" {
+		return strings.ToLower(c.CompanyPrefix)
+	}
+	return LegacyCompanyPrefix(c.CompanyName)
+}
+
+// SuiteScriptVersion returns the SuiteScript API version written in the @NApiVersion tag of
+// generated scripts.
+func (c *Project) SuiteScriptVersion() string {
+	if c.ApiVersion != "" {
+		return c.ApiVersion
+	}
+	return DefaultApiVersion
+}
+
+// ScriptLanguage returns the language scripts are generated in, LanguageTypeScript unless the
+// project is set to LanguageJavaScript.
+func (c *Project) ScriptLanguage() string {
+	if c.Language != "" {
+		return c.Language
+	}
+	return LanguageTypeScript
+}
+
+// AmdConfigPath returns the File Cabinet path of the amdconfig.json file resolving the module
+// aliases, referenced by the @NAmdConfig tag of generated scripts. It is empty when the project
+// defines no aliases.
+func (c *Project) AmdConfigPath() string {
+	if len(c.Aliases) == 0 {
+		return ""
+	}
+	return "/SuiteScripts/" + c.ProjectName + "/amdconfig.json"
+}
+
+// ScriptId returns the identifier used in the script and deployment IDs of a script name.
+func (c *Project) ScriptId(scriptName string) string {
+	if c.ScriptIdPrefix != "" {
+		return strings.TrimSuffix(c.ScriptIdPrefix, "_") + "_" + ToScriptId(scriptName)
+	}
+	return ToScriptId(scriptName)
+}
+
+// ToScriptId converts a script name into the identifier used for script and deployment IDs.
+func ToScriptId(scriptName string) string {
+	return strings.ReplaceAll(strings.ToLower(scriptName), " ", "_")
+}
+
+// stringFields returns pointers to the string settings of c, in the same order for every
+// configuration.
+func (c *Project) stringFields() []*string {
+	return []*string{
+		&c.ProjectName, &c.CompanyName, &c.UserName, &c.UserEmail, &c.DefaultEnvironment,
+		&c.CompanyPrefix, &c.ScriptIdPrefix, &c.DefaultFolder, &c.ApiVersion, &c.GitHooks, &c.Language,
+		&c.DeployStatus, &c.LogLevel,
+		&c.DateFormat, &c.TimeZone, &c.Locale,
+		&c.License, &c.CopyrightHolder, &c.CopyrightYear, &c.LicenseHeader, &c.TemplatePack,
+		&c.ScriptIdPattern, &c.DeploymentIdPattern, &c.FileNamePattern, &c.ObjectFileNamePattern,
+	}
+}
+
+// Merge returns the personal configuration with unset values taken from the team configuration.
+func Merge(team, personal *Project) *Project {
+	merged := *personal
+	teamFields := team.stringFields()
+	for i, field := range merged.stringFields() {
+		if *field == "" {
+			*field = *teamFields[i]
+		}
+	}
+
+	merged.Environments = mergeMaps(team.Environments, personal.Environments)
+	merged.Hooks = mergeMaps(team.Hooks, personal.Hooks)
+	merged.Aliases = mergeMaps(team.Aliases, personal.Aliases)
+	merged.Vars = mergeMaps(team.Vars, personal.Vars)
+	return &merged
+}
+
+// mergeMaps returns the personal entries added to the team entries.
+func mergeMaps(team, personal map[string]string) map[string]string {
+	if len(team) == 0 {
+		return personal
+	}
+	merged := make(map[string]string)
+	for key, value := range team {
+		merged[key] = value
+	}
+	for key, value := range personal {
+		merged[key] = value
+	}
+	return merged
+}
+
+// Strip returns the configuration without the values that match the team configuration.
+func Strip(team, config *Project) *Project {
+	stripped := *config
+	teamFields := team.stringFields()
+	for i, field := range stripped.stringFields() {
+		if *field == *teamFields[i] {
+			*field = ""
+		}
+	}
+
+	stripped.Environments = stripMap(team.Environments, config.Environments)
+	stripped.Hooks = stripMap(team.Hooks, config.Hooks)
+	stripped.Aliases = stripMap(team.Aliases, config.Aliases)
+	stripped.Vars = stripMap(team.Vars, config.Vars)
+	return &stripped
+}
+
+// stripMap returns the entries of values that differ from the team entries.
+func stripMap(team, values map[string]string) map[string]string {
+	if len(values) == 0 {
+		return values
+	}
+	stripped := make(map[string]string)
+	for key, value := range values {
+		if team[key] != value {
+			stripped[key] = value
+		}
+	}
+	return stripped
+}
+
+// FindRoot walks up from dir to the nearest directory holding a .netsuite-cli project file, in
+// any of the FileExtensions formats. The home directory is skipped since its .netsuite-cli file
+// holds the user configuration.
+func FindRoot(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("error resolving %s: %v", dir, err)
+	}
+	homeDir, _ := os.UserHomeDir()
+
+	for ; ; dir = filepath.Dir(dir) {
+		if dir != homeDir {
+			if ProjectFile(dir) != "" {
+				return dir, nil
+			}
+		}
+		if filepath.Dir(dir) == dir {
+			return "", ErrNoProject
+		}
+	}
+}
+
+// Load reads the project configuration of the project root, merged with the team configuration.
+// The team configuration is returned too, nil when there is none, so it can be passed to Save.
+// Older configurations are read as they are, see Migrate to upgrade them.
+func Load(root string) (*Project, *Project, error) {
+	path, err := findFile(root, FileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if path == "" {
+		path = filepath.Join(root, FileName)
+	}
+
+	var config Project
+	if err := readFile(path, &config); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, fmt.Errorf("error reading config file: %v", err)
+		}
+		return nil, nil, fmt.Errorf("error parsing %s: %v", filepath.Base(path), err)
+	}
+	if config.Version > SchemaVersion {
+		return nil, nil, fmt.Errorf("%s has version %d, which needs a newer netsuite-cli (this one reads up to version %d)", filepath.Base(path), config.Version, SchemaVersion)
+	}
+
+	team, err := LoadTeam(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	if team != nil {
+		config = *Merge(team, &config)
+	}
+	return &config, team, nil
+}
+
+// Save writes the project configuration to the .netsuite-cli file in the specified directory,
+// in the format of the existing file or JSON for a new one, with the current SchemaVersion.
+// Values inherited from team, which may be nil, are not copied into it.
+func Save(dir string, config, team *Project) error {
+	if team != nil {
+		config = Strip(team, config)
+	}
+	saved := *config
+	saved.Version = SchemaVersion
+
+	path, err := findFile(dir, FileName)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		path = filepath.Join(dir, FileName)
+	}
+	return writeFile(path, &saved, false)
+}
+
+// LoadTeam reads the team configuration of the project root, returning nil when there is none.
+func LoadTeam(root string) (*Project, error) {
+	path, err := findFile(root, TeamFileName)
+	if err != nil || path == "" {
+		return nil, err
+	}
+
+	var team Project
+	if err := readFile(path, &team); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", filepath.Base(path), err)
+	}
+	return &team, nil
+}
+
+// SaveTeam writes the team configuration to the .netsuite-cli.team file in the specified
+// directory, in the format of the existing file or JSON for a new one. Empty values are left
+// out, since personal details usually do not belong in the shared file.
+func SaveTeam(dir string, team *Project) error {
+	path, err := findFile(dir, TeamFileName)
+	if err != nil {
+		return err
+	}
+	if path != "" && fileFormat(path) != "json" {
+		return writeFile(path, team, true)
+	}
+
+	data, err := json.Marshal(team)
+	if err != nil {
+		return fmt.Errorf("error marshaling team config: %v", err)
+	}
+	values := make(map[string]any)
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("error marshaling team config: %v", err)
+	}
+	for key, value := range values {
+		if value == "" {
+			delete(values, key)
+		}
+	}
+	data, err = json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling team config: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, TeamFileName), data, 0644); err != nil {
+		return fmt.Errorf("error writing team config file: %v", err)
+	}
+	return nil
+}
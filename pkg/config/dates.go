@@ -0,0 +1,145 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultDateFormat is the Go time layout of the dates in generated files.
+const DefaultDateFormat = "2006-01-02"
+
+// Dates formats the dates written into generated files.
+type Dates struct {
+	Layout   string // Go time layout, DefaultDateFormat when empty
+	TimeZone string // IANA time zone such as Europe/Madrid, the local time zone when empty
+	Locale   string // language of the month and weekday names, see Locales
+}
+
+// Dates returns the date formatting of the project.
+func (c *Project) Dates() Dates {
+	return Dates{Layout: c.DateFormat, TimeZone: c.TimeZone, Locale: c.Locale}
+}
+
+// Locales lists the languages month and weekday names can be written in. The first one is
+// the default.
+var Locales = []string{"en", "es", "fr", "de", "it", "pt", "nl"}
+
+// localeNames holds the month names, January first, and weekday names, Sunday first, of the
+// locales other than English.
+var localeNames = map[string]struct {
+	months   [12]string
+	weekdays [7]string
+}{
+	"es": {
+		[12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		[7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	},
+	"fr": {
+		[12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		[7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	},
+	"de": {
+		[12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		[7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	},
+	"it": {
+		[12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		[7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+	},
+	"pt": {
+		[12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		[7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+	},
+	"nl": {
+		[12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		[7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+	},
+}
+
+// nameTokens lists the layout elements written as names, longest first so that January is not
+// taken for Jan.
+var nameTokens = []string{"January", "Monday", "Jan", "Mon"}
+
+// Format formats t with the layout, in the time zone and with the month and weekday names of
+// the locale. An unknown time zone is ignored.
+func (d Dates) Format(t time.Time) string {
+	if d.TimeZone != "" {
+		if location, err := time.LoadLocation(d.TimeZone); err == nil {
+			t = t.In(location)
+		}
+	}
+	layout := d.Layout
+	if layout == "" {
+		layout = DefaultDateFormat
+	}
+	names, ok := localeNames[d.Locale]
+	if !ok {
+		return t.Format(layout)
+	}
+
+	// The names are written outside of t.Format, since a localized name such as Montag would
+	// be read as a layout element.
+	var b strings.Builder
+	for layout != "" {
+		i, token := len(layout), ""
+		for _, candidate := range nameTokens {
+			if j := strings.Index(layout, candidate); j >= 0 && (j < i || j == i && len(candidate) > len(token)) {
+				i, token = j, candidate
+			}
+		}
+		b.WriteString(t.Format(layout[:i]))
+		if token == "" {
+			break
+		}
+		switch token {
+		case "January":
+			b.WriteString(names.months[t.Month()-1])
+		case "Jan":
+			b.WriteString(abbreviate(names.months[t.Month()-1]))
+		case "Monday":
+			b.WriteString(names.weekdays[t.Weekday()])
+		case "Mon":
+			b.WriteString(abbreviate(names.weekdays[t.Weekday()]))
+		}
+		layout = layout[i+len(token):]
+	}
+	return b.String()
+}
+
+// abbreviate returns the first three letters of a month or weekday name.
+func abbreviate(name string) string {
+	if utf8.RuneCountInString(name) <= 3 {
+		return name
+	}
+	return string([]rune(name)[:3])
+}
+
+// ValidateDateFormat reports whether layout is a Go time layout with at least one date or time
+// element, e.g. 02/01/2006 or 2006-01-02 15:04 MST.
+func ValidateDateFormat(layout string) error {
+	sample := time.Date(2001, time.February, 3, 4, 5, 6, 0, time.UTC)
+	if sample.Format(layout) == layout {
+		return fmt.Errorf("invalid date format '%s', use a Go time layout such as 2006-01-02 or 02/01/2006", layout)
+	}
+	return nil
+}
+
+// ValidateTimeZone reports whether zone is a known IANA time zone.
+func ValidateTimeZone(zone string) error {
+	if _, err := time.LoadLocation(zone); err != nil || zone == "" {
+		return errors.New("unknown time zone '" + zone + "', use an IANA name such as America/New_York")
+	}
+	return nil
+}
+
+// ValidateLocale reports whether locale is one of Locales.
+func ValidateLocale(locale string) error {
+	if !slices.Contains(Locales, locale) {
+		return fmt.Errorf("unsupported locale '%s' (supported: %s)", locale, strings.Join(Locales, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DeployStatuses lists the statuses of the script deployments generated by add.
+var DeployStatuses = []string{"TESTING", "RELEASED"}
+
+// LogLevels lists the log levels of the script deployments generated by add.
+var LogLevels = []string{"DEBUG", "AUDIT", "ERROR", "EMERGENCY"}
+
+// ValidateDeployStatus reports whether status is one of DeployStatuses.
+func ValidateDeployStatus(status string) error {
+	if !slices.Contains(DeployStatuses, strings.ToUpper(status)) {
+		return fmt.Errorf("unsupported deployment status '%s' (supported: %s)", status, strings.Join(DeployStatuses, ", "))
+	}
+	return nil
+}
+
+// ValidateLogLevel reports whether level is one of LogLevels.
+func ValidateLogLevel(level string) error {
+	if !slices.Contains(LogLevels, strings.ToUpper(level)) {
+		return fmt.Errorf("unsupported log level '%s' (supported: %s)", level, strings.Join(LogLevels, ", "))
+	}
+	return nil
+}
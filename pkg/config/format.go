@@ -0,0 +1,417 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FileExtensions lists the formats a configuration file can be written in, as the extension
+// added to its name: none for JSON, then YAML and TOML. The first one is used for new files.
+var FileExtensions = []string{"", ".yaml", ".yml", ".toml"}
+
+// findFile returns the path of the configuration file named base in dir, in any of the formats
+// of FileExtensions, or an empty string when there is none. Several files for the same
+// configuration are an error, since only one of them would be read.
+func findFile(dir, base string) (string, error) {
+	var found []string
+	for _, ext := range FileExtensions {
+		path := filepath.Join(dir, base+ext)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			found = append(found, path)
+		}
+	}
+	if len(found) > 1 {
+		names := make([]string, len(found))
+		for i, path := range found {
+			names[i] = filepath.Base(path)
+		}
+		return "", fmt.Errorf("found %s in %s, keep only one of them", strings.Join(names, " and "), dir)
+	}
+	if len(found) == 0 {
+		return "", nil
+	}
+	return found[0], nil
+}
+
+// ProjectFile returns the path of the project configuration file in dir, in any format, or an
+// empty string when dir holds none.
+func ProjectFile(dir string) string {
+	path, err := findFile(dir, FileName)
+	if err != nil {
+		return filepath.Join(dir, FileName)
+	}
+	return path
+}
+
+// fileFormat returns the format of a configuration file from its name: json, yaml or toml.
+func fileFormat(path string) string {
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".toml":
+		return "toml"
+	}
+	return "json"
+}
+
+// readFile decodes the configuration file at path into v, a pointer to a configuration struct.
+func readFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	data, err = toJSON(path, data, reflect.TypeOf(v))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// toJSON converts the content of a YAML or TOML configuration file into JSON. Values are
+// converted to the types of the fields of t, so that e.g. apiVersion: 2.1 stays a string.
+// JSON content is returned as is.
+func toJSON(path string, data []byte, t reflect.Type) ([]byte, error) {
+	var value any
+	var err error
+	switch fileFormat(path) {
+	case "yaml":
+		value, err = parseYAML(string(data))
+	case "toml":
+		value, err = parseTOML(string(data))
+	default:
+		return data, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		value = map[string]any{}
+	}
+	if value, err = coerce(value, t, ""); err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// coerce converts a decoded YAML or TOML value to the JSON representation of type t. name is
+// the key of the value in errors.
+func coerce(value any, t reflect.Type, name string) (any, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		values, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a table of settings", keyName(name))
+		}
+		fields := make(map[string]reflect.Type)
+		for i := 0; i < t.NumField(); i++ {
+			tag, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			if tag != "" && tag != "-" {
+				fields[tag] = t.Field(i).Type
+			}
+		}
+		result := make(map[string]any, len(values))
+		for key, item := range values {
+			fieldType, ok := fields[key]
+			if !ok {
+				continue
+			}
+			converted, err := coerce(item, fieldType, joinKey(name, key))
+			if err != nil {
+				return nil, err
+			}
+			result[key] = converted
+		}
+		return result, nil
+	case reflect.Map:
+		values, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a table", keyName(name))
+		}
+		result := make(map[string]any, len(values))
+		for key, item := range values {
+			converted, err := coerce(item, t.Elem(), joinKey(name, key))
+			if err != nil {
+				return nil, err
+			}
+			result[key] = converted
+		}
+		return result, nil
+	case reflect.Slice:
+		items, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a list", keyName(name))
+		}
+		result := make([]any, len(items))
+		for i, item := range items {
+			converted, err := coerce(item, t.Elem(), fmt.Sprintf("%s[%d]", name, i))
+			if err != nil {
+				return nil, err
+			}
+			result[i] = converted
+		}
+		return result, nil
+	case reflect.String:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		case int64:
+			return strconv.FormatInt(v, 10), nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		}
+	case reflect.Bool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b, nil
+			}
+		}
+		return nil, fmt.Errorf("%s: expected true or false", keyName(name))
+	case reflect.Int, reflect.Int64:
+		switch v := value.(type) {
+		case int64:
+			return v, nil
+		case string:
+			if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return n, nil
+			}
+		}
+		return nil, fmt.Errorf("%s: expected a number", keyName(name))
+	}
+	return nil, fmt.Errorf("%s: unexpected value", keyName(name))
+}
+
+// joinKey returns the dotted name of key under parent.
+func joinKey(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+// keyName returns the name of a key in errors.
+func keyName(name string) string {
+	if name == "" {
+		return "configuration"
+	}
+	return name
+}
+
+// writeFile encodes v into the configuration file at path, in the format of its name. With
+// omitEmpty, empty strings are left out of YAML and TOML files as well. An existing YAML or TOML
+// file with comments is not rewritten, since they would be lost, see keepComments.
+func writeFile(path string, v any, omitEmpty bool) error {
+	var data []byte
+	var err error
+	switch fileFormat(path) {
+	case "json":
+		data, err = json.MarshalIndent(v, "", "  ")
+	default:
+		var tree orderedMap
+		if tree, err = orderedJSON(v, omitEmpty); err != nil {
+			break
+		}
+		if existing, readErr := os.ReadFile(path); readErr == nil && hasComments(path, existing) {
+			return keepComments(path, existing, v, tree)
+		}
+		if fileFormat(path) == "yaml" {
+			data = formatYAML(tree)
+		} else {
+			data, err = formatTOML(tree)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("error marshaling %s: %v", filepath.Base(path), err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// hasComments reports whether the content of a YAML or TOML configuration file has comments.
+func hasComments(path string, data []byte) bool {
+	switch fileFormat(path) {
+	case "yaml":
+		return yamlHasComments(string(data))
+	case "toml":
+		return tomlHasComments(string(data))
+	}
+	return false
+}
+
+// keepComments handles a change to a YAML or TOML file with comments, which writing it again
+// would drop. Nothing is written: when v sets the same values as the file it is left as is,
+// otherwise the error lists the settings to change by hand, in the format of the file.
+func keepComments(path string, data []byte, v any, tree orderedMap) error {
+	converted, err := toJSON(path, data, reflect.TypeOf(v))
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %v", filepath.Base(path), err)
+	}
+	current, err := settingValues(converted)
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %v", filepath.Base(path), err)
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("error marshaling %s: %v", filepath.Base(path), err)
+	}
+	wanted, err := settingValues(encoded)
+	if err != nil {
+		return fmt.Errorf("error marshaling %s: %v", filepath.Base(path), err)
+	}
+
+	var changed orderedMap
+	for _, field := range tree {
+		if wanted[field.Key] != nil && !reflect.DeepEqual(current[field.Key], wanted[field.Key]) {
+			changed = append(changed, field)
+		}
+	}
+	var removed []string
+	for key := range current {
+		if wanted[key] == nil {
+			removed = append(removed, key)
+		}
+	}
+	if len(changed) == 0 && len(removed) == 0 {
+		return nil
+	}
+	sort.Strings(removed)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s has comments, which rewriting it would drop, change it by hand instead", filepath.Base(path))
+	if len(changed) > 0 {
+		var settings []byte
+		if fileFormat(path) == "yaml" {
+			settings = formatYAML(changed)
+		} else if settings, err = formatTOML(changed); err != nil {
+			return fmt.Errorf("error marshaling %s: %v", filepath.Base(path), err)
+		}
+		b.WriteString("\nset:\n")
+		for _, line := range strings.Split(strings.TrimRight(string(settings), "\n"), "\n") {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+	if len(removed) > 0 {
+		b.WriteString("\nremove: " + strings.Join(removed, ", ") + "\n")
+	}
+	return errors.New(strings.TrimRight(b.String(), "\n"))
+}
+
+// settingValues decodes the settings of a JSON configuration, leaving out the empty ones, which
+// are the same as unset.
+func settingValues(data []byte) (map[string]any, error) {
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	for key, value := range values {
+		if isEmptySetting(value) {
+			delete(values, key)
+		}
+	}
+	return values, nil
+}
+
+// isEmptySetting reports whether a decoded JSON value is null, an empty string, list or object.
+func isEmptySetting(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+	return false
+}
+
+// orderedMap is a JSON object with the order of its keys, written to YAML and TOML files in the
+// order of the configuration struct.
+type orderedMap []orderedField
+
+type orderedField struct {
+	Key   string
+	Value any // string, bool, json.Number, []any or orderedMap
+}
+
+// orderedJSON returns the JSON object v is marshaled to, with the order of its keys. With
+// omitEmpty, empty strings are left out.
+func orderedJSON(v any, omitEmpty bool) (orderedMap, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	value, err := decodeOrdered(decoder, omitEmpty)
+	if err != nil {
+		return nil, err
+	}
+	tree, ok := value.(orderedMap)
+	if !ok {
+		return nil, fmt.Errorf("expected an object")
+	}
+	return tree, nil
+}
+
+// decodeOrdered decodes the next JSON value of decoder, keeping the order of object keys.
+func decodeOrdered(decoder *json.Decoder, omitEmpty bool) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		tree := orderedMap{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(decoder, omitEmpty)
+			if err != nil {
+				return nil, err
+			}
+			if value == nil || omitEmpty && value == "" {
+				continue
+			}
+			tree = append(tree, orderedField{Key: key.(string), Value: value})
+		}
+		_, err := decoder.Token()
+		return tree, err
+	case json.Delim('['):
+		items := []any{}
+		for decoder.More() {
+			value, err := decodeOrdered(decoder, omitEmpty)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		_, err := decoder.Token()
+		return items, err
+	}
+	return token, nil
+}
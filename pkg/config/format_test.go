@@ -0,0 +1,125 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// sampleProject returns a project configuration with the values that are hard to write: quotes,
+// comment and key markers, multi-line patterns and nested tables.
+func sampleProject() *Project {
+	return &Project{
+		Version:     SchemaVersion,
+		ProjectName: "Orders: \"EU\" #2",
+		CompanyName: "O'Brien & Sons",
+		UserName:    " padded ",
+		UserEmail:   "null",
+		Environments: map[string]string{
+			"sandbox": "1234567_SB1",
+		},
+		CompanyPrefix:   "-x",
+		ApiVersion:      "2.1",
+		LicenseHeader:   "Copyright {{.Year}} {{.Holder}}\n\nAll rights reserved.\n",
+		ScriptIdPattern: "customscript_{{.Prefix}}_{{.Name}}\n{{- if .Type}}_{{.Type}}{{end}}",
+		Hooks: map[string]string{
+			"postAdd":   "npm run lint -- --fix # after add",
+			"preDeploy": `echo "deploying \ now"`,
+		},
+		Aliases: map[string]string{
+			"@lib": "lib",
+		},
+	}
+}
+
+// roundTrip writes config to a file named name in a temporary directory and reads it back.
+func roundTrip(t *testing.T, name string, config *Project) *Project {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := writeFile(path, config, false); err != nil {
+		t.Fatal(err)
+	}
+	var read Project
+	if err := readFile(path, &read); err != nil {
+		data, _ := os.ReadFile(path)
+		t.Fatalf("%v, in:\n%s", err, data)
+	}
+	return &read
+}
+
+func TestWriteFileRoundTrip(t *testing.T) {
+	for _, name := range []string{FileName, FileName + ".yaml", FileName + ".toml"} {
+		t.Run(name, func(t *testing.T) {
+			want := sampleProject()
+			if got := roundTrip(t, name, want); !reflect.DeepEqual(got, want) {
+				t.Errorf("read back\n%+v\nwant\n%+v", got, want)
+			}
+		})
+	}
+}
+
+func TestWriteFileKeepsComments(t *testing.T) {
+	tests := map[string]string{
+		FileName + ".yaml": "# Orders project\nversion: 1\nprojectName: Orders # shown in deploy.xml\ncompanyName: Acme\nhooks:\n  # run before every deploy\n  preDeploy: npm test\n",
+		FileName + ".toml": "# Orders project\nversion = 1\nprojectName = \"Orders\" # shown in deploy.xml\ncompanyName = \"Acme\"\n\n[hooks]\n# run before every deploy\npreDeploy = \"npm test\"\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			var config Project
+			if err := readFile(path, &config); err != nil {
+				t.Fatal(err)
+			}
+
+			// Unchanged settings leave the file as is.
+			if err := writeFile(path, &config, false); err != nil {
+				t.Errorf("writing the same settings: %v", err)
+			}
+
+			config.ProjectName = "Returns"
+			config.Hooks = nil
+			err := writeFile(path, &config, false)
+			if err == nil {
+				t.Fatal("writing changed settings did not fail")
+			}
+			for _, want := range []string{"has comments", "projectName", "Returns", "remove: hooks"} {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err, want)
+				}
+			}
+			if strings.Contains(err.Error(), "companyName") {
+				t.Errorf("error %q lists the unchanged companyName", err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != content {
+				t.Errorf("file was rewritten:\n%s", data)
+			}
+		})
+	}
+}
+
+func TestWriteFileRewritesWithoutComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName+".yaml")
+	if err := os.WriteFile(path, []byte("version: 1\nprojectName: Orders\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFile(path, &Project{Version: 1, ProjectName: "Returns"}, true); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "version: 1\nprojectName: Returns\n"; string(data) != want {
+		t.Errorf("file is\n%s\nwant\n%s", data, want)
+	}
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// LicenseHeaderStyles lists the forms of the license header: a license sentence, an
+// SPDX-License-Identifier tag, or both. The first one is the default.
+var LicenseHeaderStyles = []string{"text", "spdx", "both"}
+
+// HasLicenseHeader reports whether generated files get a license header, which is the case once
+// a license or a copyright holder is configured.
+func (c *Project) HasLicenseHeader() bool {
+	return c.License != "" || c.CopyrightHolder != ""
+}
+
+// LicenseHeaderStyle returns the form of the license header, the first of LicenseHeaderStyles
+// unless set.
+func (c *Project) LicenseHeaderStyle() string {
+	if c.LicenseHeader != "" {
+		return c.LicenseHeader
+	}
+	return LicenseHeaderStyles[0]
+}
+
+// Copyright returns the copyright holder and year range of the license header for the given
+// current year, e.g. "2021-2026 Acme". The holder defaults to the company name and the range
+// starts at CopyrightYear when it is set and earlier than year.
+func (c *Project) Copyright(year int) string {
+	holder := c.CopyrightHolder
+	if holder == "" {
+		holder = c.CompanyName
+	}
+	years := strconv.Itoa(year)
+	if start, err := strconv.Atoi(c.CopyrightYear); err == nil && start < year {
+		years = c.CopyrightYear + "-" + years
+	}
+	return strings.TrimSpace(years + " " + holder)
+}
+
+// ValidateLicenseHeader reports whether style is one of LicenseHeaderStyles.
+func ValidateLicenseHeader(style string) error {
+	if !slices.Contains(LicenseHeaderStyles, style) {
+		return fmt.Errorf("unsupported license header '%s' (supported: %s)", style, strings.Join(LicenseHeaderStyles, ", "))
+	}
+	return nil
+}
+
+// ValidateCopyrightYear reports whether year is a four digit year.
+func ValidateCopyrightYear(year string) error {
+	if _, err := strconv.Atoi(year); err != nil || len(year) != 4 {
+		return fmt.Errorf("invalid copyright year '%s', expected a year such as 2021", year)
+	}
+	return nil
+}
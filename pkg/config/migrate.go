@@ -0,0 +1,123 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+)
+
+// SchemaVersion is the version of the project configuration written by this version of
+// netsuite-cli. Configurations without a version field are version 0.
+const SchemaVersion = 1
+
+// migration upgrades a project configuration from the version before it to its own version. file
+// holds the keys of the personal configuration file and team those of the team configuration,
+// nil when there is none; only file is changed.
+type migration func(file, team map[string]any)
+
+// migrations lists the migrations in order, the first one upgrading version 0 to 1. Migrations
+// are only appended, so that older configurations go through every later one.
+var migrations = []migration{
+	// Version 1 stores the values that used to be derived at every run, so they no longer
+	// change with the company name or the defaults of a later release. The prefix is the one
+	// version 0 derived, which the existing scripts of the project are named with.
+	func(file, team map[string]any) {
+		if !hasSetting(file, team, "companyPrefix") {
+			if companyName, _ := file["companyName"].(string); companyName != "" {
+				file["companyPrefix"] = LegacyCompanyPrefix(companyName)
+			}
+		}
+		if !hasSetting(file, team, "apiVersion") {
+			file["apiVersion"] = DefaultApiVersion
+		}
+	},
+}
+
+// hasSetting reports whether the personal or team configuration sets key.
+func hasSetting(file, team map[string]any, key string) bool {
+	if value, ok := file[key]; ok && value != "" {
+		return true
+	}
+	value, ok := team[key]
+	return ok && value != ""
+}
+
+// readSettings returns the keys of the configuration file at path, in any format, and its
+// content.
+func readSettings(path string) (map[string]any, []byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading config file: %v", err)
+	}
+	converted, err := toJSON(path, data, reflect.TypeOf(Project{}))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing %s: %v", filepath.Base(path), err)
+	}
+	var settings map[string]any
+	if err := json.Unmarshal(converted, &settings); err != nil {
+		return nil, nil, fmt.Errorf("error parsing %s: %v", filepath.Base(path), err)
+	}
+	return settings, data, nil
+}
+
+// Migrate upgrades the .netsuite-cli file of the project root to SchemaVersion, keeping a copy
+// of the original next to it with a .v<version>.bak suffix, e.g. .netsuite-cli.yaml.v0.bak. It
+// returns the version the file had, which is at least SchemaVersion when nothing was migrated.
+func Migrate(root string) (int, error) {
+	path, err := findFile(root, FileName)
+	if err != nil {
+		return 0, err
+	}
+	if path == "" {
+		path = filepath.Join(root, FileName)
+	}
+	file, data, err := readSettings(path)
+	if err != nil {
+		return 0, err
+	}
+
+	version := 0
+	if v, ok := file["version"].(float64); ok {
+		version = int(v)
+	}
+	if version >= SchemaVersion {
+		// Newer versions are rejected by Load.
+		return version, nil
+	}
+
+	var team map[string]any
+	if teamPath, err := findFile(root, TeamFileName); err != nil {
+		return version, err
+	} else if teamPath != "" {
+		if team, _, err = readSettings(teamPath); err != nil {
+			return version, err
+		}
+	}
+	for _, migrate := range migrations[version:] {
+		migrate(file, team)
+	}
+
+	// The migrated keys are decoded into Project and saved like any other change, so the
+	// file keeps the usual key order.
+	migrated, err := json.Marshal(file)
+	if err != nil {
+		return version, fmt.Errorf("error migrating config file: %v", err)
+	}
+	var config Project
+	if err := json.Unmarshal(migrated, &config); err != nil {
+		return version, fmt.Errorf("error migrating config file: %v", err)
+	}
+
+	backup := fmt.Sprintf("%s.v%d.bak", path, version)
+	if err := os.WriteFile(backup, data, 0644); err != nil {
+		return version, fmt.Errorf("error writing config backup: %v", err)
+	}
+	if err := Save(root, &config, nil); err != nil {
+		// A YAML or TOML file with comments is left for the user to change, see keepComments.
+		os.Remove(backup)
+		return version, fmt.Errorf("error migrating %s to version %d: %v", filepath.Base(path), SchemaVersion, err)
+	}
+	return version, nil
+}
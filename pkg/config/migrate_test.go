@@ -0,0 +1,125 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// copyFixture copies the files of testdata/name into a temporary directory and returns it.
+func copyFixture(t *testing.T, name string) string {
+	t.Helper()
+	dir := t.TempDir()
+	entries, err := os.ReadDir(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join("testdata", name, entry.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, entry.Name()), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestMigrateV0KeepsLegacyPrefix(t *testing.T) {
+	dir := copyFixture(t, "v0")
+	before, _, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from, err := Migrate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != 0 {
+		t.Errorf("Migrate returned version %d, want 0", from)
+	}
+	if _, err := os.Stat(filepath.Join(dir, FileName+".v0.bak")); err != nil {
+		t.Errorf("no backup of the original file: %v", err)
+	}
+
+	after, _, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Version != SchemaVersion {
+		t.Errorf("version = %d, want %d", after.Version, SchemaVersion)
+	}
+	if after.CompanyPrefix != "acm" {
+		t.Errorf("companyPrefix = %q, want %q", after.CompanyPrefix, "acm")
+	}
+	if after.Prefix() != before.Prefix() {
+		t.Errorf("prefix changed from %q to %q", before.Prefix(), after.Prefix())
+	}
+	if after.ApiVersion != DefaultApiVersion {
+		t.Errorf("apiVersion = %q, want %q", after.ApiVersion, DefaultApiVersion)
+	}
+
+	if from, err := Migrate(dir); err != nil || from != SchemaVersion {
+		t.Errorf("second Migrate = %d, %v, want %d, nil", from, err, SchemaVersion)
+	}
+}
+
+func TestMigrateV0KeepsTeamPrefix(t *testing.T) {
+	dir := copyFixture(t, "v0")
+	if err := os.WriteFile(filepath.Join(dir, TeamFileName), []byte(`{"companyPrefix": "acme"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Migrate(dir); err != nil {
+		t.Fatal(err)
+	}
+	config, _, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Prefix() != "acme" {
+		t.Errorf("prefix = %q, want the team prefix %q", config.Prefix(), "acme")
+	}
+}
+
+func TestMigrateKeepsComments(t *testing.T) {
+	dir := t.TempDir()
+	content := "# Orders project\nprojectName: Orders\ncompanyName: Acme Cloud Ops\n"
+	path := filepath.Join(dir, FileName+".yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Migrate(dir)
+	if err == nil {
+		t.Fatal("Migrate did not fail")
+	}
+	for _, want := range []string{"version: 1", "companyPrefix: acm", "apiVersion: " + DefaultApiVersion} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+	if data, _ := os.ReadFile(path); string(data) != content {
+		t.Errorf("file was rewritten:\n%s", data)
+	}
+	if _, err := os.Stat(path + ".v0.bak"); !os.IsNotExist(err) {
+		t.Errorf("backup left behind: %v", err)
+	}
+}
+
+func TestLegacyCompanyPrefix(t *testing.T) {
+	tests := map[string]string{
+		"Acme Cloud Ops": "acm",
+		"  Globex ":      "glo",
+		"IBM":            "ibm",
+		"Al":             "al",
+		"":               "com",
+	}
+	for name, want := range tests {
+		if got := LegacyCompanyPrefix(name); got != want {
+			t.Errorf("LegacyCompanyPrefix(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// Default naming patterns, used when the project configuration does not set them.
+const (
+	DefaultScriptIdPattern       = "customscript_{{.Id}}"
+	DefaultDeploymentIdPattern   = "customdeploy_{{.Id}}"
+	DefaultFileNamePattern       = "{{.Prefix}}_{{.Name}}_{{.Type}}"
+	DefaultObjectFileNamePattern = "{{.Prefix}}_{{.Name}}"
+)
+
+// MaxObjectIdLength is the longest script ID NetSuite accepts for a custom object.
+const MaxObjectIdLength = 40
+
+// idRule describes the characters of the IDs NetSuite accepts.
+const idRule = "may only contain lowercase letters, digits and underscores"
+
+var (
+	objectIdRe            = regexp.MustCompile(`^[a-z0-9_]+$`)
+	scriptIdPatternRe     = regexp.MustCompile(`^customscript_[a-z0-9_]+$`)
+	deploymentIdPatternRe = regexp.MustCompile(`^customdeploy_[a-z0-9_]+$`)
+	fileNamePatternRe     = regexp.MustCompile(`^[^<>:"/\\|?*]+$`)
+)
+
+// NamingData holds the values available to the naming patterns.
+type NamingData struct {
+	Prefix string // company prefix, e.g. acm
+	Name   string // script name as entered, e.g. order_sync
+	Id     string // script name as used in IDs, including the script ID prefix
+	Type   string // script type, e.g. suitelet
+}
+
+// ScriptNaming holds the IDs and file names generated for a script.
+type ScriptNaming struct {
+	ScriptId       string
+	DeploymentId   string
+	FileName       string // TypeScript file name without extension
+	ObjectFileName string // object XML file name without extension
+}
+
+// ScriptNaming applies the naming patterns of the project to a script name and type.
+func (c *Project) ScriptNaming(scriptName, scriptType string) (ScriptNaming, error) {
+	data := NamingData{
+		Prefix: c.Prefix(),
+		Name:   scriptName,
+		Id:     c.ScriptId(scriptName),
+		Type:   scriptType,
+	}
+
+	const fileRule = `cannot contain < > : " / \ | ? *`
+	var naming ScriptNaming
+	patterns := []struct {
+		key     string
+		label   string
+		pattern string
+		def     string
+		valid   *regexp.Regexp
+		rule    string
+		target  *string
+	}{
+		{"scriptIdPattern", "script ID", c.ScriptIdPattern, DefaultScriptIdPattern, scriptIdPatternRe, idRule, &naming.ScriptId},
+		{"deploymentIdPattern", "deployment ID", c.DeploymentIdPattern, DefaultDeploymentIdPattern, deploymentIdPatternRe, idRule, &naming.DeploymentId},
+		{"fileNamePattern", "file name", c.FileNamePattern, DefaultFileNamePattern, fileNamePatternRe, fileRule, &naming.FileName},
+		{"objectFileNamePattern", "object file name", c.ObjectFileNamePattern, DefaultObjectFileNamePattern, fileNamePatternRe, fileRule, &naming.ObjectFileName},
+	}
+
+	for _, p := range patterns {
+		pattern := p.pattern
+		if pattern == "" {
+			pattern = p.def
+		}
+		value, err := ApplyNamingPattern(pattern, data)
+		if err != nil {
+			return ScriptNaming{}, fmt.Errorf("invalid %s: %v", p.key, err)
+		}
+		if !p.valid.MatchString(value) {
+			return ScriptNaming{}, &InvalidScriptNameError{ScriptName: scriptName, Key: p.key, Label: p.label, Value: value, Rule: p.rule, Custom: p.pattern != ""}
+		}
+		*p.target = value
+	}
+
+	return naming, nil
+}
+
+// InvalidScriptNameError reports a script name the naming patterns turn into an invalid ID or
+// file name.
+type InvalidScriptNameError struct {
+	ScriptName string
+	Key        string // setting of the naming pattern, e.g. scriptIdPattern
+	Label      string // what the pattern names, e.g. script ID
+	Value      string // the invalid value
+	Rule       string // what the value may contain
+	Custom     bool   // whether the pattern is set in the configuration
+}
+
+func (e *InvalidScriptNameError) Error() string {
+	msg := fmt.Sprintf("invalid script name '%s': the %s '%s' %s", e.ScriptName, e.Label, e.Value, e.Rule)
+	if e.Custom {
+		msg += " (with the " + e.Key + " of the project)"
+	}
+	return msg
+}
+
+// ObjectId returns the script ID of a custom object of the given kind, e.g.
+// customrecord_acm_invoice_batch for the kind customrecord and the name Invoice Batch. It fails
+// when the name leaves characters NetSuite does not accept in IDs, such as punctuation or
+// accents, or makes the ID longer than MaxObjectIdLength.
+func (c *Project) ObjectId(kind, name string) (string, error) {
+	id := kind + "_" + c.Prefix() + "_" + ToScriptId(name)
+	if !objectIdRe.MatchString(id) {
+		return "", fmt.Errorf("invalid name '%s': the ID '%s' %s", name, id, idRule)
+	}
+	if len(id) > MaxObjectIdLength {
+		return "", fmt.Errorf("invalid name '%s': the ID '%s' is longer than %d characters", name, id, MaxObjectIdLength)
+	}
+	return id, nil
+}
+
+// ApplyNamingPattern renders a naming pattern with the given data.
+func ApplyNamingPattern(pattern string, data NamingData) (string, error) {
+	tmpl, err := template.New("naming").Option("missingkey=error").Parse(pattern)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// companyNameSuffixes lists legal entity suffixes ignored when deriving a company prefix.
+var companyNameSuffixes = []string{"co", "corp", "gmbh", "inc", "llc", "llp", "ltd", "plc", "sa", "srl"}
+
+// companyPrefixRe matches the prefixes allowed in NetSuite object IDs.
+var companyPrefixRe = regexp.MustCompile(`^[a-z0-9]{1,10}$`)
+
+// CompanyPrefix generates a 3-letter prefix from the company name, ignoring punctuation and
+// legal suffixes such as "Inc". Names with several words use their initials ("Acme Cloud Ops"
+// -> "aco"), completed with the letters of the last word when there are fewer than three; single
+// words use their first three letters. It is only used when a project is created, existing
+// configurations without a prefix keep LegacyCompanyPrefix.
+func CompanyPrefix(companyName string) string {
+	words := strings.FieldsFunc(strings.ToLower(companyName), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	if len(words) > 1 {
+		var significant []string
+		for _, word := range words {
+			if !slices.Contains(companyNameSuffixes, word) {
+				significant = append(significant, word)
+			}
+		}
+		if len(significant) > 0 {
+			words = significant
+		}
+	}
+	if len(words) == 0 {
+		return "com"
+	}
+
+	var prefix string
+	if len(words) == 1 {
+		prefix = words[0]
+	} else {
+		for _, word := range words {
+			prefix += word[:1]
+		}
+		last := words[len(words)-1]
+		if len(prefix) < 3 && len(last) > 1 {
+			prefix += last[1:]
+		}
+	}
+
+	if len(prefix) > 3 {
+		prefix = prefix[:3]
+	}
+	return prefix
+}
+
+// LegacyCompanyPrefix returns the prefix derived from the company name before CompanyPrefix
+// used initials: its first three characters in lowercase ("Acme Cloud Ops" -> "acm"). Projects
+// whose configuration has no companyPrefix were generated with it.
+func LegacyCompanyPrefix(companyName string) string {
+	prefix := strings.ToLower(strings.TrimSpace(companyName))
+	if prefix == "" {
+		return "com"
+	}
+	if len(prefix) > 3 {
+		prefix = prefix[:3]
+	}
+	return prefix
+}
+
+// ValidateCompanyPrefix reports whether an explicit company prefix can be used in NetSuite
+// object IDs.
+func ValidateCompanyPrefix(prefix string) error {
+	if !companyPrefixRe.MatchString(prefix) {
+		return fmt.Errorf("company prefix '%s' must be 1 to 10 lowercase letters or digits", prefix)
+	}
+	return nil
+}
+
+// ApiVersions lists the SuiteScript API versions supported in the @NApiVersion tag.
+var ApiVersions = []string{"2.0", "2.1", "2.x"}
+
+const (
+	// DefaultApiVersion is the @NApiVersion written when the project does not set one.
+	DefaultApiVersion = "2.x"
+	// DefaultTypingsVersion is the @hitc/netsuite-types version written to package.json.
+	DefaultTypingsVersion = "^2025.2.10"
+)
+
+// Languages scripts can be generated in.
+const (
+	LanguageTypeScript = "ts"
+	LanguageJavaScript = "js"
+)
+
+// ValidateLanguage reports whether language is a supported script language. JavaScript scripts
+// use SuiteScript 2.1 syntax, so they cannot be combined with API version 2.0.
+func ValidateLanguage(language, apiVersion string) error {
+	if language != LanguageTypeScript && language != LanguageJavaScript {
+		return fmt.Errorf("unsupported language '%s' (supported: %s, %s)", language, LanguageTypeScript, LanguageJavaScript)
+	}
+	if language == LanguageJavaScript && apiVersion == "2.0" {
+		return errors.New("JavaScript scripts are generated for SuiteScript 2.1 and cannot use API version 2.0")
+	}
+	return nil
+}
+
+// ValidateApiVersion reports whether version is a supported SuiteScript API version.
+func ValidateApiVersion(version string) error {
+	if !slices.Contains(ApiVersions, version) {
+		return fmt.Errorf("unsupported SuiteScript API version '%s' (supported: %s)", version, strings.Join(ApiVersions, ", "))
+	}
+	return nil
+}
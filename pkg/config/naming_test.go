@@ -0,0 +1,25 @@
+package config
+
+import "testing"
+
+func TestObjectId(t *testing.T) {
+	c := &Project{CompanyPrefix: "acm"}
+	tests := map[string]string{
+		"invoice batch":                 "customrecord_acm_invoice_batch",
+		"Invoice Batch":                 "customrecord_acm_invoice_batch",
+		"batch_2":                       "customrecord_acm_batch_2",
+		"invoice-batch":                 "",
+		"Café":                          "",
+		"batch (old)":                   "",
+		"a_very_long_name_for_a_record": "",
+	}
+	for name, want := range tests {
+		id, err := c.ObjectId("customrecord", name)
+		switch {
+		case want == "" && err == nil:
+			t.Errorf("%q = %s, want an error", name, id)
+		case want != "" && (err != nil || id != want):
+			t.Errorf("%q = %s, %v, want %s", name, id, err, want)
+		}
+	}
+}
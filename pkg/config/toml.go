@@ -0,0 +1,559 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseTOML parses a TOML configuration file: tables, arrays of tables, dotted and quoted keys,
+// basic, literal and multi-line strings, numbers, booleans, arrays and inline tables. Dates are
+// not supported.
+func parseTOML(content string) (any, error) {
+	return newTOMLParser(content).parse()
+}
+
+// tomlHasComments reports whether TOML content has comments, which are left out when the
+// file is written again.
+func tomlHasComments(content string) bool {
+	p := newTOMLParser(content)
+	p.parse()
+	return p.comments
+}
+
+type tomlParser struct {
+	src      string
+	pos      int
+	line     int
+	comments bool // a comment was skipped
+}
+
+func newTOMLParser(content string) *tomlParser {
+	return &tomlParser{src: strings.ReplaceAll(content, "\r\n", "\n"), line: 1}
+}
+
+func (p *tomlParser) parse() (any, error) {
+	root := map[string]any{}
+	current := root
+	for {
+		p.skipSpace(true)
+		if p.eof() {
+			return root, nil
+		}
+		var err error
+		if p.peek() == '[' {
+			current, err = p.header(root)
+		} else {
+			err = p.keyValue(current)
+		}
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace(false)
+		if !p.eof() && p.peek() != '\n' {
+			return nil, p.errorf("expected end of line")
+		}
+	}
+}
+
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *tomlParser) peek() byte {
+	return p.src[p.pos]
+}
+
+func (p *tomlParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+// skipSpace skips spaces, tabs and comments, and newlines as well when newlines is set.
+func (p *tomlParser) skipSpace(newlines bool) {
+	for !p.eof() {
+		switch c := p.peek(); {
+		case c == ' ' || c == '\t' || c == '\r':
+			p.pos++
+		case c == '\n' && newlines:
+			p.pos++
+			p.line++
+		case c == '#':
+			p.comments = true
+			for !p.eof() && p.peek() != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// header parses a [table] or [[array]] header and returns the table the following keys go in.
+func (p *tomlParser) header(root map[string]any) (map[string]any, error) {
+	array := strings.HasPrefix(p.src[p.pos:], "[[")
+	if array {
+		p.pos += 2
+	} else {
+		p.pos++
+	}
+	keys, err := p.key()
+	if err != nil {
+		return nil, err
+	}
+	closing := "]"
+	if array {
+		closing = "]]"
+	}
+	if !strings.HasPrefix(p.src[p.pos:], closing) {
+		return nil, p.errorf("expected '%s'", closing)
+	}
+	p.pos += len(closing)
+
+	table, err := p.table(root, keys[:len(keys)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := keys[len(keys)-1]
+	if array {
+		items, _ := table[last].([]any)
+		if _, ok := table[last]; ok && items == nil {
+			return nil, p.errorf("'%s' is not an array of tables", strings.Join(keys, "."))
+		}
+		item := map[string]any{}
+		table[last] = append(items, item)
+		return item, nil
+	}
+	return p.table(table, []string{last})
+}
+
+// table returns the table at keys under parent, creating the missing ones. A key naming an array
+// of tables continues in its last table.
+func (p *tomlParser) table(parent map[string]any, keys []string) (map[string]any, error) {
+	for _, key := range keys {
+		switch v := parent[key].(type) {
+		case nil:
+			table := map[string]any{}
+			parent[key] = table
+			parent = table
+		case map[string]any:
+			parent = v
+		case []any:
+			table, ok := v[len(v)-1].(map[string]any)
+			if !ok {
+				return nil, p.errorf("'%s' is not a table", key)
+			}
+			parent = table
+		default:
+			return nil, p.errorf("'%s' is not a table", key)
+		}
+	}
+	return parent, nil
+}
+
+// keyValue parses a key = value line into table.
+func (p *tomlParser) keyValue(table map[string]any) error {
+	keys, err := p.key()
+	if err != nil {
+		return err
+	}
+	if p.eof() || p.peek() != '=' {
+		return p.errorf("expected '=' after %s", strings.Join(keys, "."))
+	}
+	p.pos++
+	p.skipSpace(false)
+	value, err := p.value()
+	if err != nil {
+		return err
+	}
+	table, err = p.table(table, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+	last := keys[len(keys)-1]
+	if _, ok := table[last]; ok {
+		return p.errorf("duplicate key '%s'", strings.Join(keys, "."))
+	}
+	table[last] = value
+	return nil
+}
+
+// key parses a bare, quoted or dotted key.
+func (p *tomlParser) key() ([]string, error) {
+	var keys []string
+	for {
+		p.skipSpace(false)
+		if p.eof() {
+			return nil, p.errorf("expected a key")
+		}
+		switch c := p.peek(); {
+		case c == '"' || c == '\'':
+			key, err := p.str()
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		default:
+			start := p.pos
+			for !p.eof() && isBareKeyChar(p.peek()) {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, p.errorf("expected a key")
+			}
+			keys = append(keys, p.src[start:p.pos])
+		}
+		p.skipSpace(false)
+		if p.eof() || p.peek() != '.' {
+			return keys, nil
+		}
+		p.pos++
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+// value parses a string, number, boolean, array or inline table.
+func (p *tomlParser) value() (any, error) {
+	if p.eof() {
+		return nil, p.errorf("expected a value")
+	}
+	switch c := p.peek(); {
+	case c == '"' || c == '\'':
+		return p.str()
+	case c == '[':
+		return p.array()
+	case c == '{':
+		return p.inlineTable()
+	}
+
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(" \t\r\n,]}#", rune(p.peek())) {
+		p.pos++
+	}
+	word := p.src[start:p.pos]
+	switch word {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "":
+		return nil, p.errorf("expected a value")
+	}
+	number := strings.ReplaceAll(word, "_", "")
+	if n, err := strconv.ParseInt(number, 0, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(number, 64); err == nil {
+		return f, nil
+	}
+	return nil, p.errorf("invalid value '%s'", word)
+}
+
+// array parses an array, which may span several lines.
+func (p *tomlParser) array() (any, error) {
+	p.pos++
+	items := []any{}
+	for {
+		p.skipSpace(true)
+		if p.eof() {
+			return nil, p.errorf("unterminated array")
+		}
+		if p.peek() == ']' {
+			p.pos++
+			return items, nil
+		}
+		item, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		p.skipSpace(true)
+		if !p.eof() && p.peek() == ',' {
+			p.pos++
+		} else if p.eof() || p.peek() != ']' {
+			return nil, p.errorf("expected ',' or ']' in array")
+		}
+	}
+}
+
+// inlineTable parses a { key = value, ... } table.
+func (p *tomlParser) inlineTable() (any, error) {
+	p.pos++
+	table := map[string]any{}
+	p.skipSpace(false)
+	if !p.eof() && p.peek() == '}' {
+		p.pos++
+		return table, nil
+	}
+	for {
+		if err := p.keyValue(table); err != nil {
+			return nil, err
+		}
+		p.skipSpace(false)
+		if p.eof() {
+			return nil, p.errorf("unterminated inline table")
+		}
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return table, nil
+		default:
+			return nil, p.errorf("expected ',' or '}' in inline table")
+		}
+	}
+}
+
+// str parses a basic, literal or multi-line string.
+func (p *tomlParser) str() (string, error) {
+	quote := p.src[p.pos : p.pos+1]
+	multiline := strings.HasPrefix(p.src[p.pos:], strings.Repeat(quote, 3))
+	if multiline {
+		p.pos += 3
+		// A newline right after the opening quotes is not part of the string.
+		if strings.HasPrefix(p.src[p.pos:], "\n") {
+			p.pos++
+			p.line++
+		}
+	} else {
+		p.pos++
+	}
+
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated string")
+		}
+		c := p.peek()
+		switch {
+		case multiline && strings.HasPrefix(p.src[p.pos:], strings.Repeat(quote, 3)):
+			p.pos += 3
+			// Up to two quotes may directly precede the closing ones.
+			for i := 0; i < 2 && strings.HasPrefix(p.src[p.pos:], quote); i++ {
+				b.WriteString(quote)
+				p.pos++
+			}
+			return b.String(), nil
+		case !multiline && c == quote[0]:
+			p.pos++
+			return b.String(), nil
+		case c == '\n':
+			if !multiline {
+				return "", p.errorf("unterminated string")
+			}
+			b.WriteByte(c)
+			p.pos++
+			p.line++
+		case c == '\\' && quote == `"`:
+			if err := p.escape(&b, multiline); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+// escape writes the character of the escape sequence at the current position of a basic string.
+func (p *tomlParser) escape(b *strings.Builder, multiline bool) error {
+	p.pos++
+	if p.eof() {
+		return p.errorf("unterminated string")
+	}
+	c := p.peek()
+	p.pos++
+	switch c {
+	case 'b':
+		b.WriteByte('\b')
+	case 't':
+		b.WriteByte('\t')
+	case 'n':
+		b.WriteByte('\n')
+	case 'f':
+		b.WriteByte('\f')
+	case 'r':
+		b.WriteByte('\r')
+	case '"', '\\':
+		b.WriteByte(c)
+	case 'u', 'U':
+		size := 4
+		if c == 'U' {
+			size = 8
+		}
+		if p.pos+size > len(p.src) {
+			return p.errorf("invalid escape sequence")
+		}
+		code, err := strconv.ParseUint(p.src[p.pos:p.pos+size], 16, 32)
+		if err != nil {
+			return p.errorf("invalid escape sequence")
+		}
+		b.WriteRune(rune(code))
+		p.pos += size
+	default:
+		// A backslash at the end of a line of a multi-line string trims the following whitespace.
+		rest := p.src[p.pos-1:]
+		if end := strings.IndexByte(rest, '\n'); multiline && end >= 0 && strings.Trim(rest[:end], " \t\r") == "" {
+			p.pos--
+			for !p.eof() && strings.ContainsRune(" \t\r\n", rune(p.peek())) {
+				if p.peek() == '\n' {
+					p.line++
+				}
+				p.pos++
+			}
+			return nil
+		}
+		return p.errorf("invalid escape sequence '\\%c'", c)
+	}
+	return nil
+}
+
+// formatTOML writes a configuration as TOML: the plain settings first, then a table for each
+// object and an array of tables for each list of objects. Multi-line strings are written as
+// """ strings.
+func formatTOML(tree orderedMap) ([]byte, error) {
+	var b strings.Builder
+	if err := writeTOMLTable(&b, tree, nil); err != nil {
+		return nil, err
+	}
+	return []byte(strings.TrimPrefix(b.String(), "\n")), nil
+}
+
+// writeTOMLTable writes the settings of the table at path, then its nested tables.
+func writeTOMLTable(b *strings.Builder, tree orderedMap, path []string) error {
+	var tables []orderedField
+	for _, field := range tree {
+		if isTOMLTable(field.Value) {
+			tables = append(tables, field)
+			continue
+		}
+		value, err := tomlValue(field.Value)
+		if err != nil {
+			return fmt.Errorf("%s: %v", strings.Join(append(path, field.Key), "."), err)
+		}
+		b.WriteString(tomlKey(field.Key) + " = " + value + "\n")
+	}
+	for _, field := range tables {
+		name := append(append([]string{}, path...), field.Key)
+		header := make([]string, len(name))
+		for i, key := range name {
+			header[i] = tomlKey(key)
+		}
+		if table, ok := field.Value.(orderedMap); ok {
+			b.WriteString("\n[" + strings.Join(header, ".") + "]\n")
+			if err := writeTOMLTable(b, table, name); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, item := range field.Value.([]any) {
+			b.WriteString("\n[[" + strings.Join(header, ".") + "]]\n")
+			if err := writeTOMLTable(b, item.(orderedMap), name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isTOMLTable reports whether value is written as a table or an array of tables rather than as
+// a value: a non-empty object, or a non-empty list of objects only.
+func isTOMLTable(value any) bool {
+	switch v := value.(type) {
+	case orderedMap:
+		return len(v) > 0
+	case []any:
+		for _, item := range v {
+			if _, ok := item.(orderedMap); !ok {
+				return false
+			}
+		}
+		return len(v) > 0
+	}
+	return false
+}
+
+// tomlValue returns value as an inline TOML value.
+func tomlValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		if strings.Contains(v, "\n") && !strings.Contains(v, `"""`) {
+			escaped := strings.ReplaceAll(v, `\`, `\\`)
+			return "\"\"\"\n" + escaped + `"""`, nil
+		}
+		return tomlString(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case json.Number:
+		return v.String(), nil
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			s, err := tomlValue(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = s
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case orderedMap:
+		fields := make([]string, len(v))
+		for i, field := range v {
+			s, err := tomlValue(field.Value)
+			if err != nil {
+				return "", err
+			}
+			fields[i] = tomlKey(field.Key) + " = " + s
+		}
+		if len(fields) == 0 {
+			return "{}", nil
+		}
+		return "{ " + strings.Join(fields, ", ") + " }", nil
+	}
+	return "", fmt.Errorf("unsupported value %v", value)
+}
+
+// tomlKey returns key bare when it only holds bare key characters, else quoted.
+func tomlKey(key string) string {
+	for i := 0; i < len(key); i++ {
+		if !isBareKeyChar(key[i]) {
+			return tomlString(key)
+		}
+	}
+	if key == "" {
+		return `""`
+	}
+	return key
+}
+
+// tomlString returns s as a TOML basic string.
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
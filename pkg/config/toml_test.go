@@ -0,0 +1,159 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTOML(t *testing.T) {
+	content := `version = 1
+projectName = "Orders"
+"quoted key" = 'literal \n'
+dotted.key = true
+numbers = [1, 2_000, 1.5]
+lines = [
+  "a",
+  "b",
+]
+inline = { a = "b", c = [] }
+
+[hooks]
+postAdd = "npm run lint"
+
+[environments.sandbox]
+account = "1234567_SB1"
+
+[[items]]
+name = "first"
+
+[[items]]
+name = "second"
+`
+	got, err := parseTOML(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"version":      int64(1),
+		"projectName":  "Orders",
+		"quoted key":   `literal \n`,
+		"dotted":       map[string]any{"key": true},
+		"numbers":      []any{int64(1), int64(2000), 1.5},
+		"lines":        []any{"a", "b"},
+		"inline":       map[string]any{"a": "b", "c": []any{}},
+		"hooks":        map[string]any{"postAdd": "npm run lint"},
+		"environments": map[string]any{"sandbox": map[string]any{"account": "1234567_SB1"}},
+		"items": []any{
+			map[string]any{"name": "first"},
+			map[string]any{"name": "second"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseTOML =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+func TestParseTOMLStrings(t *testing.T) {
+	tests := map[string]string{
+		`"tab\there"`:                      "tab\there",
+		`"quote \" inside"`:                `quote " inside`,
+		`"\u00e9"`:                         "é",
+		`"x # y"`:                          "x # y",
+		`'C:\path'`:                        `C:\path`,
+		"\"\"\"\nline one\nline two\"\"\"": "line one\nline two",
+		"'''\nraw \\n\n'''":                "raw \\n\n",
+		"\"\"\"\nfolded \\\n  text\"\"\"":  "folded text",
+	}
+	for value, want := range tests {
+		got, err := parseTOML("key = " + value + "\n")
+		if err != nil {
+			t.Errorf("%s: %v", value, err)
+			continue
+		}
+		if got := got.(map[string]any)["key"]; got != want {
+			t.Errorf("%s = %#v, want %#v", value, got, want)
+		}
+	}
+}
+
+func TestParseTOMLComments(t *testing.T) {
+	content := `# Orders project
+projectName = "Orders" # shown in deploy.xml
+
+[hooks] # lifecycle
+# run before every deploy
+preDeploy = "npm test"
+list = [
+  "a", # first
+  "b",
+]
+`
+	got, err := parseTOML(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"projectName": "Orders",
+		"hooks":       map[string]any{"preDeploy": "npm test", "list": []any{"a", "b"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseTOML =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+func TestParseTOMLErrors(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		line    string
+	}{
+		"no value":           {"a = 1\nkey =\n", "line 2:"},
+		"two values":         {"key = 1 2\n", "line 1:"},
+		"duplicate key":      {"a = 1\n\na = 2\n", "line 3:"},
+		"unterminated array": {"key = [\n  1,\n  2\n", "line 4:"},
+		"bad value":          {"# header\nkey = yes\n", "line 2:"},
+		"unclosed header":    {"a = 1\n[hooks\n", "line 2:"},
+		"unclosed string":    {"key = \"open\n", "line 1:"},
+		"not a table":        {"hooks = 1\n[hooks]\n", "line 2:"},
+	}
+	for name, test := range tests {
+		_, err := parseTOML(test.content)
+		if err == nil {
+			t.Errorf("%s: parseTOML(%q) did not fail", name, test.content)
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), test.line) {
+			t.Errorf("%s: error %q, want it at %s", name, err, strings.TrimSuffix(test.line, ":"))
+		}
+	}
+}
+
+func TestTOMLHasComments(t *testing.T) {
+	tests := map[string]bool{
+		"a = 1\n":                     false,
+		"# header\na = 1\n":           true,
+		"a = 1 # trailing\n":          true,
+		"[hooks] # table\n":           true,
+		"a = [\n  1, # item\n]\n":     true,
+		"a = \"x # y\"\n":             false,
+		"a = '''\n# in a string'''\n": false,
+	}
+	for content, want := range tests {
+		if got := tomlHasComments(content); got != want {
+			t.Errorf("tomlHasComments(%q) = %v, want %v", content, got, want)
+		}
+	}
+}
+
+func TestTOMLString(t *testing.T) {
+	for _, s := range []string{"", "plain", `back\slash`, `"quoted"`, "tab\t", "new\nline", "\x01"} {
+		got, err := parseTOML("key = " + tomlString(s) + "\n")
+		if err != nil {
+			t.Errorf("%q written as %s: %v", s, tomlString(s), err)
+			continue
+		}
+		if value := got.(map[string]any)["key"]; value != s {
+			t.Errorf("%q written as %s reads back as %#v", s, tomlString(s), value)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// User represents the global user configuration.
+type User struct {
+	CompanyName string `json:"companyName"`
+	UserName    string `json:"userName"`
+	UserEmail   string `json:"userEmail"`
+
+	// UpdateCheck enables the daily check for new releases of the CLI when "true".
+	UpdateCheck string `json:"updateCheck,omitempty"`
+	// Telemetry is "on" when the user opted in to anonymous usage telemetry.
+	Telemetry string `json:"telemetry,omitempty"`
+
+	Accounts []AccountProfile `json:"accounts,omitempty"`
+
+	// Template variables used in every project, see Project.Vars.
+	Vars map[string]string `json:"vars,omitempty"`
+}
+
+// TemplateVars returns the template variables of a project, with the values of the user
+// configuration, which may be nil, used for the variables the project leaves empty or does not
+// declare. Variables that are still empty have to be given a value when a script is generated.
+func TemplateVars(user *User, project *Project) map[string]string {
+	vars := make(map[string]string)
+	if user != nil {
+		for name, value := range user.Vars {
+			vars[name] = value
+		}
+	}
+	for name, value := range project.Vars {
+		if value != "" || vars[name] == "" {
+			vars[name] = value
+		}
+	}
+	return vars
+}
+
+// AccountProfile represents a NetSuite account the user works with.
+type AccountProfile struct {
+	Label     string `json:"label"`
+	AccountID string `json:"accountId"`
+	AuthID    string `json:"authId"`
+	Default   bool   `json:"default,omitempty"`
+
+	// OAuth 2.0 machine-to-machine credentials used by the REST based commands.
+	ClientID       string `json:"clientId,omitempty"`
+	CertificateID  string `json:"certificateId,omitempty"`
+	PrivateKeyPath string `json:"privateKeyPath,omitempty"`
+}
+
+// FindAccount returns the account profile with the given label, or nil if none matches.
+func (c *User) FindAccount(label string) *AccountProfile {
+	for i := range c.Accounts {
+		if strings.EqualFold(c.Accounts[i].Label, label) {
+			return &c.Accounts[i]
+		}
+	}
+	return nil
+}
+
+// DefaultAccount returns the account profile marked as default, or nil if none is.
+func (c *User) DefaultAccount() *AccountProfile {
+	for i := range c.Accounts {
+		if c.Accounts[i].Default {
+			return &c.Accounts[i]
+		}
+	}
+	return nil
+}
+
+// userConfigPath returns the path of the user configuration file in the home directory, in any
+// of the FileExtensions formats, or the path of a new JSON file when there is none.
+func userConfigPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error getting home directory: %v", err)
+	}
+	path, err := findFile(homeDir, FileName)
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		path = filepath.Join(homeDir, FileName)
+	}
+	return path, nil
+}
+
+// LoadUser reads the user configuration from the .netsuite-cli file in the user's home
+// directory. It returns nil when the file does not exist.
+func LoadUser() (*User, error) {
+	configPath, err := userConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var config User
+	if err := readFile(configPath, &config); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", filepath.Base(configPath), err)
+	}
+	return &config, nil
+}
+
+// SaveUser writes the user configuration to the .netsuite-cli file in the user's home directory.
+func SaveUser(config *User) error {
+	configPath, err := userConfigPath()
+	if err != nil {
+		return err
+	}
+
+	return writeFile(configPath, config, false)
+}
+
+// UserDir returns the directory holding user-level CLI data such as template overrides.
+func UserDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("error getting user config directory: %v", err)
+	}
+	return filepath.Join(configDir, "netsuite-cli"), nil
+}
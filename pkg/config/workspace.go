@@ -0,0 +1,116 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WorkspaceFileName is the file at the root of a repository listing the SDF projects it holds.
+const WorkspaceFileName = ".netsuite-cli.workspace"
+
+// ErrNoWorkspace is returned by FindWorkspace when no directory holds a workspace file.
+var ErrNoWorkspace = errors.New(".netsuite-cli.workspace file not found")
+
+// Workspace represents a repository holding several projects.
+type Workspace struct {
+	Projects []WorkspaceProject `json:"projects"`
+	Lib      *WorkspaceLib      `json:"lib,omitempty"`
+}
+
+// WorkspaceProject is a project of a workspace. Path is relative to the workspace root; Name
+// defaults to the last element of Path.
+type WorkspaceProject struct {
+	Name string `json:"name,omitempty"`
+	Path string `json:"path"`
+}
+
+// WorkspaceLib is a folder of TypeScript code shared by the projects of a workspace, copied into
+// the SuiteScripts folder of each project before it is compiled. Path is relative to the
+// workspace root; Folder is the folder under SuiteScripts holding the copies and defaults to
+// the last element of Path.
+type WorkspaceLib struct {
+	Path   string `json:"path"`
+	Folder string `json:"folder,omitempty"`
+}
+
+// Project returns the project with the given name or path, or nil if none matches.
+func (w *Workspace) Project(name string) *WorkspaceProject {
+	for i := range w.Projects {
+		project := &w.Projects[i]
+		if project.Name == name || filepath.Clean(project.Path) == filepath.Clean(name) {
+			return project
+		}
+	}
+	return nil
+}
+
+// Names returns the names of the projects in the order they are listed.
+func (w *Workspace) Names() []string {
+	names := make([]string, len(w.Projects))
+	for i, project := range w.Projects {
+		names[i] = project.Name
+	}
+	return names
+}
+
+// FindWorkspace walks up from dir to the nearest directory holding a .netsuite-cli.workspace file.
+func FindWorkspace(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("error resolving %s: %v", dir, err)
+	}
+	for ; ; dir = filepath.Dir(dir) {
+		if info, err := os.Stat(filepath.Join(dir, WorkspaceFileName)); err == nil && !info.IsDir() {
+			return dir, nil
+		}
+		if filepath.Dir(dir) == dir {
+			return "", ErrNoWorkspace
+		}
+	}
+}
+
+// LoadWorkspace reads the workspace file of the workspace root. Project names default to the
+// last element of their path and must be unique.
+func LoadWorkspace(root string) (*Workspace, error) {
+	data, err := os.ReadFile(filepath.Join(root, WorkspaceFileName))
+	if err != nil {
+		return nil, fmt.Errorf("error reading workspace file: %v", err)
+	}
+
+	var workspace Workspace
+	if err := json.Unmarshal(data, &workspace); err != nil {
+		return nil, fmt.Errorf("error parsing workspace file: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for i := range workspace.Projects {
+		project := &workspace.Projects[i]
+		project.Path = filepath.FromSlash(strings.TrimSpace(project.Path))
+		if project.Path == "" || filepath.IsAbs(project.Path) {
+			return nil, fmt.Errorf("workspace project %d must have a path relative to the workspace root", i+1)
+		}
+		if project.Name == "" {
+			project.Name = filepath.Base(project.Path)
+		}
+		if seen[project.Name] {
+			return nil, fmt.Errorf("workspace project '%s' is listed twice", project.Name)
+		}
+		seen[project.Name] = true
+	}
+
+	if lib := workspace.Lib; lib != nil {
+		lib.Path = filepath.FromSlash(strings.TrimSpace(lib.Path))
+		if lib.Path == "" || filepath.IsAbs(lib.Path) {
+			return nil, errors.New("the workspace lib must have a path relative to the workspace root")
+		}
+		lib.Folder = strings.Trim(filepath.ToSlash(strings.TrimSpace(lib.Folder)), "/")
+		if lib.Folder == "" {
+			lib.Folder = filepath.Base(lib.Path)
+		}
+	}
+	return &workspace, nil
+}
@@ -0,0 +1,425 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseYAML parses the subset of YAML used by configuration files: nested mappings, lists,
+// plain, quoted and block scalars (| and >), and comments. Scalars are returned as strings and
+// converted to the type of their setting afterwards, except null and ~.
+func parseYAML(content string) (any, error) {
+	return newYAMLParser(content).parse()
+}
+
+// yamlHasComments reports whether YAML content has comments, which are left out when the
+// file is written again.
+func yamlHasComments(content string) bool {
+	p := newYAMLParser(content)
+	p.parse()
+	return p.comments
+}
+
+type yamlParser struct {
+	lines    []string
+	pos      int
+	comments bool // a comment was skipped
+}
+
+func newYAMLParser(content string) *yamlParser {
+	return &yamlParser{lines: strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")}
+}
+
+func (p *yamlParser) parse() (any, error) {
+	if p.next() && strings.TrimSpace(p.lines[p.pos]) == "---" {
+		p.pos++
+	}
+	if !p.next() {
+		return nil, nil
+	}
+	value, err := p.block(p.indent())
+	if err != nil {
+		return nil, err
+	}
+	if p.next() {
+		return nil, p.errorf("unexpected indentation")
+	}
+	return value, nil
+}
+
+// next skips blank and comment lines and reports whether a line is left.
+func (p *yamlParser) next() bool {
+	for ; p.pos < len(p.lines); p.pos++ {
+		line := strings.TrimSpace(p.lines[p.pos])
+		if strings.HasPrefix(line, "#") {
+			p.comments = true
+		} else if line != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// indent returns the indentation of the current line.
+func (p *yamlParser) indent() int {
+	line := p.lines[p.pos]
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func (p *yamlParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("line %d: %s", p.pos+1, fmt.Sprintf(format, args...))
+}
+
+// tabIndented reports whether the indentation of the current line holds a tab, which YAML
+// does not allow.
+func (p *yamlParser) tabIndented() bool {
+	return strings.HasPrefix(p.lines[p.pos][p.indent():], "\t")
+}
+
+// isListItem reports whether the current line is a list item.
+func (p *yamlParser) isListItem() bool {
+	line := strings.TrimSpace(p.lines[p.pos])
+	return line == "-" || strings.HasPrefix(line, "- ")
+}
+
+// block parses the mapping or list starting at the current line, indented by indent.
+func (p *yamlParser) block(indent int) (any, error) {
+	if p.isListItem() {
+		return p.list(indent)
+	}
+	return p.mapping(indent)
+}
+
+// mapping parses the key: value lines indented by indent.
+func (p *yamlParser) mapping(indent int) (any, error) {
+	result := make(map[string]any)
+	for p.next() && p.indent() == indent && !p.isListItem() {
+		if p.tabIndented() {
+			return nil, p.errorf("tabs are not allowed for indentation")
+		}
+		line := strings.TrimSpace(p.lines[p.pos])
+		key, rest, err := splitYAMLKey(line)
+		if err != nil {
+			return nil, p.errorf("%v", err)
+		}
+		if _, ok := result[key]; ok {
+			return nil, p.errorf("duplicate key '%s'", key)
+		}
+		p.pos++
+
+		rest = p.stripComment(rest)
+		switch {
+		case rest == "":
+			if p.next() && (p.indent() > indent || p.indent() == indent && p.isListItem()) {
+				value, err := p.block(p.indent())
+				if err != nil {
+					return nil, err
+				}
+				result[key] = value
+			} else {
+				result[key] = nil
+			}
+		case strings.HasPrefix(rest, "|") || strings.HasPrefix(rest, ">"):
+			result[key] = p.blockScalar(rest, indent)
+		default:
+			value, err := parseYAMLScalar(rest)
+			if err != nil {
+				p.pos-- // report the line of the key, which is already consumed
+				return nil, p.errorf("%v", err)
+			}
+			result[key] = value
+		}
+	}
+	if p.next() && p.indent() > indent {
+		return nil, p.errorf("unexpected indentation")
+	}
+	return result, nil
+}
+
+// list parses the list items indented by indent. An item holding key: value lines is a mapping
+// indented by the column of its first key.
+func (p *yamlParser) list(indent int) (any, error) {
+	result := []any{}
+	for p.next() && p.indent() == indent && p.isListItem() {
+		if p.tabIndented() {
+			return nil, p.errorf("tabs are not allowed for indentation")
+		}
+		line := p.lines[p.pos]
+		rest := strings.TrimSpace(line[indent+1:])
+		if rest == "" {
+			p.pos++
+			if p.next() && p.indent() > indent {
+				value, err := p.block(p.indent())
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, value)
+			} else {
+				result = append(result, nil)
+			}
+			continue
+		}
+		column := indent + 1 + len(line[indent+1:]) - len(strings.TrimLeft(line[indent+1:], " "))
+		if _, _, err := splitYAMLKey(rest); err == nil && !strings.HasPrefix(rest, `"`) && !strings.HasPrefix(rest, "'") {
+			p.lines[p.pos] = strings.Repeat(" ", column) + rest
+			value, err := p.mapping(column)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+			continue
+		}
+		value, err := parseYAMLScalar(p.stripComment(rest))
+		if err != nil {
+			return nil, p.errorf("%v", err)
+		}
+		result = append(result, value)
+		p.pos++
+	}
+	return result, nil
+}
+
+// blockScalar reads the lines of a | or > block scalar of a key indented by indent.
+func (p *yamlParser) blockScalar(header string, indent int) string {
+	var lines []string
+	blockIndent := -1
+	for ; p.pos < len(p.lines); p.pos++ {
+		line := p.lines[p.pos]
+		if strings.TrimSpace(line) == "" {
+			lines = append(lines, "")
+			continue
+		}
+		lineIndent := len(line) - len(strings.TrimLeft(line, " "))
+		if lineIndent <= indent {
+			break
+		}
+		if blockIndent < 0 {
+			blockIndent = lineIndent
+		}
+		if lineIndent < blockIndent {
+			break
+		}
+		lines = append(lines, line[blockIndent:])
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	var text string
+	if strings.HasPrefix(header, ">") {
+		var b strings.Builder
+		for i, line := range lines {
+			switch {
+			case i == 0:
+			case line == "":
+				b.WriteString("\n")
+			case lines[i-1] == "":
+				// The empty line before already broke the paragraph.
+			default:
+				b.WriteString(" ")
+			}
+			b.WriteString(line)
+		}
+		text = b.String()
+	} else {
+		text = strings.Join(lines, "\n")
+	}
+	if !strings.Contains(header, "-") && text != "" {
+		text += "\n"
+	}
+	return text
+}
+
+// stripComment removes a trailing comment from a value, noting that the content has comments.
+func (p *yamlParser) stripComment(value string) string {
+	stripped := stripYAMLComment(value)
+	if stripped != strings.TrimSpace(value) {
+		p.comments = true
+	}
+	return stripped
+}
+
+// splitYAMLKey splits a key: value line into its key and the rest of the line.
+func splitYAMLKey(line string) (string, string, error) {
+	if strings.HasPrefix(line, `"`) || strings.HasPrefix(line, "'") {
+		end := closingQuote(line)
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated quoted key")
+		}
+		key, err := parseYAMLScalar(line[:end+1])
+		if err != nil {
+			return "", "", err
+		}
+		rest := strings.TrimLeft(line[end+1:], " ")
+		if !strings.HasPrefix(rest, ":") {
+			return "", "", fmt.Errorf("expected ':' after key")
+		}
+		return key.(string), strings.TrimSpace(rest[1:]), nil
+	}
+	for i := 0; i < len(line); i++ {
+		if line[i] == ':' && (i == len(line)-1 || line[i+1] == ' ') {
+			return strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:]), nil
+		}
+	}
+	return "", "", fmt.Errorf("expected key: value")
+}
+
+// closingQuote returns the index of the quote closing the quoted string at the start of s, or
+// -1 when it is not closed.
+func closingQuote(s string) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case quote == '\'' && s[i] == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// stripYAMLComment removes a trailing comment from a value.
+func stripYAMLComment(value string) string {
+	start := 0
+	if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'") {
+		if end := closingQuote(value); end >= 0 {
+			start = end + 1
+		}
+	}
+	if i := strings.Index(value[start:], " #"); i >= 0 {
+		value = value[:start+i]
+	}
+	if strings.HasPrefix(value, "#") {
+		return ""
+	}
+	return strings.TrimSpace(value)
+}
+
+// parseYAMLScalar parses a single line value: a quoted or plain string, null, or a flow list
+// of scalars such as [a, b].
+func parseYAMLScalar(value string) (any, error) {
+	switch {
+	case value == "~" || value == "null":
+		return nil, nil
+	case value == "{}":
+		return map[string]any{}, nil
+	case strings.HasPrefix(value, "["):
+		if !strings.HasSuffix(value, "]") {
+			return nil, fmt.Errorf("unterminated list %s", value)
+		}
+		items := []any{}
+		inner := strings.TrimSpace(value[1 : len(value)-1])
+		if inner == "" {
+			return items, nil
+		}
+		for _, item := range strings.Split(inner, ",") {
+			parsed, err := parseYAMLScalar(strings.TrimSpace(item))
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, parsed)
+		}
+		return items, nil
+	case strings.HasPrefix(value, `"`):
+		if closingQuote(value) != len(value)-1 {
+			return nil, fmt.Errorf("invalid quoted string %s", value)
+		}
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quoted string %s", value)
+		}
+		return unquoted, nil
+	case strings.HasPrefix(value, "'"):
+		if closingQuote(value) != len(value)-1 {
+			return nil, fmt.Errorf("invalid quoted string %s", value)
+		}
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'"), nil
+	}
+	return value, nil
+}
+
+// formatYAML writes a configuration as YAML. Multi-line strings are written as | blocks.
+func formatYAML(tree orderedMap) []byte {
+	var b strings.Builder
+	writeYAMLMap(&b, tree, 0)
+	return []byte(b.String())
+}
+
+func writeYAMLMap(b *strings.Builder, tree orderedMap, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for _, field := range tree {
+		b.WriteString(pad + yamlString(field.Key) + ":")
+		writeYAMLValue(b, field.Value, indent)
+	}
+}
+
+// writeYAMLValue writes the value of a key or list item, starting on the line of the key.
+func writeYAMLValue(b *strings.Builder, value any, indent int) {
+	switch v := value.(type) {
+	case orderedMap:
+		if len(v) == 0 {
+			b.WriteString(" {}\n")
+			return
+		}
+		b.WriteString("\n")
+		writeYAMLMap(b, v, indent+2)
+	case []any:
+		if len(v) == 0 {
+			b.WriteString(" []\n")
+			return
+		}
+		b.WriteString("\n")
+		pad := strings.Repeat(" ", indent+2)
+		for _, item := range v {
+			if tree, ok := item.(orderedMap); ok && len(tree) > 0 {
+				// The first key goes on the line of the dash, the others align with it.
+				var item strings.Builder
+				writeYAMLMap(&item, tree, indent+4)
+				b.WriteString(pad + "- " + strings.TrimLeft(item.String(), " "))
+				continue
+			}
+			b.WriteString(pad + "-")
+			writeYAMLValue(b, item, indent+2)
+		}
+	case string:
+		if strings.Contains(v, "\n") {
+			header := "|"
+			if !strings.HasSuffix(v, "\n") {
+				header = "|-"
+			}
+			b.WriteString(" " + header + "\n")
+			pad := strings.Repeat(" ", indent+2)
+			for _, line := range strings.Split(strings.TrimSuffix(v, "\n"), "\n") {
+				if line == "" {
+					b.WriteString("\n")
+				} else {
+					b.WriteString(pad + line + "\n")
+				}
+			}
+			return
+		}
+		b.WriteString(" " + yamlString(v) + "\n")
+	case bool:
+		b.WriteString(" " + strconv.FormatBool(v) + "\n")
+	case json.Number:
+		b.WriteString(" " + v.String() + "\n")
+	default:
+		b.WriteString(" " + fmt.Sprint(v) + "\n")
+	}
+}
+
+// yamlString returns s as a plain scalar, or double quoted when it would be read differently
+// as a plain scalar.
+func yamlString(s string) string {
+	if s == "" || s != strings.TrimSpace(s) || s == "~" || s == "null" ||
+		strings.ContainsAny(s[:1], `"'#&*!|>%@[]{},?:-`+"`") ||
+		strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseYAMLNested(t *testing.T) {
+	content := `---
+projectName: Orders
+hooks:
+  postAdd: npm run lint
+  preDeploy: npm test
+"quoted key": 1
+environments:
+  sandbox:
+    account: 1234567_SB1
+list:
+  - a
+  - name: b
+    value: c
+  -
+    name: d
+inline: [a, "b c", 'd']
+empty: []
+none: {}
+`
+	got, err := parseYAML(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"projectName":  "Orders",
+		"hooks":        map[string]any{"postAdd": "npm run lint", "preDeploy": "npm test"},
+		"quoted key":   "1",
+		"environments": map[string]any{"sandbox": map[string]any{"account": "1234567_SB1"}},
+		"list": []any{
+			"a",
+			map[string]any{"name": "b", "value": "c"},
+			map[string]any{"name": "d"},
+		},
+		"inline": []any{"a", "b c", "d"},
+		"empty":  []any{},
+		"none":   map[string]any{},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseYAML =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+func TestParseYAMLScalars(t *testing.T) {
+	tests := map[string]any{
+		`plain text`:           "plain text",
+		`a#b`:                  "a#b",
+		`value # comment`:      "value",
+		`"x # y"`:              "x # y",
+		`"tab\there"`:          "tab\there",
+		`"quote \" inside"`:    `quote " inside`,
+		`'it''s'`:              "it's",
+		`'a: b' # comment`:     "a: b",
+		`2.1`:                  "2.1",
+		`~`:                    nil,
+		`null`:                 nil,
+		`[a, "b", 'c']`:        []any{"a", "b", "c"},
+		`[]`:                   []any{},
+		`{}`:                   map[string]any{},
+		`"" # empty`:           "",
+		`true`:                 "true",
+		`customscript_x_{{.}}`: "customscript_x_{{.}}",
+	}
+	for value, want := range tests {
+		got, err := parseYAML("key: " + value + "\n")
+		if err != nil {
+			t.Errorf("%s: %v", value, err)
+			continue
+		}
+		if got := got.(map[string]any)["key"]; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s = %#v, want %#v", value, got, want)
+		}
+	}
+}
+
+func TestParseYAMLBlockScalars(t *testing.T) {
+	content := `keep: |
+  line one
+
+  line three
+strip: |-
+  line one
+  line two
+fold: >
+  folded
+  text
+
+  new paragraph
+foldStrip: >-
+  folded
+  text
+hash: |
+  # not a comment
+next: value
+`
+	got, err := parseYAML(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"keep":      "line one\n\nline three\n",
+		"strip":     "line one\nline two",
+		"fold":      "folded text\nnew paragraph\n",
+		"foldStrip": "folded text",
+		"hash":      "# not a comment\n",
+		"next":      "value",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseYAML =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+func TestParseYAMLComments(t *testing.T) {
+	content := `# Orders project
+projectName: Orders # shown in deploy.xml
+
+hooks:
+  # run before every deploy
+  preDeploy: npm test
+list:
+  - a # first
+  # between items
+  - b
+`
+	got, err := parseYAML(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"projectName": "Orders",
+		"hooks":       map[string]any{"preDeploy": "npm test"},
+		"list":        []any{"a", "b"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseYAML =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		line    string
+	}{
+		"tab indentation": {"hooks:\n\tpostAdd: x\n", "line 2:"},
+		"duplicate key":   {"a: 1\nb: 2\na: 3\n", "line 3:"},
+		"no key":          {"a: 1\njust text\n", "line 2:"},
+		"bad indentation": {"a:\n    b: 1\n  c: 2\n", "line 3:"},
+		"unclosed quote":  {"# header\na: \"open\n", "line 2:"},
+		"unclosed list":   {"a: [1, 2\n", "line 1:"},
+	}
+	for name, test := range tests {
+		_, err := parseYAML(test.content)
+		if err == nil {
+			t.Errorf("%s: parseYAML(%q) did not fail", name, test.content)
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), test.line) {
+			t.Errorf("%s: error %q, want it at %s", name, err, strings.TrimSuffix(test.line, ":"))
+		}
+	}
+}
+
+func TestYAMLHasComments(t *testing.T) {
+	tests := map[string]bool{
+		"a: b\n":                        false,
+		"# header\na: b\n":              true,
+		"a: b # trailing\n":             true,
+		"a:\n  # nested\n  b: c\n":      true,
+		"list:\n  - a # item\n":         true,
+		"a: \"x # y\"\n":                false,
+		"a: b#c\n":                      false,
+		"a: |\n  # block content\n":     false,
+		"a: |\n  text\n# after block\n": true,
+	}
+	for content, want := range tests {
+		if got := yamlHasComments(content); got != want {
+			t.Errorf("yamlHasComments(%q) = %v, want %v", content, got, want)
+		}
+	}
+}
+
+func TestYAMLString(t *testing.T) {
+	for _, s := range []string{"", "plain", " padded", "a: b", "x #y", "-x", "null", "~", "key:", "@lib", "[a]", "it's"} {
+		got, err := parseYAML("key: " + yamlString(s) + "\n")
+		if err != nil {
+			t.Errorf("%q written as %s: %v", s, yamlString(s), err)
+			continue
+		}
+		if value := got.(map[string]any)["key"]; value != s {
+			t.Errorf("%q written as %s reads back as %#v", s, yamlString(s), value)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package scaffold
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DeploymentAudience describes who can run the deployment of a suitelet, RESTlet or portlet.
+type DeploymentAudience struct {
+	Audience string   // one of AudienceTypes
+	Roles    []string // roles of the "roles" audience, as SDF role references
+}
+
+// AudienceTypes lists the supported deployment audiences.
+var AudienceTypes = []string{"allroles", "allemployees", "allpartners", "roles"}
+
+// defaultAudiences maps the script types whose deployments have an audience to the audience
+// generated when none is given.
+var defaultAudiences = map[string]string{
+	"portlet":  "allemployees",
+	"restlet":  "allroles",
+	"suitelet": "allemployees",
+}
+
+// standardRoleIds maps the internal IDs of standard roles to their SDF role names.
+var standardRoleIds = map[string]string{
+	"3": "ADMINISTRATOR",
+}
+
+// UsesAudience reports whether the deployment of a script type has an audience.
+func UsesAudience(scriptType string) bool {
+	_, ok := defaultAudiences[scriptType]
+	return ok
+}
+
+// DefaultAudience returns the audience generated for a script type when none is given.
+func DefaultAudience(scriptType string) string {
+	return defaultAudiences[scriptType]
+}
+
+// Flag returns T when the audience is the given one, F otherwise, for the allroles,
+// allemployees and allpartners deployment fields.
+func (a DeploymentAudience) Flag(audience string) string {
+	if a.Audience == audience {
+		return "T"
+	}
+	return "F"
+}
+
+// SelectedRoles returns the audslctrole value of the deployment: the roles separated by |.
+func (a DeploymentAudience) SelectedRoles() string {
+	if a.Audience != "roles" {
+		return ""
+	}
+	return strings.Join(a.Roles, "|")
+}
+
+// NewDeploymentAudience validates the audience options and returns a deployment audience.
+// Roles imply the "roles" audience. Roles are given as SDF role names (e.g. ADMINISTRATOR),
+// custom role script IDs, or internal IDs; custom roles are referenced by script ID and the
+// internal IDs of standard roles are replaced by their names.
+func NewDeploymentAudience(audience string, roles []string) (DeploymentAudience, error) {
+	audience = strings.ToLower(strings.TrimSpace(audience))
+	var references []string
+	for _, role := range roles {
+		if role = strings.TrimSpace(role); role != "" {
+			references = append(references, RoleReference(role))
+		}
+	}
+	if audience == "" && len(references) > 0 {
+		audience = "roles"
+	}
+	if audience == "" {
+		return DeploymentAudience{}, nil
+	}
+	if !slices.Contains(AudienceTypes, audience) {
+		return DeploymentAudience{}, fmt.Errorf("invalid audience '%s' (supported: %s)", audience, strings.Join(AudienceTypes, ", "))
+	}
+	if audience == "roles" && len(references) == 0 {
+		return DeploymentAudience{}, fmt.Errorf("the roles audience requires at least one role")
+	}
+	if audience != "roles" && len(references) > 0 {
+		return DeploymentAudience{}, fmt.Errorf("roles can only be given with the roles audience, not %s", audience)
+	}
+	return DeploymentAudience{Audience: audience, Roles: references}, nil
+}
+
+// RoleReference returns the SDF reference of a role: [scriptid=...] for custom roles, the role
+// name for standard roles given by name or known internal ID. Other internal IDs are returned
+// as is.
+func RoleReference(role string) string {
+	if strings.HasPrefix(strings.ToLower(role), "customrole") {
+		return "[scriptid=" + strings.ToLower(role) + "]"
+	}
+	if name, ok := standardRoleIds[role]; ok {
+		return name
+	}
+	return strings.ToUpper(role)
+}
@@ -0,0 +1,207 @@
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"netsuite-cli/pkg/config"
+)
+
+// CustomField describes a custom field to generate.
+type CustomField struct {
+	Kind             string // see CustomFieldKinds, e.g. body
+	Name             string // field name, e.g. approval_notes
+	Label            string // empty for the name
+	Description      string
+	Type             string // field type, see ParamTypes; empty for text
+	SelectRecordType string // record type listed by select fields, e.g. -2
+	SourceList       string // field holding the record the value is sourced from, e.g. STDENTITYCUSTOMER
+	SourceFrom       string // field of the source record the value is copied from, e.g. STDENTITYEMAIL
+	AppliesTo        []string
+}
+
+// CustomFieldKind describes an SDF custom field object type.
+type CustomFieldKind struct {
+	ObjectType string
+	Prefix     string
+	// AppliesTo maps the records a field of the kind can apply to, in order, to the element
+	// enabling them.
+	AppliesTo [][2]string
+}
+
+// AppliesToNames returns the records fields of the kind can apply to.
+func (k CustomFieldKind) AppliesToNames() []string {
+	names := make([]string, 0, len(k.AppliesTo))
+	for _, option := range k.AppliesTo {
+		names = append(names, option[0])
+	}
+	return names
+}
+
+// CustomFieldKinds maps the supported field kinds to their object type, script ID prefix and applies-to options.
+var CustomFieldKinds = map[string]CustomFieldKind{
+	"entity": {"entitycustomfield", "custentity", [][2]string{
+		{"contact", "appliestocontact"},
+		{"customer", "appliestocustomer"},
+		{"employee", "appliestoemployee"},
+		{"partner", "appliestopartner"},
+		{"vendor", "appliestovendor"},
+	}},
+	"body": {"transactionbodycustomfield", "custbody", [][2]string{
+		{"expensereport", "bodyexpensereport"},
+		{"itemfulfillment", "bodyitemfulfillment"},
+		{"itemreceipt", "bodyitemreceipt"},
+		{"journal", "bodyjournal"},
+		{"opportunity", "bodyopportunity"},
+		{"purchase", "bodypurchase"},
+		{"sale", "bodysale"},
+	}},
+	"column": {"transactioncolumncustomfield", "custcol", [][2]string{
+		{"expensereport", "colexpensereport"},
+		{"journal", "coljournal"},
+		{"opportunity", "colopportunity"},
+		{"purchase", "colpurchase"},
+		{"sale", "colsale"},
+	}},
+	"item": {"itemcustomfield", "custitem", [][2]string{
+		{"inventory", "appliestoinventory"},
+		{"noninventory", "appliestononinventory"},
+		{"othercharge", "appliestoothercharge"},
+		{"service", "appliestoservice"},
+	}},
+}
+
+// CustomFieldKindNames returns the supported field kinds in sorted order.
+func CustomFieldKindNames() []string {
+	names := make([]string, 0, len(CustomFieldKinds))
+	for name := range CustomFieldKinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveCustomFieldKind returns the field kind with the given name.
+func ResolveCustomFieldKind(name string) (CustomFieldKind, error) {
+	kind, ok := CustomFieldKinds[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return CustomFieldKind{}, fmt.Errorf("invalid field kind '%s' (supported: %s)", name, strings.Join(CustomFieldKindNames(), ", "))
+	}
+	return kind, nil
+}
+
+// CustomFieldAppliesTo describes an applies-to flag of a custom field.
+type CustomFieldAppliesTo struct {
+	Element string
+	Enabled bool
+}
+
+// CustomFieldData holds the data used to render the custom field template.
+type CustomFieldData struct {
+	ObjectType       string
+	ScriptId         string
+	Label            string
+	Description      string
+	FieldType        string
+	SelectRecordType string
+	SourceList       string
+	SourceFrom       string
+	AppliesTo        []CustomFieldAppliesTo
+}
+
+// ResolveAppliesTo validates the selected applies-to options for a field kind.
+func ResolveAppliesTo(kind CustomFieldKind, selected []string) ([]CustomFieldAppliesTo, error) {
+	names := kind.AppliesToNames()
+	enabled := make(map[string]bool)
+	for _, name := range selected {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(names, name) {
+			return nil, fmt.Errorf("invalid applies-to '%s' (supported: %s)", name, strings.Join(names, ", "))
+		}
+		enabled[name] = true
+	}
+	if len(enabled) == 0 {
+		return nil, fmt.Errorf("at least one applies-to record is required (supported: %s)", strings.Join(names, ", "))
+	}
+
+	result := make([]CustomFieldAppliesTo, 0, len(kind.AppliesTo))
+	for _, option := range kind.AppliesTo {
+		result = append(result, CustomFieldAppliesTo{Element: option[1], Enabled: enabled[option[0]]})
+	}
+	return result, nil
+}
+
+// FieldName returns the name of the field as used in its script ID, e.g. approval_notes for
+// custbody_approval_notes or Approval Notes.
+func (f CustomField) FieldName() string {
+	if kind, ok := CustomFieldKinds[strings.ToLower(strings.TrimSpace(f.Kind))]; ok {
+		return SnakeCase(strings.TrimPrefix(strings.TrimSpace(f.Name), kind.Prefix+"_"))
+	}
+	return SnakeCase(f.Name)
+}
+
+// ScriptId returns the script ID of the field, e.g. custbody_<prefix>_<name> for body fields.
+func (f CustomField) ScriptId(c *config.Project) (string, error) {
+	kind, err := ResolveCustomFieldKind(f.Kind)
+	if err != nil {
+		return "", err
+	}
+	return kind.Prefix + "_" + c.Prefix() + "_" + f.FieldName(), nil
+}
+
+// CustomField renders the object XML of a custom field.
+func (g *Generator) CustomField(f CustomField) (File, error) {
+	kind, err := ResolveCustomFieldKind(f.Kind)
+	if err != nil {
+		return File{}, err
+	}
+	name := f.FieldName()
+	if name == "" {
+		return File{}, errors.New("field name is required")
+	}
+
+	typeName := strings.ToLower(strings.TrimSpace(f.Type))
+	if typeName == "" {
+		typeName = "text"
+	}
+	fieldType, ok := ParamTypes[typeName]
+	if !ok {
+		return File{}, fmt.Errorf("unsupported field type '%s' (supported: %s)", typeName, strings.Join(ParamTypeNames(), ", "))
+	}
+	if typeName == "select" && f.SelectRecordType == "" {
+		return File{}, errors.New("select fields require a select record type")
+	}
+	if (f.SourceList == "") != (f.SourceFrom == "") {
+		return File{}, errors.New("sourcing requires both a source list and a source from field")
+	}
+	appliesTo, err := ResolveAppliesTo(kind, f.AppliesTo)
+	if err != nil {
+		return File{}, err
+	}
+
+	scriptId, err := f.ScriptId(g.Config)
+	if err != nil {
+		return File{}, err
+	}
+	data := CustomFieldData{
+		ObjectType:       kind.ObjectType,
+		ScriptId:         scriptId,
+		Label:            f.Label,
+		Description:      f.Description,
+		FieldType:        fieldType.FieldType,
+		SelectRecordType: f.SelectRecordType,
+		SourceList:       f.SourceList,
+		SourceFrom:       f.SourceFrom,
+		AppliesTo:        appliesTo,
+	}
+	if data.Label == "" {
+		data.Label = name
+	}
+	return g.object(kind.ObjectType, "customfield.xml.tmpl", scriptId, data)
+}
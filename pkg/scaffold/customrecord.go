@@ -0,0 +1,165 @@
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"netsuite-cli/pkg/config"
+)
+
+// CustomRecord describes a custom record type to generate.
+type CustomRecord struct {
+	Name        string // record name, e.g. shipping_label
+	Label       string // empty for the name
+	Description string // empty for "<name> description"
+	Fields      []CustomRecordField
+	Permissions []CustomRecordPermission
+	Sublists    []CustomRecordSublist
+}
+
+// ScriptId returns the script ID of the record type, customrecord_<prefix>_<name>.
+func (r CustomRecord) ScriptId(c *config.Project) (string, error) {
+	return c.ObjectId("customrecord", r.Name)
+}
+
+// CustomRecordField describes a field of a custom record type.
+type CustomRecordField struct {
+	Id               string
+	Label            string
+	FieldType        string
+	SelectRecordType string
+}
+
+// CustomRecordPermission describes the access level granted to a role on a custom record type.
+type CustomRecordPermission struct {
+	Role  string
+	Level string
+}
+
+// CustomRecordSublist describes a saved search shown as a sublist on a custom record type.
+type CustomRecordSublist struct {
+	Search string
+	Label  string
+}
+
+// CustomRecordData holds the data used to render the custom record type template.
+type CustomRecordData struct {
+	ScriptId    string
+	Label       string
+	Description string
+	AccessType  string
+	Fields      []CustomRecordField
+	Permissions []CustomRecordPermission
+	Sublists    []CustomRecordSublist
+}
+
+// CustomRecordPermissionLevels lists the valid permission levels for custom record types.
+var CustomRecordPermissionLevels = []string{"NONE", "VIEW", "CREATE", "EDIT", "FULL"}
+
+// ParseCustomRecordField parses a field specification in the form id:type[:label[:selectrecordtype]].
+func ParseCustomRecordField(spec, prefix string) (CustomRecordField, error) {
+	parts := strings.SplitN(spec, ":", 4)
+	for len(parts) < 4 {
+		parts = append(parts, "")
+	}
+	return NewCustomRecordField(parts[0], parts[1], parts[2], parts[3], prefix)
+}
+
+// NewCustomRecordField builds a custom record field from its id, type, label and select record type.
+func NewCustomRecordField(id, fieldType, label, selectRecordType, prefix string) (CustomRecordField, error) {
+	id = strings.TrimPrefix(SnakeCase(strings.TrimSpace(id)), "custrecord_")
+	if id == "" {
+		return CustomRecordField{}, fmt.Errorf("field id is required")
+	}
+
+	fieldType = strings.ToLower(strings.TrimSpace(fieldType))
+	if fieldType == "" {
+		fieldType = "text"
+	}
+	types, ok := ParamTypes[fieldType]
+	if !ok {
+		return CustomRecordField{}, fmt.Errorf("unsupported field type '%s' (supported: %s)", fieldType, strings.Join(ParamTypeNames(), ", "))
+	}
+
+	selectRecordType = strings.TrimSpace(selectRecordType)
+	if fieldType == "select" && selectRecordType == "" {
+		return CustomRecordField{}, fmt.Errorf("select field '%s' requires a select record type", id)
+	}
+
+	label = strings.TrimSpace(label)
+	if label == "" {
+		label = id
+	}
+
+	return CustomRecordField{
+		Id:               "custrecord_" + prefix + "_" + id,
+		Label:            label,
+		FieldType:        types.FieldType,
+		SelectRecordType: selectRecordType,
+	}, nil
+}
+
+// ParseCustomRecordPermission parses a permission specification in the form ROLE:LEVEL.
+func ParseCustomRecordPermission(spec string) (CustomRecordPermission, error) {
+	role, level, _ := strings.Cut(spec, ":")
+	role = strings.ToUpper(strings.TrimSpace(role))
+	level = strings.ToUpper(strings.TrimSpace(level))
+	if role == "" {
+		return CustomRecordPermission{}, fmt.Errorf("role is required")
+	}
+	if level == "" {
+		level = "FULL"
+	}
+	if !slices.Contains(CustomRecordPermissionLevels, level) {
+		return CustomRecordPermission{}, fmt.Errorf("invalid permission level '%s' (supported: %s)", level, strings.Join(CustomRecordPermissionLevels, ", "))
+	}
+	return CustomRecordPermission{Role: role, Level: level}, nil
+}
+
+// ParseCustomRecordSublist parses a sublist specification in the form customsearch_id[:label].
+func ParseCustomRecordSublist(spec string) (CustomRecordSublist, error) {
+	search, label, _ := strings.Cut(spec, ":")
+	search = strings.TrimSpace(search)
+	if search == "" {
+		return CustomRecordSublist{}, fmt.Errorf("saved search id is required")
+	}
+	label = strings.TrimSpace(label)
+	if label == "" {
+		label = search
+	}
+	return CustomRecordSublist{Search: search, Label: label}, nil
+}
+
+// CustomRecord renders the object XML of a custom record type. Records with permissions are
+// restricted to the roles listed, the others use the entry permissions of the record.
+func (g *Generator) CustomRecord(r CustomRecord) (File, error) {
+	if r.Name == "" {
+		return File{}, errors.New("custom record name is required")
+	}
+	scriptId, err := r.ScriptId(g.Config)
+	if err != nil {
+		return File{}, err
+	}
+
+	data := CustomRecordData{
+		ScriptId:    scriptId,
+		Label:       r.Label,
+		Description: r.Description,
+		AccessType:  "CUSTRECORDENTRYPERM",
+		Fields:      r.Fields,
+		Permissions: r.Permissions,
+		Sublists:    r.Sublists,
+	}
+	if data.Label == "" {
+		data.Label = r.Name
+	}
+	if data.Description == "" {
+		data.Description = r.Name + " description"
+	}
+	if len(r.Permissions) > 0 {
+		data.AccessType = "USEPERMISSIONLIST"
+	}
+	return g.object("customrecordtype", "customrecord.xml.tmpl", scriptId, data)
+}
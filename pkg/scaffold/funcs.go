@@ -0,0 +1,75 @@
+package scaffold
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"netsuite-cli/pkg/config"
+)
+
+// Funcs returns the functions available to the templates, in addition to the text/template
+// builtins:
+//
+//	upper, lower      change the case of a string
+//	camelcase         convert a string to camelCase, e.g. order_page to orderPage
+//	snakecase         convert a string to snake_case, e.g. OrderPage to order_page
+//	now [layout]      the current time in a Go time layout, the project date format by default
+//	uuid              a random version 4 UUID
+//	default d value   value, or d when value is empty, e.g. {{.Vars.Ticket | default "none"}}
+//	xml               escape a string for XML text, e.g. <description>{{xml .Description}}</description>
+//
+// now formats the time with the layout, time zone and locale of dates.
+func Funcs(dates config.Dates) template.FuncMap {
+	return template.FuncMap{
+		"upper":     strings.ToUpper,
+		"lower":     strings.ToLower,
+		"camelcase": func(s string) string { return CamelCase(SnakeCase(s)) },
+		"snakecase": SnakeCase,
+		"now": func(layout ...string) string {
+			format := dates
+			if len(layout) > 0 {
+				format.Layout = layout[0]
+			}
+			return format.Format(time.Now())
+		},
+		"uuid":    newUUID,
+		"default": defaultValue,
+		"xml":     EscapeXML,
+	}
+}
+
+// EscapeXML escapes the characters of s that cannot appear as such in XML text or attributes.
+func EscapeXML(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// newUUID returns a random version 4 UUID.
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("error generating uuid: %v", err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// defaultValue returns the given value, or def when it is missing or the zero value of its type.
+// The value comes last so the function can end a pipeline.
+func defaultValue(def any, given ...any) any {
+	if len(given) == 0 || given[0] == nil {
+		return def
+	}
+	if v := reflect.ValueOf(given[0]); v.IsZero() || (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.Len() == 0 {
+		return def
+	}
+	return given[0]
+}
@@ -0,0 +1,250 @@
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"netsuite-cli/pkg/config"
+)
+
+// File is a file rendered by the Generator.
+type File struct {
+	Path    string
+	Content []byte
+	// Deploy is the path to list in deploy.xml for the file, empty for files that are not
+	// deployed. Scripts are deployed with the path of their compiled .js file.
+	Deploy string
+}
+
+// Script describes a script to generate.
+type Script struct {
+	Type             string
+	Name             string
+	Description      string
+	RecordType       string // record type of userevent, workflowaction and massupdate scripts, e.g. CUSTOMER
+	SearchId         string // script ID of the saved search providing the default filter of massupdate scripts
+	ReturnType       string // return type of workflowaction scripts, see ReturnTypes; empty for none
+	ReturnRecordType string // record type returned by workflowaction scripts with a select return type, e.g. -2
+	Folder           string // folder under SuiteScripts using '/' separators, empty for the root
+	Params           []ScriptParam
+	EntryPoints      []string // empty for every entry point of the script type
+	TypedStages      bool
+	Schedule         DeploymentSchedule
+	Audience         DeploymentAudience // audience of suitelet, restlet and portlet deployments, zero for the default
+	DeployStatus     string             // deployment status, see config.DeployStatuses; empty for the project default
+	LogLevel         string             // deployment log level, see config.LogLevels; empty for the project default
+	Variant          string             // empty for the default variant of the script type
+	Language         string             // config.LanguageTypeScript or config.LanguageJavaScript, empty for the project language
+	Vars             map[string]string  // template variables, nil for the variables of the project configuration
+	Test             bool               // also render a unit test stub into TestsDir, TypeScript scripts only
+}
+
+// Generator renders the files of new scripts for a project.
+type Generator struct {
+	Config    *config.Project
+	Templates Templates
+
+	// Project folders the files are generated in, relative to the project root or absolute.
+	SuiteScriptsDir string
+	ObjectsDir      string
+	TestsDir        string
+
+	// Override, when set, is called with the template data of each script before its files are
+	// rendered, e.g. to replace values in a preview.
+	Override func(data *TemplateData) error
+}
+
+// Script renders the TypeScript or JavaScript file of a script, its unit test stub when
+// requested and its object XML when the script type has one. The files are returned in that
+// order.
+func (g *Generator) Script(s Script) ([]File, error) {
+	if s.Name == "" {
+		return nil, errors.New("script name is required")
+	}
+	s.Type = ResolveScriptType(s.Type)
+	if !isScriptType(s.Type) {
+		return nil, fmt.Errorf("unknown script type '%s'", s.Type)
+	}
+
+	naming, err := g.Config.ScriptNaming(s.Name, s.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	entryPoints := s.EntryPoints
+	if _, ok := EntryPoints[s.Type]; ok {
+		if entryPoints, err = ResolveEntryPoints(s.Type, entryPoints); err != nil {
+			return nil, err
+		}
+	}
+	variant := s.Variant
+	if _, ok := Variants[s.Type]; ok {
+		if variant, err = ResolveVariant(s.Type, variant); err != nil {
+			return nil, err
+		}
+	}
+
+	returnType, err := ResolveReturnType(s.ReturnType)
+	if err != nil {
+		return nil, err
+	}
+	returnRecordType := ""
+	if returnType.FieldType == "SELECT" {
+		if returnRecordType = s.ReturnRecordType; returnRecordType == "" {
+			return nil, errors.New("the record type returned is required for select return types")
+		}
+	}
+
+	language := s.Language
+	if language == "" {
+		language = g.Config.ScriptLanguage()
+	}
+	if err := config.ValidateLanguage(language, g.Config.SuiteScriptVersion()); err != nil {
+		return nil, err
+	}
+	ext := "." + language
+
+	deployStatus, logLevel := s.DeployStatus, s.LogLevel
+	if deployStatus == "" {
+		deployStatus = g.Config.DeployStatus
+	}
+	if logLevel == "" {
+		logLevel = g.Config.LogLevel
+	}
+	deployStatus, logLevel = strings.ToUpper(deployStatus), strings.ToUpper(logLevel)
+	if deployStatus != "" {
+		if err := config.ValidateDeployStatus(deployStatus); err != nil {
+			return nil, err
+		}
+	}
+	if logLevel != "" {
+		if err := config.ValidateLogLevel(logLevel); err != nil {
+			return nil, err
+		}
+	}
+
+	audience := s.Audience
+	if audience.Audience == "" {
+		audience.Audience = DefaultAudience(s.Type)
+	}
+
+	now := time.Now()
+	data := TemplateData{
+		Project:      g.Config.ProjectName,
+		ProjectName:  g.Config.ProjectName,
+		Description:  s.Description,
+		Date:         g.Config.Dates().Format(now),
+		StartDate:    config.Dates{Layout: config.DefaultDateFormat, TimeZone: g.Config.TimeZone}.Format(now),
+		CompanyName:  g.Config.CompanyName,
+		UserName:     g.Config.UserName,
+		UserEmail:    g.Config.UserEmail,
+		ScriptName:   s.Name,
+		ScriptId:     naming.ScriptId,
+		ScriptPath:   path.Join("SuiteScripts", s.Folder, naming.FileName+ext),
+		DeploymentId: naming.DeploymentId,
+		RecordType:   s.RecordType,
+		SearchId:     s.SearchId,
+		Return:       returnType,
+		ReturnRecord: returnRecordType,
+		Params:       s.Params,
+		EntryPoints:  entryPoints,
+		TypedStages:  s.TypedStages,
+		Schedule:     s.Schedule,
+		Audience:     audience,
+		DeployStatus: deployStatus,
+		LogLevel:     logLevel,
+		Variant:      variant,
+		ApiVersion:   g.Config.SuiteScriptVersion(),
+		AmdConfig:    g.Config.AmdConfigPath(),
+		Locale:       g.Config.Locale,
+		Vars:         s.Vars,
+	}
+	if data.Vars == nil {
+		data.Vars = g.Config.Vars
+	}
+	if g.Override != nil {
+		if err := g.Override(&data); err != nil {
+			return nil, err
+		}
+	}
+
+	tsPath := filepath.Join(g.SuiteScriptsDir, filepath.FromSlash(s.Folder), naming.FileName+ext)
+	tsFile, err := g.render(tsPath, s.Type+ext+".tmpl", data)
+	if err != nil {
+		return nil, err
+	}
+	tsFile.Deploy = strings.TrimSuffix(tsPath, ext) + ".js"
+	files := []File{tsFile}
+
+	if s.Test && language == config.LanguageTypeScript {
+		modulePath := strings.TrimSuffix(tsPath, ".ts")
+		importPath, err := filepath.Rel(g.TestsDir, modulePath)
+		if err != nil {
+			return nil, err
+		}
+		testPath := filepath.Join(g.TestsDir, filepath.Base(modulePath)+".test.ts")
+		stub := TestStubData{TemplateData: data, ImportPath: filepath.ToSlash(importPath)}
+		testFile, err := g.render(testPath, "test.ts.tmpl", stub)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, testFile)
+	}
+
+	if objectType := ObjectType(s.Type); objectType != "" {
+		xmlPath := filepath.Join(g.ObjectsDir, g.Config.ProjectName, objectType, naming.ObjectFileName+".xml")
+		xmlFile, err := g.render(xmlPath, s.Type+".xml.tmpl", data)
+		if err != nil {
+			return nil, err
+		}
+		xmlFile.Deploy = xmlPath
+		files = append(files, xmlFile)
+	}
+
+	return files, nil
+}
+
+// render renders the named template into a file at path. TypeScript and JavaScript files get
+// the license header of the project.
+func (g *Generator) render(path, name string, data any) (File, error) {
+	templates := g.Templates
+	templates.Dates = g.Config.Dates()
+	tmplContent, err := templates.Read(name)
+	if err != nil {
+		return File{}, err
+	}
+	content, err := templates.Render(path, string(tmplContent), data)
+	if err != nil {
+		return File{}, err
+	}
+	if ext := filepath.Ext(path); ext == ".ts" || ext == ".js" {
+		content = ApplyHeader(content, LicenseHeader(g.Config, time.Now().Year()))
+	}
+	return File{Path: path, Content: content}, nil
+}
+
+// object renders the object XML of a custom object from the named template into the folder of
+// its object type.
+func (g *Generator) object(objectType, name, scriptId string, data any) (File, error) {
+	path := filepath.Join(g.ObjectsDir, g.Config.ProjectName, objectType, scriptId+".xml")
+	file, err := g.render(path, name, data)
+	if err != nil {
+		return File{}, err
+	}
+	file.Deploy = path
+	return file, nil
+}
+
+// isScriptType reports whether scriptType is one of ScriptTypes.
+func isScriptType(scriptType string) bool {
+	for _, t := range ScriptTypes {
+		if t.Name == scriptType {
+			return true
+		}
+	}
+	return false
+}
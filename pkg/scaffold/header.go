@@ -0,0 +1,64 @@
+package scaffold
+
+import (
+	"bytes"
+	"strings"
+
+	"netsuite-cli/pkg/config"
+)
+
+// LicenseHeader returns the license header comment of the project for the given current year,
+// or an empty string when the project configures no license header.
+func LicenseHeader(c *config.Project, year int) string {
+	if !c.HasLicenseHeader() {
+		return ""
+	}
+	license := strings.TrimSpace(c.License)
+	if license == "" {
+		license = "UNLICENSED"
+	}
+
+	lines := []string{"Copyright (c) " + c.Copyright(year)}
+	style := c.LicenseHeaderStyle()
+	if style == "text" || style == "both" {
+		switch strings.ToLower(license) {
+		case "unlicensed", "proprietary":
+			lines = append(lines, "All rights reserved.")
+		default:
+			lines = append(lines, "Licensed under the "+license+" license.")
+		}
+	}
+	if style == "spdx" || style == "both" {
+		lines = append(lines, "SPDX-License-Identifier: "+license)
+	}
+
+	var b strings.Builder
+	b.WriteString("/*\n")
+	for _, line := range lines {
+		b.WriteString(" * " + line + "\n")
+	}
+	b.WriteString(" */\n")
+	return b.String()
+}
+
+// ApplyHeader returns content with header at the top. A license header already at the top of
+// content, a /* */ comment mentioning a copyright or an SPDX tag, is replaced. JSDoc comments
+// (/** */) are never taken for a license header, since they hold the SuiteScript tags.
+func ApplyHeader(content []byte, header string) []byte {
+	if header == "" {
+		return content
+	}
+	rest := bytes.TrimLeft(content, "\ufeff \t\r\n")
+	if bytes.HasPrefix(rest, []byte("/*")) && !bytes.HasPrefix(rest, []byte("/**")) {
+		if end := bytes.Index(rest, []byte("*/")); end >= 0 {
+			comment := rest[:end]
+			if bytes.Contains(comment, []byte("Copyright")) || bytes.Contains(comment, []byte("SPDX-License-Identifier")) {
+				rest = bytes.TrimLeft(rest[end+2:], " \t\r\n")
+			}
+		}
+	}
+	result := make([]byte, 0, len(header)+1+len(rest))
+	result = append(result, header...)
+	result = append(result, '\n')
+	return append(result, rest...)
+}
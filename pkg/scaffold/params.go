@@ -0,0 +1,146 @@
+package scaffold
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ScriptParam describes a script parameter rendered into the object XML and the TypeScript accessor block.
+type ScriptParam struct {
+	Id        string
+	Key       string
+	Label     string
+	FieldType string
+	TSType    string
+	Default   string // default value, empty for none
+}
+
+// ParamType holds the SDF field type and TypeScript type of a parameter type.
+type ParamType struct {
+	FieldType string
+	TSType    string
+}
+
+// ParamTypes maps the supported parameter types to their SDF field type and TypeScript type.
+var ParamTypes = map[string]ParamType{
+	"text":     {"TEXT", "string"},
+	"textarea": {"TEXTAREA", "string"},
+	"email":    {"EMAIL", "string"},
+	"url":      {"URL", "string"},
+	"password": {"PASSWORD", "string"},
+	"select":   {"SELECT", "string"},
+	"integer":  {"INTEGER", "number"},
+	"float":    {"FLOAT", "number"},
+	"currency": {"CURRENCY", "number"},
+	"percent":  {"PERCENT", "number"},
+	"checkbox": {"CHECKBOX", "boolean"},
+	"date":     {"DATE", "Date"},
+}
+
+// ReturnType holds the SDF field type, TypeScript type and default return value of a workflow
+// action return type.
+type ReturnType struct {
+	FieldType string
+	TSType    string
+	Zero      string // TypeScript expression returned by the generated onAction handler
+}
+
+// ReturnTypes maps the supported return types of workflow action scripts to their SDF field type
+// and TypeScript type. Checkboxes are returned as "T" or "F", records as their internal ID.
+var ReturnTypes = map[string]ReturnType{
+	"text":     {"TEXT", "string", `""`},
+	"textarea": {"TEXTAREA", "string", `""`},
+	"email":    {"EMAIL", "string", `""`},
+	"url":      {"URL", "string", `""`},
+	"select":   {"SELECT", "string", `""`},
+	"integer":  {"INTEGER", "number", "0"},
+	"float":    {"FLOAT", "number", "0"},
+	"currency": {"CURRENCY", "number", "0"},
+	"percent":  {"PERCENT", "number", "0"},
+	"checkbox": {"CHECKBOX", `"T" | "F"`, `"F"`},
+	"date":     {"DATE", "Date", "new Date()"},
+}
+
+// ReturnTypeNames returns the supported return type names in sorted order.
+func ReturnTypeNames() []string {
+	names := make([]string, 0, len(ReturnTypes))
+	for name := range ReturnTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveReturnType validates a workflow action return type. An empty name or "none" means the
+// action returns nothing and resolves to the zero ReturnType.
+func ResolveReturnType(name string) (ReturnType, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || name == "none" {
+		return ReturnType{}, nil
+	}
+	returnType, ok := ReturnTypes[name]
+	if !ok {
+		return ReturnType{}, fmt.Errorf("unsupported return type '%s' (supported: none, %s)", name, strings.Join(ReturnTypeNames(), ", "))
+	}
+	return returnType, nil
+}
+
+// ParamTypeNames returns the supported parameter type names in sorted order.
+func ParamTypeNames() []string {
+	names := make([]string, 0, len(ParamTypes))
+	for name := range ParamTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewScriptParam builds a script parameter from its name, type and label.
+func NewScriptParam(name, paramType, label string) (ScriptParam, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ScriptParam{}, fmt.Errorf("parameter name is required")
+	}
+
+	paramType = strings.ToLower(strings.TrimSpace(paramType))
+	if paramType == "" {
+		paramType = "text"
+	}
+	types, ok := ParamTypes[paramType]
+	if !ok {
+		return ScriptParam{}, fmt.Errorf("unsupported parameter type '%s' (supported: %s)", paramType, strings.Join(ParamTypeNames(), ", "))
+	}
+
+	id := SnakeCase(name)
+	id = strings.TrimPrefix(id, "custscript_")
+	if id == "" {
+		return ScriptParam{}, fmt.Errorf("invalid parameter name '%s'", name)
+	}
+
+	label = strings.TrimSpace(label)
+	if label == "" {
+		label = name
+	}
+
+	return ScriptParam{
+		Id:        "custscript_" + id,
+		Key:       CamelCase(id),
+		Label:     label,
+		FieldType: types.FieldType,
+		TSType:    types.TSType,
+	}, nil
+}
+
+// ParseScriptParam parses a parameter specification in the form name:type[:label].
+func ParseScriptParam(spec string) (ScriptParam, error) {
+	parts := strings.SplitN(spec, ":", 3)
+	name, paramType, label := parts[0], "", ""
+	if len(parts) > 1 {
+		paramType = parts[1]
+	}
+	if len(parts) > 2 {
+		label = parts[2]
+	}
+	return NewScriptParam(name, paramType, label)
+}
@@ -0,0 +1,151 @@
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"netsuite-cli/pkg/config"
+)
+
+// SavedSearch describes a saved search to generate.
+type SavedSearch struct {
+	Name string // search name, e.g. open_orders
+	Spec SavedSearchSpec
+}
+
+// ScriptId returns the script ID of the search, customsearch_<prefix>_<name>.
+func (s SavedSearch) ScriptId(c *config.Project) (string, error) {
+	return c.ObjectId("customsearch", s.Name)
+}
+
+// SavedSearchColumn describes a result column of a saved search.
+type SavedSearchColumn struct {
+	Field   string `json:"field"`
+	Label   string `json:"label,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+// SavedSearchFilter describes a filter of a saved search.
+type SavedSearchFilter struct {
+	Field    string   `json:"field"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values,omitempty"`
+}
+
+// SavedSearchSpec describes a saved search, as read from a JSON spec file.
+type SavedSearchSpec struct {
+	RecordType string              `json:"recordType"`
+	Title      string              `json:"title,omitempty"`
+	Public     bool                `json:"public,omitempty"`
+	Columns    []SavedSearchColumn `json:"columns"`
+	Filters    []SavedSearchFilter `json:"filters,omitempty"`
+}
+
+// SavedSearchData holds the data used to render the saved search template.
+type SavedSearchData struct {
+	SavedSearchSpec
+	ScriptId string
+}
+
+// ParseSavedSearchColumn parses a column specification in the form field[:label[:summary]].
+func ParseSavedSearchColumn(spec string) (SavedSearchColumn, error) {
+	parts := strings.SplitN(spec, ":", 3)
+	for len(parts) < 3 {
+		parts = append(parts, "")
+	}
+	column := SavedSearchColumn{
+		Field:   strings.TrimSpace(parts[0]),
+		Label:   strings.TrimSpace(parts[1]),
+		Summary: strings.ToUpper(strings.TrimSpace(parts[2])),
+	}
+	if column.Field == "" {
+		return SavedSearchColumn{}, fmt.Errorf("column field is required")
+	}
+	return column, nil
+}
+
+// ParseSavedSearchFilter parses a filter specification in the form field:operator[:value,...].
+func ParseSavedSearchFilter(spec string) (SavedSearchFilter, error) {
+	parts := strings.SplitN(spec, ":", 3)
+	if len(parts) < 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+		return SavedSearchFilter{}, fmt.Errorf("expected field:operator[:value,...]")
+	}
+	filter := SavedSearchFilter{
+		Field:    strings.TrimSpace(parts[0]),
+		Operator: strings.ToUpper(strings.TrimSpace(parts[1])),
+	}
+	if len(parts) == 3 {
+		for _, value := range strings.Split(parts[2], ",") {
+			filter.Values = append(filter.Values, strings.TrimSpace(value))
+		}
+	}
+	return filter, nil
+}
+
+// transactionRecordTypes lists the standard record types searched with the Transaction search
+// type.
+var transactionRecordTypes = []string{
+	"ASSEMBLYBUILD", "ASSEMBLYUNBUILD", "CASHREFUND", "CASHSALE", "CHECK", "CREDITMEMO",
+	"CUSTOMERDEPOSIT", "CUSTOMERPAYMENT", "CUSTOMERREFUND", "DEPOSIT", "DEPOSITAPPLICATION",
+	"ESTIMATE", "EXPENSEREPORT", "INTERCOMPANYJOURNALENTRY", "INVENTORYADJUSTMENT",
+	"INVENTORYTRANSFER", "INVOICE", "ITEMFULFILLMENT", "ITEMRECEIPT", "JOURNALENTRY",
+	"OPPORTUNITY", "PURCHASEORDER", "PURCHASEREQUISITION", "RETURNAUTHORIZATION", "SALESORDER",
+	"STATISTICALJOURNALENTRY", "TRANSFERORDER", "VENDORBILL", "VENDORCREDIT", "VENDORPAYMENT",
+	"VENDORRETURNAUTHORIZATION", "WORKORDER", "WORKORDERCLOSE", "WORKORDERCOMPLETION",
+	"WORKORDERISSUE",
+}
+
+// searchTypes maps the other standard record types to their search type.
+var searchTypes = map[string]string{
+	"ACCOUNT": "Account", "CALENDAREVENT": "CalendarEvent", "CLASSIFICATION": "Classification",
+	"CONTACT": "Contact", "CUSTOMER": "Customer", "DEPARTMENT": "Department",
+	"EMPLOYEE": "Employee", "JOB": "Job", "LEAD": "Customer", "LOCATION": "Location",
+	"PARTNER": "Partner", "PHONECALL": "PhoneCall", "PROJECTTASK": "ProjectTask",
+	"PROSPECT": "Customer", "SUBSIDIARY": "Subsidiary", "SUPPORTCASE": "SupportCase",
+	"TASK": "Task", "TIMEBILL": "TimeBill", "VENDOR": "Vendor",
+}
+
+// SearchType returns the search type of the saved searches of a record type, e.g. Transaction
+// for SALESORDER, or an empty string when it is not known.
+func SearchType(recordType string) string {
+	recordType = strings.ToUpper(recordType)
+	switch {
+	case slices.Contains(transactionRecordTypes, recordType), strings.HasPrefix(recordType, "CUSTOMTRANSACTION"):
+		return "Transaction"
+	case strings.HasSuffix(recordType, "ITEM"), recordType == "ITEMGROUP":
+		return "Item"
+	}
+	return searchTypes[recordType]
+}
+
+// SavedSearch renders the object XML of a saved search. Searches without columns get an
+// internal ID column, and columns without a label are labeled with their field.
+func (g *Generator) SavedSearch(s SavedSearch) (File, error) {
+	if s.Name == "" {
+		return File{}, errors.New("saved search name is required")
+	}
+	if s.Spec.RecordType == "" {
+		return File{}, errors.New("record type is required for saved searches")
+	}
+	scriptId, err := s.ScriptId(g.Config)
+	if err != nil {
+		return File{}, err
+	}
+
+	data := SavedSearchData{SavedSearchSpec: s.Spec, ScriptId: scriptId}
+	if data.Title == "" {
+		data.Title = s.Name
+	}
+	data.Columns = slices.Clone(s.Spec.Columns)
+	if len(data.Columns) == 0 {
+		data.Columns = []SavedSearchColumn{{Field: "internalid", Label: "Internal ID"}}
+	}
+	for i := range data.Columns {
+		if data.Columns[i].Label == "" {
+			data.Columns[i].Label = data.Columns[i].Field
+		}
+	}
+	return g.object("savedsearch", "savedsearch.xml.tmpl", scriptId, data)
+}
@@ -0,0 +1,344 @@
+// Package scaffold renders the scripts, objects and tests netsuite-cli generates from its
+// templates. It returns the generated files instead of writing them and does not prompt or
+// print, so other Go tools can generate scripts the same way the add command does.
+package scaffold
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ScriptType describes a script type that can be generated.
+type ScriptType struct {
+	Name  string
+	Usage string
+}
+
+// ScriptTypes lists the script types that can be generated.
+var ScriptTypes = []ScriptType{
+	{"bundle", "Bundle installation scripts run during bundle installation, update, and uninstall, letting you set up or clean up account data"},
+	{"client", "Client scripts are executed by predefined event triggers in the client browser, enabling you to customize the user interface"},
+	{"formclient", "Form Client scripts are attached to forms, allowing you to add custom logic and functionality to form submissions"},
+	{"mapreduce", "Map/Reduce scripts are designed to handle large amounts of data, making them ideal for data processing and analysis tasks"},
+	{"massupdate", "Mass update scripts allow you to programmatically perform custom updates to fields that are not available through general mass updates"},
+	{"portlet", "Portlet scripts are run on the server and are rendered in the NetSuite dashboard, providing a way to customize the dashboard with custom functionality"},
+	{"restlet", "RESTlet is a SuiteScript that you make available for other applications to call, enabling integration with external services and systems"},
+	{"scheduled", "Scheduled scripts are executed (processed) with SuiteCloud Processors, allowing you to automate tasks and processes at specific times or intervals"},
+	{"sdfinstallation", "SDF installation scripts run when a SuiteApp project is deployed or updated, allowing you to migrate data between versions"},
+	{"suitelet", "Suitelets are extensions of the SuiteScript API that allow you to build custom NetSuite pages and backend logic"},
+	{"userevent", "User event scripts are executed when users perform actions on records, such as create, load, update, copy, delete, or submit, enabling you to automate tasks"},
+	{"workflowaction", "Workflow action scripts are good for custom logic or managing sublist fields which are not currently available"},
+	{"common", "Holds TypeScript definitions for your scripts, providing a way to define the structure and types of your code"},
+}
+
+// ScriptTypeAliases maps other names accepted for script types to the script type, e.g.
+// bundleinstallation, the name of the SDF object, to bundle.
+var ScriptTypeAliases = map[string]string{
+	"bundleinstallation": "bundle",
+}
+
+// ResolveScriptType returns the script type named by name, which may be an alias of it.
+func ResolveScriptType(name string) string {
+	if scriptType, ok := ScriptTypeAliases[name]; ok {
+		return scriptType
+	}
+	return name
+}
+
+// objectTypes maps the script types to the SDF object type of their script record.
+var objectTypes = map[string]string{
+	"bundle":          "bundleinstallationscript",
+	"client":          "clientscript",
+	"mapreduce":       "mapreducescript",
+	"massupdate":      "massupdatescript",
+	"portlet":         "portlet",
+	"restlet":         "restlet",
+	"scheduled":       "scheduledscript",
+	"sdfinstallation": "sdfinstallationscript",
+	"suitelet":        "suitelet",
+	"userevent":       "usereventscript",
+	"workflowaction":  "workflowactionscript",
+}
+
+// ObjectType maps a script type to the SDF object type of its script record. It returns an
+// empty string for script types generated without an object XML.
+func ObjectType(scriptType string) string {
+	return objectTypes[scriptType]
+}
+
+// ScriptTypeOf returns the script type whose script record has the given SDF object type, or an
+// empty string if there is none.
+func ScriptTypeOf(objectType string) string {
+	for scriptType, t := range objectTypes {
+		if t == objectType {
+			return scriptType
+		}
+	}
+	return ""
+}
+
+// scriptTypeTags maps the @NScriptType tags of SuiteScript files to the script types with a
+// script record. Form client scripts are tagged ClientScript too but have no script record.
+var scriptTypeTags = map[string]string{
+	"bundleinstallationscript": "bundle",
+	"clientscript":             "client",
+	"mapreducescript":          "mapreduce",
+	"massupdatescript":         "massupdate",
+	"portlet":                  "portlet",
+	"restlet":                  "restlet",
+	"scheduledscript":          "scheduled",
+	"sdfinstallationscript":    "sdfinstallation",
+	"suitelet":                 "suitelet",
+	"usereventscript":          "userevent",
+	"workflowactionscript":     "workflowaction",
+}
+
+// ScriptTypeOfTag returns the script type of an @NScriptType tag value, e.g. userevent for
+// UserEventScript, or an empty string if the tag has no script record.
+func ScriptTypeOfTag(tag string) string {
+	return scriptTypeTags[strings.ToLower(tag)]
+}
+
+// recordTypeScripts lists the script types whose deployment applies to a record type.
+var recordTypeScripts = []string{"massupdate", "userevent", "workflowaction"}
+
+// UsesRecordType reports whether the deployment of a script type applies to a record type,
+// which then has to be given when the script is generated.
+func UsesRecordType(scriptType string) bool {
+	return slices.Contains(recordTypeScripts, scriptType)
+}
+
+// EntryPoints lists the selectable entry points for script types that support entry point selection.
+var EntryPoints = map[string][]string{
+	"mapreduce": {"getInputData", "map", "reduce", "summarize"},
+	"userevent": {"beforeLoad", "beforeSubmit", "afterSubmit"},
+}
+
+// Variants lists the template variants available for script types that support them.
+// The first variant of each list is the default.
+var Variants = map[string][]string{
+	"suitelet": {"generic", "form", "list", "json"},
+	"restlet":  {"generic", "task"},
+	"portlet":  {"html", "list", "links", "form"},
+}
+
+// ResolveVariant validates the requested template variant for a script type.
+func ResolveVariant(scriptType, variant string) (string, error) {
+	available := Variants[scriptType]
+	variant = strings.ToLower(strings.TrimSpace(variant))
+	if variant == "" {
+		return available[0], nil
+	}
+	if !slices.Contains(available, variant) {
+		return "", fmt.Errorf("unknown variant '%s' for %s scripts (available: %s)", variant, scriptType, strings.Join(available, ", "))
+	}
+	return variant, nil
+}
+
+// ResolveEntryPoints validates the requested entry points for a script type and returns them in canonical form.
+// An empty selection returns every entry point of the script type.
+func ResolveEntryPoints(scriptType string, selected []string) ([]string, error) {
+	available := EntryPoints[scriptType]
+	if len(selected) == 0 {
+		return available, nil
+	}
+
+	var result []string
+	for _, name := range selected {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		found := ""
+		for _, entryPoint := range available {
+			if strings.EqualFold(entryPoint, name) {
+				found = entryPoint
+				break
+			}
+		}
+		if found == "" {
+			return nil, fmt.Errorf("unknown entry point '%s' for %s scripts (available: %s)", name, scriptType, strings.Join(available, ", "))
+		}
+		result = append(result, found)
+	}
+
+	if len(result) == 0 {
+		return available, nil
+	}
+
+	if scriptType == "mapreduce" {
+		if !slices.Contains(result, "getInputData") {
+			result = append([]string{"getInputData"}, result...)
+		}
+		if !slices.Contains(result, "map") && !slices.Contains(result, "reduce") {
+			return nil, fmt.Errorf("map/reduce scripts require at least a map or reduce stage")
+		}
+	}
+	return result, nil
+}
+
+// TemplateData holds the data used to render script templates.
+type TemplateData struct {
+	Project      string
+	ProjectName  string
+	Description  string
+	Date         string // date of the script header, in the dateFormat of the project
+	StartDate    string // date of the deployment schedules, always YYYY-MM-DD as SDF requires
+	CompanyName  string
+	UserName     string
+	UserEmail    string
+	ScriptName   string
+	ScriptId     string
+	ScriptPath   string
+	DeploymentId string
+	RecordType   string
+	SearchId     string     // script ID of the companion saved search of massupdate scripts
+	Return       ReturnType // return type of workflowaction scripts, zero when they return nothing
+	ReturnRecord string     // record type returned by workflowaction scripts with a select return type
+	Params       []ScriptParam
+	EntryPoints  []string
+	TypedStages  bool
+	Schedule     DeploymentSchedule
+	Audience     DeploymentAudience
+	DeployStatus string // status of the deployment, empty for the default of the template
+	LogLevel     string // log level of the deployment, empty for the default of the template
+	Variant      string
+	ApiVersion   string
+	AmdConfig    string            // File Cabinet path of the amdconfig.json resolving the module aliases
+	Locale       string            // locale of the project, empty for English
+	Vars         map[string]string // template variables of the project configuration
+}
+
+// HasEntryPoint reports whether the given entry point was selected for generation.
+func (d TemplateData) HasEntryPoint(name string) bool {
+	return slices.Contains(d.EntryPoints, name)
+}
+
+// Set sets the named field of the template data from a string, e.g. ScriptId or TypedStages.
+// The name is matched case-insensitively. Boolean fields take true or false, EntryPoints a
+// comma separated list and Vars.Name sets a template variable.
+func (d *TemplateData) Set(name, value string) error {
+	if field, variable, ok := strings.Cut(name, "."); ok && strings.EqualFold(field, "Vars") && variable != "" {
+		vars := make(map[string]string, len(d.Vars)+1)
+		for k, v := range d.Vars {
+			vars[k] = v
+		}
+		vars[variable] = value
+		d.Vars = vars
+		return nil
+	}
+	v := reflect.ValueOf(d).Elem()
+	field := v.FieldByNameFunc(func(field string) bool { return strings.EqualFold(field, name) })
+	if !field.IsValid() {
+		return fmt.Errorf("unknown template field '%s'", name)
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value '%s' for %s, expected true or false", value, name)
+		}
+		field.SetBool(b)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("template field '%s' cannot be set", name)
+		}
+		var values []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		field.Set(reflect.ValueOf(values))
+	default:
+		return fmt.Errorf("template field '%s' cannot be set", name)
+	}
+	return nil
+}
+
+// Define returns the opening of the define call of a JavaScript script loading the given
+// modules, with N/runtime added when the script has parameters. Each module is passed to the
+// factory as its last path element, e.g. serverWidget for N/ui/serverWidget.
+func (d TemplateData) Define(modules ...string) string {
+	if len(d.Params) > 0 && !slices.Contains(modules, "N/runtime") {
+		modules = append(modules, "N/runtime")
+	}
+	quoted := make([]string, len(modules))
+	names := make([]string, len(modules))
+	for i, module := range modules {
+		quoted[i] = `"` + module + `"`
+		names[i] = path.Base(module)
+	}
+	return fmt.Sprintf("define([%s], (%s) => {", strings.Join(quoted, ", "), strings.Join(names, ", "))
+}
+
+// TestStubData holds the data used to render the unit test stub of a script.
+type TestStubData struct {
+	TemplateData
+	ImportPath string
+}
+
+var underscoresRe = regexp.MustCompile(`_+`)
+
+// SnakeCase converts a string to snake_case.
+func SnakeCase(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	var result strings.Builder
+	runes := []rune(s)
+	prevLower := false
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && prevLower {
+				result.WriteRune('_')
+			}
+			result.WriteRune(unicode.ToLower(r))
+			prevLower = false
+		} else if unicode.IsLower(r) || unicode.IsDigit(r) {
+			result.WriteRune(r)
+			prevLower = true
+		} else {
+			if i > 0 && result.Len() > 0 && result.String()[result.Len()-1] != '_' {
+				result.WriteRune('_')
+			}
+			prevLower = false
+		}
+	}
+
+	snake := underscoresRe.ReplaceAllString(result.String(), "_")
+	snake = strings.Trim(snake, "_")
+
+	return strings.ToLower(snake)
+}
+
+// CamelCase converts a snake_case string to camelCase.
+func CamelCase(s string) string {
+	var result strings.Builder
+	upperNext := false
+	for i, r := range s {
+		if r == '_' || r == '-' || r == ' ' {
+			upperNext = result.Len() > 0
+			continue
+		}
+		if upperNext {
+			result.WriteRune(unicode.ToUpper(r))
+			upperNext = false
+		} else if i == 0 {
+			result.WriteRune(unicode.ToLower(r))
+		} else {
+			result.WriteRune(r)
+		}
+	}
+	return result.String()
+}
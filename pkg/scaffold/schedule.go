@@ -0,0 +1,127 @@
+package scaffold
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DeploymentSchedule describes the recurrence rendered into a scheduled script deployment.
+type DeploymentSchedule struct {
+	Frequency string
+	StartTime string
+	Interval  string
+	Days      []string
+}
+
+// ScheduleFrequencies lists the supported recurrence frequencies.
+var ScheduleFrequencies = []string{"none", "single", "daily", "weekly", "minutes"}
+
+// scheduleWeekdays lists the weekday elements of a weekly recurrence in SDF element order.
+var scheduleWeekdays = []string{"friday", "monday", "saturday", "sunday", "thursday", "tuesday", "wednesday"}
+
+// scheduleIntervals maps the supported repeat intervals in minutes to their SDF duration values.
+var scheduleIntervals = map[string]string{
+	"15":  "PT15M",
+	"30":  "PT30M",
+	"60":  "PT1H",
+	"120": "PT2H",
+	"240": "PT4H",
+	"360": "PT6H",
+	"480": "PT8H",
+	"720": "PT12H",
+}
+
+var scheduleTimeRe = regexp.MustCompile(`^([01]?\d|2[0-3]):([0-5]\d)$`)
+
+// Status returns the deployment status matching the schedule.
+func (s DeploymentSchedule) Status() string {
+	if s.Frequency == "" || s.Frequency == "none" {
+		return "NOTSCHEDULED"
+	}
+	return "SCHEDULED"
+}
+
+// Repeat returns the SDF repeat duration of the schedule.
+func (s DeploymentSchedule) Repeat() string {
+	return scheduleIntervals[s.Interval]
+}
+
+// Time returns the start time in the SDF starttime format.
+func (s DeploymentSchedule) Time() string {
+	if s.StartTime == "" {
+		return "23:00:00Z"
+	}
+	return s.StartTime + ":00Z"
+}
+
+// OnDay reports whether the weekly schedule runs on the given weekday.
+func (s DeploymentSchedule) OnDay(day string) bool {
+	return slices.Contains(s.Days, day)
+}
+
+// Weekdays returns the weekday element names in SDF element order.
+func (s DeploymentSchedule) Weekdays() []string {
+	return scheduleWeekdays
+}
+
+// NewDeploymentSchedule validates the schedule options and returns a deployment schedule.
+func NewDeploymentSchedule(frequency, startTime, days, interval string) (DeploymentSchedule, error) {
+	frequency = strings.ToLower(strings.TrimSpace(frequency))
+	if frequency == "" {
+		frequency = "none"
+	}
+	if !slices.Contains(ScheduleFrequencies, frequency) {
+		return DeploymentSchedule{}, fmt.Errorf("unsupported schedule '%s' (supported: %s)", frequency, strings.Join(ScheduleFrequencies, ", "))
+	}
+
+	schedule := DeploymentSchedule{Frequency: frequency}
+	if frequency == "none" {
+		return schedule, nil
+	}
+
+	startTime = strings.TrimSpace(startTime)
+	if startTime != "" {
+		m := scheduleTimeRe.FindStringSubmatch(startTime)
+		if m == nil {
+			return DeploymentSchedule{}, fmt.Errorf("invalid start time '%s', expected HH:MM", startTime)
+		}
+		schedule.StartTime = fmt.Sprintf("%02s:%s", m[1], m[2])
+	}
+
+	switch frequency {
+	case "weekly":
+		for _, day := range strings.Split(days, ",") {
+			day = strings.ToLower(strings.TrimSpace(day))
+			if day == "" {
+				continue
+			}
+			found := ""
+			for _, weekday := range scheduleWeekdays {
+				if strings.HasPrefix(weekday, day) && len(day) >= 2 {
+					found = weekday
+					break
+				}
+			}
+			if found == "" {
+				return DeploymentSchedule{}, fmt.Errorf("invalid weekday '%s'", day)
+			}
+			schedule.Days = append(schedule.Days, found)
+		}
+		if len(schedule.Days) == 0 {
+			return DeploymentSchedule{}, fmt.Errorf("weekly schedules require at least one day")
+		}
+	case "minutes":
+		interval = strings.TrimSpace(interval)
+		if interval == "" {
+			interval = "15"
+		}
+		if _, ok := scheduleIntervals[interval]; !ok {
+			return DeploymentSchedule{}, fmt.Errorf("unsupported interval '%s' (supported: 15, 30, 60, 120, 240, 360, 480, 720)", interval)
+		}
+		schedule.Interval = interval
+	}
+
+	return schedule, nil
+}
@@ -0,0 +1,127 @@
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"netsuite-cli/pkg/config"
+)
+
+//go:embed templates/*
+var templateFS embed.FS
+
+// TemplateError reports a template that cannot be read, parsed or rendered. Template names the
+// template or the file rendered from it.
+type TemplateError struct {
+	Template string
+	Err      error
+}
+
+func (e *TemplateError) Error() string {
+	return fmt.Sprintf("error rendering %s: %v", e.Template, e.Err)
+}
+func (e *TemplateError) Unwrap() error { return e.Err }
+
+// Embedded returns the content of an embedded template, ignoring any override.
+func Embedded(name string) ([]byte, error) {
+	return templateFS.ReadFile("templates/" + name)
+}
+
+// Overridable lists the embedded templates that can be overridden, in the order of the script
+// types followed by the object and test templates.
+func Overridable() []string {
+	var names []string
+	for _, t := range ScriptTypes {
+		for _, ext := range []string{".ts.tmpl", ".js.tmpl", ".xml.tmpl"} {
+			if _, err := Embedded(t.Name + ext); err == nil {
+				names = append(names, t.Name+ext)
+			}
+		}
+	}
+	return append(names, "customfield.xml.tmpl", "customrecord.xml.tmpl", "savedsearch.xml.tmpl",
+		"workflow.xml.tmpl", "test.ts.tmpl", "mock.ts.tmpl", "partials.tmpl")
+}
+
+// Templates reads the script templates, preferring the overrides found in Dirs, in order of
+// precedence, over the embedded templates.
+type Templates struct {
+	Dirs []string
+	// Dates formats the dates of the now function.
+	Dates config.Dates
+	// Logf, when set, reports the file each template is read from.
+	Logf func(format string, args ...any)
+}
+
+// Locate returns the path of the override used for the named template, or an empty string
+// when the embedded template is used.
+func (t Templates) Locate(name string) string {
+	for _, dir := range t.Dirs {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// Read returns the content of the named template.
+func (t Templates) Read(name string) ([]byte, error) {
+	for _, dir := range t.Dirs {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err == nil {
+			t.logf("template %s: %s", name, filepath.Join(dir, name))
+			return content, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, &TemplateError{Template: filepath.Join(dir, name), Err: err}
+		}
+	}
+	content, err := Embedded(name)
+	if err != nil {
+		return nil, &TemplateError{Template: name, Err: err}
+	}
+	t.logf("template %s: embedded", name)
+	return content, nil
+}
+
+// logf reports through Logf, if set.
+func (t Templates) logf(format string, args ...any) {
+	if t.Logf != nil {
+		t.Logf(format, args...)
+	}
+}
+
+// Render executes a template with data, with the templates of partials.tmpl and the functions of
+// Funcs available to it. path names the generated file in errors.
+func (t Templates) Render(path, tmplStr string, data any) ([]byte, error) {
+	tmpl, err := template.New("script").Funcs(Funcs(t.Dates)).Parse(tmplStr)
+	if err != nil {
+		return nil, &TemplateError{Template: path, Err: err}
+	}
+
+	// The embedded partials are parsed before any override, so overrides written for an older
+	// version still find the partials added since.
+	embedded, err := Embedded("partials.tmpl")
+	if err != nil {
+		return nil, &TemplateError{Template: "partials.tmpl", Err: err}
+	}
+	partials, err := t.Read("partials.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	for _, content := range [][]byte{embedded, partials} {
+		if _, err := tmpl.New("partials").Parse(string(content)); err != nil {
+			return nil, &TemplateError{Template: "partials.tmpl", Err: err}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, &TemplateError{Template: path, Err: err}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,67 @@
+package scaffold
+
+import (
+	"errors"
+	"strings"
+
+	"netsuite-cli/pkg/config"
+)
+
+// Workflow describes a workflow to generate.
+type Workflow struct {
+	Name        string // workflow name, e.g. approve_order
+	Description string // empty for "<name> description"
+	RecordType  string // record type the workflow runs on, e.g. SALESORDER
+	// ActionName is the script name of the workflow action script called on entry of the start
+	// state, empty for none. The script itself is generated with Script.
+	ActionName string
+}
+
+// ScriptId returns the script ID of the workflow, customworkflow_<prefix>_<name>.
+func (w Workflow) ScriptId(c *config.Project) (string, error) {
+	return c.ObjectId("customworkflow", w.Name)
+}
+
+// WorkflowData holds the data used to render the workflow template.
+type WorkflowData struct {
+	ScriptId       string
+	Name           string
+	Description    string
+	RecordType     string
+	ActionName     string
+	ActionScriptId string
+}
+
+// Workflow renders the object XML of a workflow with a start state, an end state and a
+// transition between them.
+func (g *Generator) Workflow(w Workflow) (File, error) {
+	if w.Name == "" {
+		return File{}, errors.New("workflow name is required")
+	}
+	if w.RecordType == "" {
+		return File{}, errors.New("record type is required for workflows")
+	}
+	scriptId, err := w.ScriptId(g.Config)
+	if err != nil {
+		return File{}, err
+	}
+
+	data := WorkflowData{
+		ScriptId:    scriptId,
+		Name:        w.Name,
+		Description: w.Description,
+		RecordType:  strings.ToUpper(w.RecordType),
+	}
+	if data.Description == "" {
+		data.Description = w.Name + " description"
+	}
+	if w.ActionName != "" {
+		naming, err := g.Config.ScriptNaming(w.ActionName, "workflowaction")
+		if err != nil {
+			return File{}, err
+		}
+		data.ActionName = config.ToScriptId(w.ActionName)
+		data.ActionScriptId = naming.ScriptId
+	}
+	return g.object("workflow", "workflow.xml.tmpl", scriptId, data)
+}